@@ -19,10 +19,15 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"net"
+	"sort"
 	"time"
 
 	"google.golang.org/grpc"
+	core_v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
 
 	agent_v1 "github.com/intel/cri-resource-manager/pkg/agent/api/v1"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/sockets"
@@ -31,6 +36,8 @@ import (
 
 func main() {
 	socket := flag.String("agent-socket", sockets.ResourceManagerAgent, "Unix domain socket where agent is serving")
+	dryRun := flag.Bool("dry-run", false, "Check what the current configuration would change in cri-resmgr")
+	dryRunFile := flag.String("dry-run-config", "", "Check what the ConfigMap in the given file would change in cri-resmgr")
 
 	// Disable logger buffering and make sure that everything has been flushed
 	// when program exits
@@ -64,4 +71,69 @@ func main() {
 		log.Fatal("health check negative: %s", rpl.Error)
 	}
 	log.Info("Health check OK")
+	if rpl.ConfigState != "" {
+		if rpl.ConfigUpdated != 0 {
+			updated := time.Unix(rpl.ConfigUpdated, 0)
+			log.Info("Configuration is %s, last received at %s", rpl.ConfigState, updated)
+		} else {
+			log.Info("Configuration is %s", rpl.ConfigState)
+		}
+	}
+
+	if *dryRun || *dryRunFile != "" {
+		if err := dryRunConfig(cli, *dryRunFile); err != nil {
+			log.Fatal("%v", err)
+		}
+	}
+}
+
+// dryRunConfig asks the agent to dry-run configuration and prints the result.
+func dryRunConfig(cli agent_v1.AgentClient, path string) error {
+	req := &agent_v1.DryRunConfigRequest{}
+	if path != "" {
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read ConfigMap %q: %v", path, err)
+		}
+		cm := &core_v1.ConfigMap{}
+		if err := yaml.Unmarshal(raw, cm); err != nil {
+			return fmt.Errorf("failed to parse ConfigMap %q: %v", path, err)
+		}
+		if len(cm.Data) == 0 {
+			return fmt.Errorf("no configuration data found in ConfigMap %q", path)
+		}
+		req.Config = cm.Data
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rpl, err := cli.DryRunConfig(ctx, req)
+	if err != nil {
+		return fmt.Errorf("dry-run failed: %v", err)
+	}
+
+	modules := make([]string, 0, len(rpl.Diff))
+	for module := range rpl.Diff {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	if len(modules) == 0 {
+		fmt.Printf("No changes.\n")
+	}
+	for _, module := range modules {
+		fmt.Printf("%s:\n", module)
+		for _, c := range rpl.Diff[module].Changes {
+			fmt.Printf("  %s: %s => %s\n", c.Field, c.Old, c.New)
+		}
+	}
+	for _, e := range rpl.Errors {
+		fmt.Printf("error: %s\n", e)
+	}
+	if len(rpl.Errors) > 0 {
+		return fmt.Errorf("configuration is invalid")
+	}
+
+	return nil
 }
@@ -0,0 +1,328 @@
+/*
+Copyright 2020 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+
+	ctl_v1 "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/ctl/api/v1"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/sockets"
+	"github.com/intel/cri-resource-manager/pkg/log"
+)
+
+const usage = `Usage: %s [options] <command> [arguments]
+
+Commands:
+  containers                     list containers with their cpuset, memset, pool and classes
+  pools                          show the utilization of the pools of the active policy
+  explain <container>            explain the placement of a container
+  rebalance                      trigger a rebalancing of containers
+  debug on|off <source>...       enable or disable debug logging for logger sources
+  tags <container> [key=value|-key]...
+                                 get, set, or delete (-key) container tags
+
+Containers can be given by ID, unique ID prefix, pod/container, or namespace/pod/container.
+
+Options:
+`
+
+// command is a single cri-resmgr-ctl command.
+type command func(ctx context.Context, cli ctl_v1.ControlClient, args []string) error
+
+var commands = map[string]command{
+	"containers": listContainers,
+	"pools":      showPools,
+	"explain":    explainPlacement,
+	"rebalance":  rebalance,
+	"debug":      setDebug,
+	"tags":       containerTags,
+}
+
+func main() {
+	socket := flag.String("control-socket", sockets.ResourceManagerControl, "Unix domain socket where cri-resmgr is serving control requests")
+	timeout := flag.Duration("timeout", 10*time.Second, "Timeout for requests to cri-resmgr")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), usage, os.Args[0])
+		flag.PrintDefaults()
+	}
+
+	// Disable logger buffering and make sure that everything has been flushed
+	// when program exits
+	log.Flush()
+	defer log.Flush()
+
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(flag.CommandLine.Output(), "unknown command %q\n", flag.Arg(0))
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Try to connect to cri-resmgr
+	dialOpts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(sock string, timeout time.Duration) (net.Conn, error) {
+			return net.Dial("unix", sock)
+		}),
+	}
+	conn, err := grpc.Dial(*socket, dialOpts...)
+	if err != nil {
+		log.Fatal("failed to connect to cri-resmgr: %v", err)
+	}
+	defer conn.Close()
+	cli := ctl_v1.NewControlClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if err := cmd(ctx, cli, flag.Args()[1:]); err != nil {
+		log.Fatal("%s: %v", flag.Arg(0), err)
+	}
+}
+
+// listContainers lists containers with their resource assignments.
+func listContainers(ctx context.Context, cli ctl_v1.ControlClient, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("unexpected arguments %v", args)
+	}
+
+	rpl, err := cli.ListContainers(ctx, &ctl_v1.ListContainersRequest{})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tCONTAINER\tID\tSTATE\tPOOL\tCPUSET\tMEMSET\tRDT\tBLOCKIO")
+	for _, c := range rpl.Containers {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Namespace, c.Pod, c.Name, shortID(c.Id), shortState(c.State), orDash(c.Pool),
+			orDash(c.Cpuset), orDash(c.Memset), orDash(c.RdtClass), orDash(c.BlockioClass))
+	}
+	return w.Flush()
+}
+
+// showPools shows the utilization of pools.
+func showPools(ctx context.Context, cli ctl_v1.ControlClient, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("unexpected arguments %v", args)
+	}
+
+	rpl, err := cli.GetPoolUsage(ctx, &ctl_v1.GetPoolUsageRequest{})
+	if err != nil {
+		return err
+	}
+	if rpl.Error != "" {
+		return fmt.Errorf("%s", rpl.Error)
+	}
+
+	fmt.Printf("Active policy: %s\n\n", rpl.Policy)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "POOL\tPARENT\tCPUS\tMEMORY\tCONTAINERS\tCPU REQUEST\tCPU USAGE\tEXCLUSIVE\tMEMORY REQUEST")
+	for _, p := range rpl.Pools {
+		usage := "-"
+		if p.CpuCapacity > 0 {
+			usage = fmt.Sprintf("%.1f%%", 100.0*float64(p.CpuRequest)/float64(p.CpuCapacity))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%dm/%dm\t%s\t%s\t%s\n",
+			p.Name, orDash(p.Parent), orDash(p.Cpus), orDash(p.Memory), p.Containers,
+			p.CpuRequest, p.CpuCapacity, usage, orDash(p.ExclusiveCpus), memorySize(p.MemoryRequest))
+	}
+	return w.Flush()
+}
+
+// explainPlacement explains the placement of a container.
+func explainPlacement(ctx context.Context, cli ctl_v1.ControlClient, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single container, got %v", args)
+	}
+
+	rpl, err := cli.ExplainPlacement(ctx, &ctl_v1.ExplainPlacementRequest{Container: args[0]})
+	if err != nil {
+		return err
+	}
+	if rpl.Error != "" {
+		return fmt.Errorf("%s", rpl.Error)
+	}
+
+	fmt.Printf("Container %s (%s):\n", rpl.Container.Name, rpl.Container.Id)
+	for _, line := range rpl.Explanation {
+		fmt.Printf("  %s\n", line)
+	}
+	return nil
+}
+
+// rebalance triggers a rebalancing of containers.
+func rebalance(ctx context.Context, cli ctl_v1.ControlClient, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("unexpected arguments %v", args)
+	}
+
+	rpl, err := cli.Rebalance(ctx, &ctl_v1.RebalanceRequest{})
+	if err != nil {
+		return err
+	}
+	if rpl.Error != "" {
+		return fmt.Errorf("%s", rpl.Error)
+	}
+
+	fmt.Println("Containers rebalanced.")
+	return nil
+}
+
+// setDebug enables or disables debug logging for logger sources.
+func setDebug(ctx context.Context, cli ctl_v1.ControlClient, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("expected on|off and at least one logger source")
+	}
+
+	req := &ctl_v1.SetDebugRequest{Sources: args[1:]}
+	switch args[0] {
+	case "on":
+		req.Enable = true
+	case "off":
+		req.Enable = false
+	default:
+		return fmt.Errorf("expected on or off, got %q", args[0])
+	}
+
+	rpl, err := cli.SetDebug(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range req.Sources {
+		fmt.Printf("%s: debug %s (was %s)\n", source, args[0], onOff(rpl.Previous[source]))
+	}
+	return nil
+}
+
+// containerTags gets, sets or deletes container tags.
+func containerTags(ctx context.Context, cli ctl_v1.ControlClient, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("expected a container")
+	}
+
+	var tags map[string]string
+	if len(args) == 1 {
+		rpl, err := cli.GetContainerTags(ctx, &ctl_v1.GetContainerTagsRequest{Container: args[0]})
+		if err != nil {
+			return err
+		}
+		if rpl.Error != "" {
+			return fmt.Errorf("%s", rpl.Error)
+		}
+		tags = rpl.Tags
+	} else {
+		req := &ctl_v1.SetContainerTagsRequest{
+			Container: args[0],
+			Set:       make(map[string]string),
+		}
+		for _, arg := range args[1:] {
+			switch {
+			case strings.HasPrefix(arg, "-"):
+				req.Delete = append(req.Delete, arg[1:])
+			case strings.Contains(arg, "="):
+				kv := strings.SplitN(arg, "=", 2)
+				req.Set[kv[0]] = kv[1]
+			default:
+				return fmt.Errorf("invalid tag %q, expected key=value or -key", arg)
+			}
+		}
+		rpl, err := cli.SetContainerTags(ctx, req)
+		if err != nil {
+			return err
+		}
+		if rpl.Error != "" {
+			return fmt.Errorf("%s", rpl.Error)
+		}
+		tags = rpl.Tags
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", key, tags[key])
+	}
+	return nil
+}
+
+// shortID shortens a container ID for display.
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// shortState strips the common prefix of container states.
+func shortState(state string) string {
+	return strings.TrimPrefix(state, "CONTAINER_")
+}
+
+// orDash returns the given value, or "-" if it is empty.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// onOff returns "on" for true and "off" for false.
+func onOff(value bool) string {
+	if value {
+		return "on"
+	}
+	return "off"
+}
+
+// memorySize formats a memory size for display.
+func memorySize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d", bytes)
+	}
+	value, suffix := float64(bytes), ""
+	for _, s := range []string{"Ki", "Mi", "Gi", "Ti"} {
+		if value < unit {
+			break
+		}
+		value /= unit
+		suffix = s
+	}
+	return fmt.Sprintf("%.1f%s", value, suffix)
+}
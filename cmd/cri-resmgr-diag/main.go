@@ -0,0 +1,86 @@
+/*
+Copyright 2020 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	config_v1 "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config/api/v1"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/sockets"
+	"github.com/intel/cri-resource-manager/pkg/log"
+)
+
+const (
+	// maxBundleSize is the maximum size of a diagnostics bundle we accept.
+	maxBundleSize = 256 * 1024 * 1024
+)
+
+func main() {
+	socket := flag.String("config-socket", sockets.ResourceManagerConfig, "Unix domain socket where cri-resmgr is serving configuration requests")
+	output := flag.String("output", "", "File to write the diagnostics bundle to (default: cri-resmgr-diag-<timestamp>.tar.gz)")
+	redact := flag.Bool("redact", false, "Leave out container commands, environment, mounts, and values of foreign labels and annotations")
+	timeout := flag.Duration("timeout", 30*time.Second, "Timeout for collecting diagnostics")
+
+	// Disable logger buffering and make sure that everything has been flushed
+	// when program exits
+	log.Flush()
+	defer log.Flush()
+
+	flag.Parse()
+
+	if *output == "" {
+		*output = "cri-resmgr-diag-" + time.Now().Format("20060102-150405") + ".tar.gz"
+	}
+
+	// Try to connect to cri-resmgr
+	dialOpts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(sock string, timeout time.Duration) (net.Conn, error) {
+			return net.Dial("unix", sock)
+		}),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxBundleSize)),
+	}
+	conn, err := grpc.Dial(*socket, dialOpts...)
+	if err != nil {
+		log.Fatal("failed to connect to cri-resmgr: %v", err)
+	}
+	defer conn.Close()
+	cli := config_v1.NewConfigClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	rpl, err := cli.GetDiagnostics(ctx, &config_v1.GetDiagnosticsRequest{Redact: *redact})
+	if err != nil {
+		log.Fatal("failed to collect diagnostics: %v", err)
+	}
+	if rpl.Error != "" {
+		log.Fatal("failed to collect diagnostics: %s", rpl.Error)
+	}
+
+	if err := ioutil.WriteFile(*output, rpl.Bundle, 0600); err != nil {
+		log.Fatal("failed to write diagnostics to %q: %v", *output, err)
+	}
+
+	log.Info("diagnostics written to %s", *output)
+}
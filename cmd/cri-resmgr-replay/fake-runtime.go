@@ -0,0 +1,164 @@
+/*
+Copyright 2020 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"reflect"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/intel/cri-resource-manager/pkg/dump"
+)
+
+const (
+	// runtimeService is the gRPC method prefix of the CRI runtime service.
+	runtimeService = "/runtime.v1alpha2.RuntimeService/"
+	// imageService is the gRPC method prefix of the CRI image service.
+	imageService = "/runtime.v1alpha2.ImageService/"
+)
+
+// methodTypes are the request and reply types of a CRI method.
+type methodTypes struct {
+	req reflect.Type
+	rpl reflect.Type
+}
+
+// criMethods maps full CRI method names to their request and reply types.
+var criMethods = map[string]*methodTypes{}
+
+// newRequest creates a new request for the given CRI method.
+func (t *methodTypes) newRequest() interface{} {
+	return reflect.New(t.req.Elem()).Interface()
+}
+
+// newReply creates a new reply for the given CRI method.
+func (t *methodTypes) newReply() interface{} {
+	return reflect.New(t.rpl.Elem()).Interface()
+}
+
+// discoverMethods discovers the request and reply types of a CRI service.
+func discoverMethods(prefix string, service reflect.Type) {
+	for i := 0; i < service.NumMethod(); i++ {
+		m := service.Method(i)
+		if m.Type.NumIn() != 2 || m.Type.NumOut() != 2 {
+			continue
+		}
+		criMethods[prefix+m.Name] = &methodTypes{
+			req: m.Type.In(1),
+			rpl: m.Type.Out(0),
+		}
+	}
+}
+
+// fakeRuntime is a fake CRI runtime serving replies from a recording.
+type fakeRuntime struct {
+	sync.Mutex
+	socket  string
+	server  *grpc.Server
+	pending map[string][]*dump.Record
+}
+
+// newFakeRuntime creates a fake CRI runtime for the given socket.
+func newFakeRuntime(socket string) *fakeRuntime {
+	f := &fakeRuntime{
+		socket:  socket,
+		pending: map[string][]*dump.Record{},
+	}
+	f.server = grpc.NewServer(grpc.UnknownServiceHandler(f.handle))
+	return f
+}
+
+// Start starts serving CRI requests.
+func (f *fakeRuntime) Start() error {
+	if err := os.Remove(f.socket); err != nil && !os.IsNotExist(err) {
+		return replayError("failed to remove stale socket %q: %v", f.socket, err)
+	}
+	l, err := net.Listen("unix", f.socket)
+	if err != nil {
+		return replayError("failed to create fake runtime socket %q: %v", f.socket, err)
+	}
+	go f.server.Serve(l)
+	return nil
+}
+
+// Stop stops serving CRI requests.
+func (f *fakeRuntime) Stop() {
+	f.server.Stop()
+}
+
+// Expect queues a recorded reply for the next call of the recorded method.
+func (f *fakeRuntime) Expect(rec *dump.Record) {
+	f.Lock()
+	defer f.Unlock()
+	f.pending[rec.Method] = append(f.pending[rec.Method], rec)
+}
+
+// next dequeues the next recorded reply for the given method, if any.
+func (f *fakeRuntime) next(method string) *dump.Record {
+	f.Lock()
+	defer f.Unlock()
+	q := f.pending[method]
+	if len(q) == 0 {
+		return nil
+	}
+	f.pending[method] = q[1:]
+	return q[0]
+}
+
+// handle serves a CRI request with a recorded reply, or an empty one.
+func (f *fakeRuntime) handle(_ interface{}, stream grpc.ServerStream) error {
+	method, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "failed to determine method")
+	}
+	t, ok := criMethods[method]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+
+	req := t.newRequest()
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+
+	rpl := t.newReply()
+	if rec := f.next(method); rec != nil {
+		if rec.Error != nil {
+			return status.Error(codes.Code(rec.Error.Code), rec.Error.Message)
+		}
+		if len(rec.Reply) > 0 {
+			if err := json.Unmarshal(rec.Reply, rpl); err != nil {
+				return status.Errorf(codes.Internal, "invalid recorded reply: %v", err)
+			}
+		}
+	}
+
+	return stream.SendMsg(rpl)
+}
+
+func init() {
+	discoverMethods(runtimeService, reflect.TypeOf((*api.RuntimeServiceServer)(nil)).Elem())
+	discoverMethods(imageService, reflect.TypeOf((*api.ImageServiceServer)(nil)).Elem())
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2020 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/cri-resource-manager/pkg/cgroups"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	"github.com/intel/cri-resource-manager/pkg/dump"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+	"github.com/intel/cri-resource-manager/pkg/sysfs"
+)
+
+var log = logger.Default()
+
+// allocation is the resulting resource allocation of a single container.
+type allocation struct {
+	Namespace    string `json:"namespace"`
+	Pod          string `json:"pod"`
+	Container    string `json:"container"`
+	ID           string `json:"id"`
+	QOSClass     string `json:"qosClass"`
+	CpusetCpus   string `json:"cpusetCpus"`
+	CpusetMems   string `json:"cpusetMems"`
+	CPUShares    int64  `json:"cpuShares"`
+	CPUQuota     int64  `json:"cpuQuota"`
+	MemoryLimit  int64  `json:"memoryLimit"`
+	RDTClass     string `json:"rdtClass,omitempty"`
+	BlockIOClass string `json:"blockioClass,omitempty"`
+}
+
+// summary summarizes a replay.
+type summary struct {
+	Replayed    int           `json:"replayed"`
+	Skipped     int           `json:"skipped"`
+	Mismatches  int           `json:"mismatches"`
+	Allocations []*allocation `json:"allocations"`
+}
+
+func main() {
+	recording := flag.String("recording", "", "JSON recording of CRI requests to replay.")
+	sysRoot := flag.String("sysfs", "", "fake sysfs root directory to discover hardware from.")
+	workDir := flag.String("work-dir", "", "directory for sockets and state, a temporary one if omitted.")
+	asJSON := flag.Bool("json", false, "report allocations as JSON.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(),
+			"usage: %s -recording <file> [-sysfs <dir>] [-force-config <file>] [options]\n",
+			os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *recording == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	records, err := dump.ReadRecordFile(*recording)
+	if err != nil {
+		log.Fatal("%v", err)
+	}
+
+	dir := *workDir
+	if dir == "" {
+		if dir, err = ioutil.TempDir("", "cri-resmgr-replay-"); err != nil {
+			log.Fatal("failed to create work directory: %v", err)
+		}
+		defer os.RemoveAll(dir)
+	}
+	if err := setupEnvironment(dir, *sysRoot); err != nil {
+		log.Fatal("%v", err)
+	}
+
+	runtime := newFakeRuntime(filepath.Join(dir, "runtime.sock"))
+	if err := runtime.Start(); err != nil {
+		log.Fatal("%v", err)
+	}
+	defer runtime.Stop()
+
+	m, err := resmgr.NewResourceManager()
+	if err != nil {
+		log.Fatal("failed to create resource manager instance: %v", err)
+	}
+	if err := m.Start(); err != nil {
+		log.Fatal("failed to start resource manager: %v", err)
+	}
+
+	sum, err := replay(filepath.Join(dir, "relay.sock"), runtime, records)
+	m.Stop()
+	dump.Sync()
+	logger.Flush()
+	if err != nil {
+		log.Fatal("%v", err)
+	}
+
+	if sum.Allocations, err = collectAllocations(filepath.Join(dir, "relay")); err != nil {
+		log.Fatal("%v", err)
+	}
+
+	if *asJSON {
+		raw, _ := json.MarshalIndent(sum, "", "  ")
+		fmt.Println(string(raw))
+	} else {
+		printSummary(sum)
+	}
+}
+
+// setupEnvironment points the resource manager to our fake runtime, sysfs and cgroupfs.
+func setupEnvironment(dir, sysRoot string) error {
+	set := map[string]string{
+		"runtime-socket": filepath.Join(dir, "runtime.sock"),
+		"image-socket":   filepath.Join(dir, "runtime.sock"),
+		"relay-socket":   filepath.Join(dir, "relay.sock"),
+		"relay-dir":      filepath.Join(dir, "relay"),
+		"agent-socket":   filepath.Join(dir, "agent.sock"),
+		"config-socket":  filepath.Join(dir, "config.sock"),
+	}
+
+	explicit := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	if !explicit["cgroup-mount"] {
+		cgroupDir := filepath.Join(dir, "cgroup")
+		if err := os.MkdirAll(cgroupDir, 0755); err != nil {
+			return replayError("failed to create fake cgroupfs %q: %v", cgroupDir, err)
+		}
+		cgroups.SetMountDir(cgroupDir)
+	}
+	for name, value := range set {
+		if err := flag.Set(name, value); err != nil {
+			return replayError("failed to set option %s to %q: %v", name, value, err)
+		}
+	}
+
+	sysfs.SetSysRoot(sysRoot)
+
+	return nil
+}
+
+// replay replays the recorded requests through the resource manager.
+func replay(socket string, runtime *fakeRuntime, records []*dump.Record) (*summary, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	conn, err := grpc.DialContext(ctx, socket, grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", addr)
+		}))
+	cancel()
+	if err != nil {
+		return nil, replayError("failed to connect to relay socket %q: %v", socket, err)
+	}
+	defer conn.Close()
+
+	sum := &summary{}
+	for idx, rec := range records {
+		t, ok := criMethods[rec.Method]
+		if !ok {
+			log.Warn("#%d: skipping unknown method %s", idx, rec.Method)
+			sum.Skipped++
+			continue
+		}
+
+		req := t.newRequest()
+		if len(rec.Request) > 0 {
+			if err := json.Unmarshal(rec.Request, req); err != nil {
+				log.Warn("#%d: skipping %s with invalid request: %v", idx, rec.Method, err)
+				sum.Skipped++
+				continue
+			}
+		}
+
+		runtime.Expect(rec)
+		err := conn.Invoke(context.Background(), rec.Method, req, t.newReply())
+		sum.Replayed++
+
+		if !sameResult(rec, err) {
+			log.Warn("#%d: %s result differs from recording: %v", idx, rec.Method, err)
+			sum.Mismatches++
+		}
+	}
+
+	return sum, nil
+}
+
+// sameResult checks if a replayed call succeeded or failed the same way as recorded.
+func sameResult(rec *dump.Record, err error) bool {
+	if rec.Error == nil {
+		return err == nil
+	}
+	return err != nil && uint32(status.Code(err)) == rec.Error.Code
+}
+
+// collectAllocations collects container allocations from the saved resource manager cache.
+func collectAllocations(dir string) ([]*allocation, error) {
+	cch, err := cache.NewCache(cache.Options{CacheDir: dir})
+	if err != nil {
+		return nil, replayError("failed to load cache from %q: %v", dir, err)
+	}
+
+	allocations := []*allocation{}
+	for _, c := range cch.GetContainers() {
+		a := &allocation{
+			Namespace:    c.GetNamespace(),
+			Container:    c.GetName(),
+			ID:           c.GetID(),
+			QOSClass:     string(c.GetQOSClass()),
+			CpusetCpus:   c.GetCpusetCpus(),
+			CpusetMems:   c.GetCpusetMems(),
+			CPUShares:    c.GetCPUShares(),
+			CPUQuota:     c.GetCPUQuota(),
+			MemoryLimit:  c.GetMemoryLimit(),
+			RDTClass:     c.GetRDTClass(),
+			BlockIOClass: c.GetBlockIOClass(),
+		}
+		if pod, ok := c.GetPod(); ok {
+			a.Pod = pod.GetName()
+		}
+		allocations = append(allocations, a)
+	}
+	sort.Slice(allocations, func(i, j int) bool {
+		ai, aj := allocations[i], allocations[j]
+		if ai.Namespace != aj.Namespace {
+			return ai.Namespace < aj.Namespace
+		}
+		if ai.Pod != aj.Pod {
+			return ai.Pod < aj.Pod
+		}
+		return ai.Container < aj.Container
+	})
+
+	return allocations, nil
+}
+
+// printSummary prints a human-readable summary of the replay.
+func printSummary(sum *summary) {
+	fmt.Printf("replayed %d requests (%d skipped, %d with differing results)\n",
+		sum.Replayed, sum.Skipped, sum.Mismatches)
+	for _, a := range sum.Allocations {
+		fmt.Printf("%s/%s:%s (%s):\n", a.Namespace, a.Pod, a.Container, a.QOSClass)
+		fmt.Printf("    cpuset: cpus %q, mems %q\n", a.CpusetCpus, a.CpusetMems)
+		fmt.Printf("    cpu: shares %d, quota %d; memory limit: %d\n",
+			a.CPUShares, a.CPUQuota, a.MemoryLimit)
+		if a.RDTClass != "" || a.BlockIOClass != "" {
+			fmt.Printf("    rdt class: %q, blockio class: %q\n", a.RDTClass, a.BlockIOClass)
+		}
+	}
+}
+
+// replayError returns a formatted replay-specific error.
+func replayError(format string, args ...interface{}) error {
+	return fmt.Errorf("replay: "+format, args...)
+}
@@ -31,6 +31,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/yaml"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	topologyaware "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/policy/builtin/topology-aware"
 )
 
 type jsonPatch struct {
@@ -71,8 +74,18 @@ func stringify(r interface{}) string {
 	return string(out)
 }
 
-// Handle HTTP requests
+// Handle HTTP requests for mutating pods
 func handle(w http.ResponseWriter, r *http.Request) {
+	serve(w, r, mutatePodObject)
+}
+
+// Handle HTTP requests for validating pods
+func handleValidate(w http.ResponseWriter, r *http.Request) {
+	serve(w, r, validatePodObject)
+}
+
+// Handle HTTP requests using the given handler for Pod objects
+func serve(w http.ResponseWriter, r *http.Request, podHandler func(*admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse) {
 	var body []byte
 	if r.Body != nil {
 		if data, err := ioutil.ReadAll(r.Body); err == nil {
@@ -107,7 +120,7 @@ func handle(w http.ResponseWriter, r *http.Request) {
 			case "pods":
 				arRsp.Kind = "AdmissionReview"
 				arRsp.APIVersion = "admission.k8s.io/v1"
-				arRsp.Response = mutatePodObject(&arReq.Request.Object)
+				arRsp.Response = podHandler(arReq.Request)
 			default:
 				arRsp.Response = errResponse(fmt.Errorf("Unexpected resource %s", arReq.Request.Resource))
 			}
@@ -127,12 +140,25 @@ func handle(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Handle AdmissionReview requests for Pod objects
-func mutatePodObject(rawObj *runtime.RawExtension) *admissionv1.AdmissionResponse {
-	pod := corev1.Pod{}
+// Decode the Pod object of an AdmissionReview request
+func decodePodObject(req *admissionv1.AdmissionRequest) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
 	deserializer := codecs.UniversalDeserializer()
-	if _, _, err := deserializer.Decode(rawObj.Raw, nil, &pod); err != nil {
+	if _, _, err := deserializer.Decode(req.Object.Raw, nil, pod); err != nil {
 		log.Printf("ERROR: failed to deserialize Pod object: %v", err)
+		return nil, err
+	}
+	// Pods created by controllers only get their namespace from the request
+	if pod.Namespace == "" {
+		pod.Namespace = req.Namespace
+	}
+	return pod, nil
+}
+
+// Handle AdmissionReview requests for Pod objects
+func mutatePodObject(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	pod, err := decodePodObject(req)
+	if err != nil {
 		return errResponse(err)
 	}
 
@@ -145,11 +171,14 @@ func mutatePodObject(rawObj *runtime.RawExtension) *admissionv1.AdmissionRespons
 		patches = append(patches, jsonPatch{Op: "add", Path: "/metadata/annotations", Value: map[string]string{}})
 	}
 
-	patch, err := patchResourceAnnotation(&pod)
+	patch, err := patchResourceAnnotation(pod)
 	if err != nil {
 		return errResponse(err)
 	}
 	patches = append(patches, patch)
+	if req.Operation == admissionv1.Create {
+		patches = append(patches, patchDefaultAnnotations(pod)...)
+	}
 
 	reviewResponse.Patch, err = json.Marshal(patches)
 	if err != nil {
@@ -162,6 +191,58 @@ func mutatePodObject(rawObj *runtime.RawExtension) *admissionv1.AdmissionRespons
 	return &reviewResponse
 }
 
+// Handle AdmissionReview requests for validating Pod objects
+func validatePodObject(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	pod, err := decodePodObject(req)
+	if err != nil {
+		return errResponse(err)
+	}
+
+	reviewResponse := admissionv1.AdmissionResponse{}
+	reviewResponse.Allowed = true
+
+	if err := validateAnnotations(pod); err != nil {
+		log.Printf("rejecting Pod %s/%s: %v", pod.Namespace, podName(pod), err)
+		reviewResponse.Allowed = false
+		reviewResponse.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		}
+	}
+
+	return &reviewResponse
+}
+
+// Check the cri-resource-manager annotations of a Pod using the parsers of the node
+func validateAnnotations(pod *corev1.Pod) error {
+	containers := []string{}
+	for _, container := range pod.Spec.InitContainers {
+		containers = append(containers, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, container.Name)
+	}
+
+	if err := cache.ValidatePodAnnotations(pod.Name, pod.Annotations, containers); err != nil {
+		return err
+	}
+	if err := topologyaware.ValidatePodAnnotations(pod.Annotations, containers); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Get a name for a Pod which might not have one yet
+func podName(pod *corev1.Pod) string {
+	if pod.Name != "" {
+		return pod.Name
+	}
+	return pod.GenerateName + "<generated>"
+}
+
 // Create a Pod (JSON) patch adding resource annotation
 func patchResourceAnnotation(pod *corev1.Pod) (jsonPatch, error) {
 	patch := jsonPatch{Op: "add", Path: "/metadata/annotations/intel.com~1resources"}
@@ -28,6 +28,7 @@ func parseArgs() args {
 	flag.IntVar(&args.port, "port", 443, "Port on which to listen for connections")
 	flag.StringVar(&args.certFile, "cert-file", "", "x509 certificate used for authenticating connections")
 	flag.StringVar(&args.keyFile, "key-file", "", "Private x509 key matching --cert-file")
+	flag.StringVar(&args.rulesFile, "annotation-rules", "", "File with rules for injecting default annotations, empty string disables injection")
 
 	flag.Parse()
 
@@ -0,0 +1,268 @@
+/*
+Copyright 2020 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/intel/cri-resource-manager/pkg/apis/resmgr"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/kubernetes"
+)
+
+// Key for evaluating pod annotations in rule expressions
+const keyAnnotations = "annotations"
+
+// A rule for injecting default annotations to matching pods
+type annotationRule struct {
+	Name        string               `json:"name"`
+	Match       []*resmgr.Expression `json:"match"`
+	Annotations map[string]string    `json:"annotations"`
+}
+
+// A set of rules, as stored in the rule file
+type annotationRules struct {
+	Rules []*annotationRule `json:"rules"`
+}
+
+// Currently active rules
+var rules = &ruleSet{}
+
+// The currently active rules, updated when the rule file changes
+type ruleSet struct {
+	sync.RWMutex
+	rules []*annotationRule
+}
+
+// Wrapper for evaluating rule expressions against a Pod
+type podEvaluable struct {
+	pod *corev1.Pod
+}
+
+// Load and check annotation rules from a file, a missing file has no rules
+func loadRules(path string) ([]*annotationRule, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read annotation rules %q: %v", path, err)
+	}
+
+	parsed := annotationRules{}
+	if err := yaml.UnmarshalStrict(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation rules %q: %v", path, err)
+	}
+
+	for idx, r := range parsed.Rules {
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("#%d", idx)
+		}
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("invalid annotation rule %s in %q: %v", r.Name, path, err)
+		}
+	}
+
+	return parsed.Rules, nil
+}
+
+// Check a rule for errors
+func (r *annotationRule) validate() error {
+	for _, expr := range r.Match {
+		if err := expr.Validate(); err != nil {
+			return err
+		}
+	}
+	for key := range r.Annotations {
+		domain := strings.SplitN(key, "/", 2)[0]
+		if !inResmgrNamespace(domain) {
+			return fmt.Errorf("annotation %q is not in the %s namespace",
+				key, kubernetes.ResmgrKeyNamespace)
+		}
+	}
+	return nil
+}
+
+// Check if a domain is the resource manager one or a subdomain of it
+func inResmgrNamespace(domain string) bool {
+	return domain == kubernetes.ResmgrKeyNamespace ||
+		strings.HasSuffix(domain, "."+kubernetes.ResmgrKeyNamespace)
+}
+
+// Check if the rule matches a Pod, IOW if all of its expressions evaluate to true
+func (r *annotationRule) matches(pod *corev1.Pod) bool {
+	subject := &podEvaluable{pod: pod}
+	for _, expr := range r.Match {
+		if !expr.Evaluate(subject) {
+			return false
+		}
+	}
+	return true
+}
+
+// Load rules from a file and keep reloading them whenever the file changes
+func watchRules(path string) error {
+	loaded, err := loadRules(path)
+	if err != nil {
+		return err
+	}
+	rules.set(loaded)
+	log.Printf("loaded %d annotation rule(s) from %q", len(loaded), path)
+
+	// ConfigMap volumes get updated by swapping symlinks, so watch the whole directory
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watch for annotation rules: %v", err)
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch annotation rules %q: %v", path, err)
+	}
+
+	go func() {
+		for {
+			select {
+			case e, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if e.Op == fsnotify.Chmod {
+					continue
+				}
+				loaded, err := loadRules(path)
+				if err != nil {
+					log.Printf("ERROR: keeping current annotation rules: %v", err)
+					continue
+				}
+				rules.set(loaded)
+				log.Printf("reloaded %d annotation rule(s) from %q", len(loaded), path)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("ERROR: annotation rule watch failed: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Set the active rules
+func (s *ruleSet) set(rules []*annotationRule) {
+	s.Lock()
+	defer s.Unlock()
+	s.rules = rules
+}
+
+// Collect the default annotations from matching rules which a Pod does not set itself
+func (s *ruleSet) defaultAnnotations(pod *corev1.Pod) map[string]string {
+	s.RLock()
+	defer s.RUnlock()
+
+	defaults := map[string]string{}
+	for _, r := range s.rules {
+		if !r.matches(pod) {
+			continue
+		}
+		for key, value := range r.Annotations {
+			if hasAnnotation(pod, key) {
+				continue
+			}
+			// earlier rules take precedence over later ones
+			if _, ok := defaults[key]; !ok {
+				log.Printf("rule %s: injecting %s=%q", r.Name, key, value)
+				defaults[key] = value
+			}
+		}
+	}
+
+	return defaults
+}
+
+// Check if a Pod has the annotation, or the same annotation for the whole Pod in another form
+func hasAnnotation(pod *corev1.Pod, key string) bool {
+	if _, ok := pod.Annotations[key]; ok {
+		return true
+	}
+	if base := strings.TrimSuffix(key, "/pod"); base != key {
+		_, ok := pod.Annotations[base]
+		return ok
+	}
+	_, ok := pod.Annotations[key+"/pod"]
+	return ok
+}
+
+// Create (JSON) patches adding the default annotations for a Pod
+func patchDefaultAnnotations(pod *corev1.Pod) []jsonPatch {
+	defaults := rules.defaultAnnotations(pod)
+
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	patches := make([]jsonPatch, 0, len(keys))
+	for _, key := range keys {
+		patches = append(patches, jsonPatch{
+			Op:    "add",
+			Path:  "/metadata/annotations/" + escapeJSONPointer(key),
+			Value: defaults[key],
+		})
+	}
+
+	return patches
+}
+
+// Escape a string for use as a JSON pointer (RFC 6901) path element
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// Eval returns the value of a key for expression evaluation
+func (p *podEvaluable) Eval(key string) interface{} {
+	switch key {
+	case resmgr.KeyName:
+		return p.pod.Name
+	case resmgr.KeyNamespace:
+		return p.pod.Namespace
+	case resmgr.KeyUID:
+		return string(p.pod.UID)
+	case resmgr.KeyLabels:
+		return p.pod.Labels
+	case keyAnnotations:
+		return p.pod.Annotations
+	default:
+		return fmt.Errorf("Pod %s has no key %s", p, key)
+	}
+}
+
+// String returns the Pod as a string
+func (p *podEvaluable) String() string {
+	return p.pod.Namespace + "/" + podName(p.pod)
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2021 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/intel/cri-resource-manager/pkg/apis/resmgr"
+)
+
+func TestValidateRule(t *testing.T) {
+	tcases := []struct {
+		name        string
+		annotations map[string]string
+		match       []*resmgr.Expression
+		expectError bool
+	}{
+		{
+			name: "namespace itself",
+			annotations: map[string]string{
+				"cri-resource-manager.intel.com/affinity": "c0: [ c1 ]",
+			},
+		},
+		{
+			name: "subdomain of namespace",
+			annotations: map[string]string{
+				"prefer-isolated-cpus.cri-resource-manager.intel.com/pod": "true",
+				"memory-type.cri-resource-manager.intel.com":              "dram",
+			},
+		},
+		{
+			name: "look-alike domain",
+			annotations: map[string]string{
+				"evilcri-resource-manager.intel.com/pod": "true",
+			},
+			expectError: true,
+		},
+		{
+			name: "domain with namespace as prefix",
+			annotations: map[string]string{
+				"cri-resource-manager.intel.com.example.com/pod": "true",
+			},
+			expectError: true,
+		},
+		{
+			name: "foreign domain",
+			annotations: map[string]string{
+				"example.com/pod": "true",
+			},
+			expectError: true,
+		},
+		{
+			name: "namespace only in annotation name",
+			annotations: map[string]string{
+				"example.com/cri-resource-manager.intel.com": "true",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid match expression",
+			match: []*resmgr.Expression{
+				{Key: resmgr.KeyNamespace, Op: resmgr.Equals},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &annotationRule{Name: "test", Match: tc.match, Annotations: tc.annotations}
+			err := r.validate()
+			if tc.expectError && err == nil {
+				t.Errorf("expected error, got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhook-rules-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tcases := []struct {
+		name        string
+		content     string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "missing file",
+			expected: []string{},
+		},
+		{
+			name: "valid rules",
+			content: `
+rules:
+  - name: databases
+    match:
+      - key: namespace
+        operator: Matches
+        values: [ "db-*" ]
+    annotations:
+      memory-type.cri-resource-manager.intel.com/pod: dram
+  - annotations:
+      prefer-shared-cpus.cri-resource-manager.intel.com/pod: "true"
+`,
+			expected: []string{"databases", "#1"},
+		},
+		{
+			name: "unknown field",
+			content: `
+rules:
+  - name: databases
+    selector: {}
+`,
+			expectError: true,
+		},
+		{
+			name: "look-alike domain",
+			content: `
+rules:
+  - annotations:
+      evilcri-resource-manager.intel.com/pod: "true"
+`,
+			expectError: true,
+		},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, "rules.yaml")
+			os.Remove(path)
+			if tc.content != "" {
+				if err := ioutil.WriteFile(path, []byte(tc.content), 0644); err != nil {
+					t.Fatalf("failed to write rules: %v", err)
+				}
+			}
+
+			loaded, err := loadRules(path)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			names := []string{}
+			for _, r := range loaded {
+				names = append(names, r.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Errorf("expected rules %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
+
+func TestDefaultAnnotations(t *testing.T) {
+	dbRule := &annotationRule{
+		Name: "databases",
+		Match: []*resmgr.Expression{
+			{Key: resmgr.KeyNamespace, Op: resmgr.Matches, Values: []string{"db-*"}},
+		},
+		Annotations: map[string]string{
+			"memory-type.cri-resource-manager.intel.com/pod":          "dram",
+			"prefer-isolated-cpus.cri-resource-manager.intel.com/pod": "true",
+		},
+	}
+	allRule := &annotationRule{
+		Name: "all",
+		Annotations: map[string]string{
+			"memory-type.cri-resource-manager.intel.com/pod": "dram,pmem",
+			"cri-resource-manager.intel.com/affinity":        "c0: [ c1 ]",
+		},
+	}
+
+	tcases := []struct {
+		name        string
+		namespace   string
+		annotations map[string]string
+		expected    []jsonPatch
+	}{
+		{
+			name:      "earlier rule takes precedence",
+			namespace: "db-prod",
+			expected: []jsonPatch{
+				{Op: "add", Path: "/metadata/annotations/cri-resource-manager.intel.com~1affinity", Value: "c0: [ c1 ]"},
+				{Op: "add", Path: "/metadata/annotations/memory-type.cri-resource-manager.intel.com~1pod", Value: "dram"},
+				{Op: "add", Path: "/metadata/annotations/prefer-isolated-cpus.cri-resource-manager.intel.com~1pod", Value: "true"},
+			},
+		},
+		{
+			name:      "only matching rules",
+			namespace: "default",
+			expected: []jsonPatch{
+				{Op: "add", Path: "/metadata/annotations/cri-resource-manager.intel.com~1affinity", Value: "c0: [ c1 ]"},
+				{Op: "add", Path: "/metadata/annotations/memory-type.cri-resource-manager.intel.com~1pod", Value: "dram,pmem"},
+			},
+		},
+		{
+			name:      "pod annotations are kept",
+			namespace: "db-prod",
+			annotations: map[string]string{
+				"memory-type.cri-resource-manager.intel.com":              "pmem",
+				"prefer-isolated-cpus.cri-resource-manager.intel.com/pod": "false",
+				"cri-resource-manager.intel.com/affinity":                 "",
+			},
+			expected: []jsonPatch{},
+		},
+	}
+
+	saved := rules.rules
+	defer rules.set(saved)
+	rules.set([]*annotationRule{dbRule, allRule})
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pod",
+					Namespace:   tc.namespace,
+					Annotations: tc.annotations,
+				},
+			}
+			patches := patchDefaultAnnotations(pod)
+			if !reflect.DeepEqual(patches, tc.expected) {
+				t.Errorf("expected patches %v, got %v", tc.expected, patches)
+			}
+		})
+	}
+}
@@ -24,9 +24,10 @@ import (
 )
 
 type args struct {
-	port     int
-	certFile string
-	keyFile  string
+	port      int
+	certFile  string
+	keyFile   string
+	rulesFile string
 }
 
 // Load server certificate and private key
@@ -42,8 +43,16 @@ func loadTLS(certFile, keyFile string) *tls.Config {
 
 // Run is the main entry point for the webhook server
 func Run(args args) error {
+	// Load default annotation rules
+	if args.rulesFile != "" {
+		if err := watchRules(args.rulesFile); err != nil {
+			return err
+		}
+	}
+
 	// Attach handlers
 	http.HandleFunc("/", handle)
+	http.HandleFunc("/validate", handleValidate)
 
 	// Create and run HTTP server
 	server := &http.Server{
@@ -43,6 +43,8 @@ func main() {
 
 	printConfig := flag.Bool("print-config", false, "Print configuration and exit.")
 	listPolicies := flag.Bool("list-policies", false, "List available policies.")
+	printSchema := flag.Bool("print-config-schema", false, "Print configuration JSON Schema and exit.")
+	validateConfig := flag.String("validate-config", "", "Validate the given configuration file and exit.")
 	flag.Parse()
 
 	switch {
@@ -50,6 +52,22 @@ func main() {
 		config.Print(nil)
 		os.Exit(0)
 
+	case *printSchema:
+		schema, err := config.GetSchema().JSON()
+		if err != nil {
+			log.Fatal("failed to generate configuration schema: %v", err)
+		}
+		fmt.Printf("%s\n", string(schema))
+		os.Exit(0)
+
+	case *validateConfig != "":
+		if err := config.ValidateFile(*validateConfig); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", *validateConfig, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s: configuration is valid\n", *validateConfig)
+		os.Exit(0)
+
 	case *listPolicies:
 		fmt.Printf("Available policies:\n")
 		for _, available := range policy.AvailablePolicies() {
@@ -18,17 +18,25 @@ package agent
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/intel/cri-resource-manager/pkg/log"
 	k8sclient "k8s.io/client-go/kubernetes"
 
 	resmgrcs "github.com/intel/cri-resource-manager/pkg/apis/resmgr/generated/clientset/versioned/typed/resmgr/v1alpha1"
 	resmgr "github.com/intel/cri-resource-manager/pkg/apis/resmgr/v1alpha1"
+	resmgr_v1 "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config/api/v1"
 )
 
 // Get cri-resmgr config
 type getConfigFn func() resmgrConfig
 
+// Get the freshness of cri-resmgr config and the time it was last received
+type getConfigStateFn func() (string, time.Time)
+
+// Dry-run cri-resmgr config
+type dryRunConfigFn func(*resmgrConfig) (*resmgr_v1.DryRunReply, error)
+
 // resmgrConfig represents cri-resmgr configuration
 type resmgrConfig map[string]string
 
@@ -41,6 +49,11 @@ type resmgrStatus struct {
 	errors  map[string]string
 }
 
+// resmgrConfigStatus represents the status of a configuration update
+type resmgrConfigStatus struct {
+	err error
+}
+
 // ResourceManagerAgent is the interface exposed for the CRI Resource Manager Congig Agent
 type ResourceManagerAgent interface {
 	Run() error
@@ -54,6 +67,7 @@ type agent struct {
 	server     agentServer   // gRPC server listening for requests from cri-resource-manager
 	watcher    k8sWatcher    // Watcher monitoring events in K8s cluster
 	updater    configUpdater // Client sending config updates to cri-resource-manager
+	pods       podWatcher    // Watcher tracking Pods scheduled to our node
 }
 
 // NewResourceManagerAgent creates a new instance of ResourceManagerAgent
@@ -72,14 +86,18 @@ func NewResourceManagerAgent() (ResourceManagerAgent, error) {
 		return nil, agentError("failed to initialize watcher instance: %v", err)
 	}
 
-	if a.server, err = newAgentServer(a.cli, a.watcher.GetConfig); err != nil {
-		return nil, agentError("failed to initialize gRPC server")
-	}
-
 	if a.updater, err = newConfigUpdater(opts.resmgrSocket); err != nil {
 		return nil, agentError("failed to initialize config updater instance: %v", err)
 	}
 
+	if a.pods, err = newPodWatcher(a.cli); err != nil {
+		return nil, agentError("failed to initialize pod watcher instance: %v", err)
+	}
+
+	if a.server, err = newAgentServer(a.cli, a.extCli, a.watcher.GetConfig, a.watcher.GetConfigState, a.updater.DryRunConfig, a.pods.GetPod); err != nil {
+		return nil, agentError("failed to initialize gRPC server")
+	}
+
 	return a, nil
 }
 
@@ -95,6 +113,10 @@ func (a *agent) Run() error {
 		return agentError("failed to start watcher: %v", err)
 	}
 
+	if err := a.pods.Start(); err != nil {
+		return agentError("failed to start pod watcher: %v", err)
+	}
+
 	if err := a.updater.Start(); err != nil {
 		return agentError("failed to start config updater: %v", err)
 	}
@@ -109,6 +131,10 @@ func (a *agent) Run() error {
 			if ok {
 				a.updater.UpdateAdjustment(&adjust)
 			}
+		case pod, ok := <-a.pods.PodChan():
+			if ok {
+				a.updater.UpdatePod(pod)
+			}
 		case status, ok := <-a.updater.StatusChan():
 			if ok {
 				a.Info("got status %v", status)
@@ -116,6 +142,12 @@ func (a *agent) Run() error {
 					a.Error("failed to update adjustment node status: %v", err)
 				}
 			}
+		case status, ok := <-a.updater.ConfigStatusChan():
+			if ok {
+				if err := a.watcher.UpdateConfigStatus(status); err != nil {
+					a.Error("failed to update config node status: %v", err)
+				}
+			}
 		}
 	}
 }
@@ -1,15 +1,16 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: api.proto
+// source: pkg/agent/api/v1/api.proto
 
 package v1
 
-import proto "github.com/golang/protobuf/proto"
-import fmt "fmt"
-import math "math"
-
 import (
-	context "golang.org/x/net/context"
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -21,7 +22,7 @@ var _ = math.Inf
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type GetNodeRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -33,16 +34,17 @@ func (m *GetNodeRequest) Reset()         { *m = GetNodeRequest{} }
 func (m *GetNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNodeRequest) ProtoMessage()    {}
 func (*GetNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{0}
+	return fileDescriptor_47adca9da093f095, []int{0}
 }
+
 func (m *GetNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetNodeRequest.Unmarshal(m, b)
 }
 func (m *GetNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_GetNodeRequest.Marshal(b, m, deterministic)
 }
-func (dst *GetNodeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeRequest.Merge(dst, src)
+func (m *GetNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeRequest.Merge(m, src)
 }
 func (m *GetNodeRequest) XXX_Size() int {
 	return xxx_messageInfo_GetNodeRequest.Size(m)
@@ -64,16 +66,17 @@ func (m *GetNodeReply) Reset()         { *m = GetNodeReply{} }
 func (m *GetNodeReply) String() string { return proto.CompactTextString(m) }
 func (*GetNodeReply) ProtoMessage()    {}
 func (*GetNodeReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{1}
+	return fileDescriptor_47adca9da093f095, []int{1}
 }
+
 func (m *GetNodeReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetNodeReply.Unmarshal(m, b)
 }
 func (m *GetNodeReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_GetNodeReply.Marshal(b, m, deterministic)
 }
-func (dst *GetNodeReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeReply.Merge(dst, src)
+func (m *GetNodeReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeReply.Merge(m, src)
 }
 func (m *GetNodeReply) XXX_Size() int {
 	return xxx_messageInfo_GetNodeReply.Size(m)
@@ -105,16 +108,17 @@ func (m *JsonPatch) Reset()         { *m = JsonPatch{} }
 func (m *JsonPatch) String() string { return proto.CompactTextString(m) }
 func (*JsonPatch) ProtoMessage()    {}
 func (*JsonPatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{2}
+	return fileDescriptor_47adca9da093f095, []int{2}
 }
+
 func (m *JsonPatch) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JsonPatch.Unmarshal(m, b)
 }
 func (m *JsonPatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_JsonPatch.Marshal(b, m, deterministic)
 }
-func (dst *JsonPatch) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_JsonPatch.Merge(dst, src)
+func (m *JsonPatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JsonPatch.Merge(m, src)
 }
 func (m *JsonPatch) XXX_Size() int {
 	return xxx_messageInfo_JsonPatch.Size(m)
@@ -158,16 +162,17 @@ func (m *PatchNodeRequest) Reset()         { *m = PatchNodeRequest{} }
 func (m *PatchNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*PatchNodeRequest) ProtoMessage()    {}
 func (*PatchNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{3}
+	return fileDescriptor_47adca9da093f095, []int{3}
 }
+
 func (m *PatchNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PatchNodeRequest.Unmarshal(m, b)
 }
 func (m *PatchNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_PatchNodeRequest.Marshal(b, m, deterministic)
 }
-func (dst *PatchNodeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PatchNodeRequest.Merge(dst, src)
+func (m *PatchNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PatchNodeRequest.Merge(m, src)
 }
 func (m *PatchNodeRequest) XXX_Size() int {
 	return xxx_messageInfo_PatchNodeRequest.Size(m)
@@ -195,16 +200,17 @@ func (m *PatchNodeReply) Reset()         { *m = PatchNodeReply{} }
 func (m *PatchNodeReply) String() string { return proto.CompactTextString(m) }
 func (*PatchNodeReply) ProtoMessage()    {}
 func (*PatchNodeReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{4}
+	return fileDescriptor_47adca9da093f095, []int{4}
 }
+
 func (m *PatchNodeReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PatchNodeReply.Unmarshal(m, b)
 }
 func (m *PatchNodeReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_PatchNodeReply.Marshal(b, m, deterministic)
 }
-func (dst *PatchNodeReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PatchNodeReply.Merge(dst, src)
+func (m *PatchNodeReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PatchNodeReply.Merge(m, src)
 }
 func (m *PatchNodeReply) XXX_Size() int {
 	return xxx_messageInfo_PatchNodeReply.Size(m)
@@ -227,16 +233,17 @@ func (m *UpdateNodeCapacityRequest) Reset()         { *m = UpdateNodeCapacityReq
 func (m *UpdateNodeCapacityRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateNodeCapacityRequest) ProtoMessage()    {}
 func (*UpdateNodeCapacityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{5}
+	return fileDescriptor_47adca9da093f095, []int{5}
 }
+
 func (m *UpdateNodeCapacityRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateNodeCapacityRequest.Unmarshal(m, b)
 }
 func (m *UpdateNodeCapacityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_UpdateNodeCapacityRequest.Marshal(b, m, deterministic)
 }
-func (dst *UpdateNodeCapacityRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateNodeCapacityRequest.Merge(dst, src)
+func (m *UpdateNodeCapacityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateNodeCapacityRequest.Merge(m, src)
 }
 func (m *UpdateNodeCapacityRequest) XXX_Size() int {
 	return xxx_messageInfo_UpdateNodeCapacityRequest.Size(m)
@@ -264,16 +271,17 @@ func (m *UpdateNodeCapacityReply) Reset()         { *m = UpdateNodeCapacityReply
 func (m *UpdateNodeCapacityReply) String() string { return proto.CompactTextString(m) }
 func (*UpdateNodeCapacityReply) ProtoMessage()    {}
 func (*UpdateNodeCapacityReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{6}
+	return fileDescriptor_47adca9da093f095, []int{6}
 }
+
 func (m *UpdateNodeCapacityReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateNodeCapacityReply.Unmarshal(m, b)
 }
 func (m *UpdateNodeCapacityReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_UpdateNodeCapacityReply.Marshal(b, m, deterministic)
 }
-func (dst *UpdateNodeCapacityReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateNodeCapacityReply.Merge(dst, src)
+func (m *UpdateNodeCapacityReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateNodeCapacityReply.Merge(m, src)
 }
 func (m *UpdateNodeCapacityReply) XXX_Size() int {
 	return xxx_messageInfo_UpdateNodeCapacityReply.Size(m)
@@ -294,16 +302,17 @@ func (m *GetConfigRequest) Reset()         { *m = GetConfigRequest{} }
 func (m *GetConfigRequest) String() string { return proto.CompactTextString(m) }
 func (*GetConfigRequest) ProtoMessage()    {}
 func (*GetConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{7}
+	return fileDescriptor_47adca9da093f095, []int{7}
 }
+
 func (m *GetConfigRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetConfigRequest.Unmarshal(m, b)
 }
 func (m *GetConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_GetConfigRequest.Marshal(b, m, deterministic)
 }
-func (dst *GetConfigRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigRequest.Merge(dst, src)
+func (m *GetConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigRequest.Merge(m, src)
 }
 func (m *GetConfigRequest) XXX_Size() int {
 	return xxx_messageInfo_GetConfigRequest.Size(m)
@@ -326,16 +335,17 @@ func (m *GetConfigReply) Reset()         { *m = GetConfigReply{} }
 func (m *GetConfigReply) String() string { return proto.CompactTextString(m) }
 func (*GetConfigReply) ProtoMessage()    {}
 func (*GetConfigReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{8}
+	return fileDescriptor_47adca9da093f095, []int{8}
 }
+
 func (m *GetConfigReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetConfigReply.Unmarshal(m, b)
 }
 func (m *GetConfigReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_GetConfigReply.Marshal(b, m, deterministic)
 }
-func (dst *GetConfigReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigReply.Merge(dst, src)
+func (m *GetConfigReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigReply.Merge(m, src)
 }
 func (m *GetConfigReply) XXX_Size() int {
 	return xxx_messageInfo_GetConfigReply.Size(m)
@@ -370,16 +380,17 @@ func (m *HealthCheckRequest) Reset()         { *m = HealthCheckRequest{} }
 func (m *HealthCheckRequest) String() string { return proto.CompactTextString(m) }
 func (*HealthCheckRequest) ProtoMessage()    {}
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{9}
+	return fileDescriptor_47adca9da093f095, []int{9}
 }
+
 func (m *HealthCheckRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HealthCheckRequest.Unmarshal(m, b)
 }
 func (m *HealthCheckRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_HealthCheckRequest.Marshal(b, m, deterministic)
 }
-func (dst *HealthCheckRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HealthCheckRequest.Merge(dst, src)
+func (m *HealthCheckRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthCheckRequest.Merge(m, src)
 }
 func (m *HealthCheckRequest) XXX_Size() int {
 	return xxx_messageInfo_HealthCheckRequest.Size(m)
@@ -391,7 +402,11 @@ func (m *HealthCheckRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_HealthCheckRequest proto.InternalMessageInfo
 
 type HealthCheckReply struct {
-	Error                string   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Error string `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// config_state is the freshness of the configuration served by the agent.
+	ConfigState string `protobuf:"bytes,2,opt,name=config_state,json=configState,proto3" json:"config_state,omitempty"`
+	// config_updated is the time configuration was last received, in seconds since the epoch.
+	ConfigUpdated        int64    `protobuf:"varint,3,opt,name=config_updated,json=configUpdated,proto3" json:"config_updated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -401,16 +416,17 @@ func (m *HealthCheckReply) Reset()         { *m = HealthCheckReply{} }
 func (m *HealthCheckReply) String() string { return proto.CompactTextString(m) }
 func (*HealthCheckReply) ProtoMessage()    {}
 func (*HealthCheckReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_c34572713b59108b, []int{10}
+	return fileDescriptor_47adca9da093f095, []int{10}
 }
+
 func (m *HealthCheckReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HealthCheckReply.Unmarshal(m, b)
 }
 func (m *HealthCheckReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_HealthCheckReply.Marshal(b, m, deterministic)
 }
-func (dst *HealthCheckReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HealthCheckReply.Merge(dst, src)
+func (m *HealthCheckReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthCheckReply.Merge(m, src)
 }
 func (m *HealthCheckReply) XXX_Size() int {
 	return xxx_messageInfo_HealthCheckReply.Size(m)
@@ -428,6 +444,723 @@ func (m *HealthCheckReply) GetError() string {
 	return ""
 }
 
+func (m *HealthCheckReply) GetConfigState() string {
+	if m != nil {
+		return m.ConfigState
+	}
+	return ""
+}
+
+func (m *HealthCheckReply) GetConfigUpdated() int64 {
+	if m != nil {
+		return m.ConfigUpdated
+	}
+	return 0
+}
+
+type DryRunConfigRequest struct {
+	// config is the configuration to check, the current one if empty.
+	Config               map[string]string `protobuf:"bytes,1,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DryRunConfigRequest) Reset()         { *m = DryRunConfigRequest{} }
+func (m *DryRunConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*DryRunConfigRequest) ProtoMessage()    {}
+func (*DryRunConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{11}
+}
+
+func (m *DryRunConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DryRunConfigRequest.Unmarshal(m, b)
+}
+func (m *DryRunConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DryRunConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *DryRunConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DryRunConfigRequest.Merge(m, src)
+}
+func (m *DryRunConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_DryRunConfigRequest.Size(m)
+}
+func (m *DryRunConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DryRunConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DryRunConfigRequest proto.InternalMessageInfo
+
+func (m *DryRunConfigRequest) GetConfig() map[string]string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+type DryRunConfigReply struct {
+	// diff is the per-module diff of effective values, module path as key.
+	Diff map[string]*ModuleDiff `protobuf:"bytes,1,rep,name=diff,proto3" json:"diff,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// errors are the validation errors found in the configuration, if any.
+	Errors               []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DryRunConfigReply) Reset()         { *m = DryRunConfigReply{} }
+func (m *DryRunConfigReply) String() string { return proto.CompactTextString(m) }
+func (*DryRunConfigReply) ProtoMessage()    {}
+func (*DryRunConfigReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{12}
+}
+
+func (m *DryRunConfigReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DryRunConfigReply.Unmarshal(m, b)
+}
+func (m *DryRunConfigReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DryRunConfigReply.Marshal(b, m, deterministic)
+}
+func (m *DryRunConfigReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DryRunConfigReply.Merge(m, src)
+}
+func (m *DryRunConfigReply) XXX_Size() int {
+	return xxx_messageInfo_DryRunConfigReply.Size(m)
+}
+func (m *DryRunConfigReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DryRunConfigReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DryRunConfigReply proto.InternalMessageInfo
+
+func (m *DryRunConfigReply) GetDiff() map[string]*ModuleDiff {
+	if m != nil {
+		return m.Diff
+	}
+	return nil
+}
+
+func (m *DryRunConfigReply) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type ModuleDiff struct {
+	// changes are the changed effective values of the module.
+	Changes              []*FieldChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ModuleDiff) Reset()         { *m = ModuleDiff{} }
+func (m *ModuleDiff) String() string { return proto.CompactTextString(m) }
+func (*ModuleDiff) ProtoMessage()    {}
+func (*ModuleDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{13}
+}
+
+func (m *ModuleDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModuleDiff.Unmarshal(m, b)
+}
+func (m *ModuleDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModuleDiff.Marshal(b, m, deterministic)
+}
+func (m *ModuleDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModuleDiff.Merge(m, src)
+}
+func (m *ModuleDiff) XXX_Size() int {
+	return xxx_messageInfo_ModuleDiff.Size(m)
+}
+func (m *ModuleDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModuleDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModuleDiff proto.InternalMessageInfo
+
+func (m *ModuleDiff) GetChanges() []*FieldChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type FieldChange struct {
+	// field is the path of the field within its module, in dotted notation.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// old is the current effective value, JSON-encoded.
+	Old string `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	// new is the effective value with the new configuration, JSON-encoded.
+	New                  string   `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FieldChange) Reset()         { *m = FieldChange{} }
+func (m *FieldChange) String() string { return proto.CompactTextString(m) }
+func (*FieldChange) ProtoMessage()    {}
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{14}
+}
+
+func (m *FieldChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldChange.Unmarshal(m, b)
+}
+func (m *FieldChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldChange.Marshal(b, m, deterministic)
+}
+func (m *FieldChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldChange.Merge(m, src)
+}
+func (m *FieldChange) XXX_Size() int {
+	return xxx_messageInfo_FieldChange.Size(m)
+}
+func (m *FieldChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldChange proto.InternalMessageInfo
+
+func (m *FieldChange) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *FieldChange) GetOld() string {
+	if m != nil {
+		return m.Old
+	}
+	return ""
+}
+
+func (m *FieldChange) GetNew() string {
+	if m != nil {
+		return m.New
+	}
+	return ""
+}
+
+type UpdateNodeResourceTopologyRequest struct {
+	// topology_policies are the policies used to allocate resources on the node.
+	TopologyPolicies []string `protobuf:"bytes,1,rep,name=topology_policies,json=topologyPolicies,proto3" json:"topology_policies,omitempty"`
+	// zones are the topology zones of the node.
+	Zones                []*TopologyZone `protobuf:"bytes,2,rep,name=zones,proto3" json:"zones,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *UpdateNodeResourceTopologyRequest) Reset()         { *m = UpdateNodeResourceTopologyRequest{} }
+func (m *UpdateNodeResourceTopologyRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeResourceTopologyRequest) ProtoMessage()    {}
+func (*UpdateNodeResourceTopologyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{15}
+}
+
+func (m *UpdateNodeResourceTopologyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateNodeResourceTopologyRequest.Unmarshal(m, b)
+}
+func (m *UpdateNodeResourceTopologyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateNodeResourceTopologyRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateNodeResourceTopologyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateNodeResourceTopologyRequest.Merge(m, src)
+}
+func (m *UpdateNodeResourceTopologyRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateNodeResourceTopologyRequest.Size(m)
+}
+func (m *UpdateNodeResourceTopologyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateNodeResourceTopologyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateNodeResourceTopologyRequest proto.InternalMessageInfo
+
+func (m *UpdateNodeResourceTopologyRequest) GetTopologyPolicies() []string {
+	if m != nil {
+		return m.TopologyPolicies
+	}
+	return nil
+}
+
+func (m *UpdateNodeResourceTopologyRequest) GetZones() []*TopologyZone {
+	if m != nil {
+		return m.Zones
+	}
+	return nil
+}
+
+type UpdateNodeResourceTopologyReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateNodeResourceTopologyReply) Reset()         { *m = UpdateNodeResourceTopologyReply{} }
+func (m *UpdateNodeResourceTopologyReply) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeResourceTopologyReply) ProtoMessage()    {}
+func (*UpdateNodeResourceTopologyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{16}
+}
+
+func (m *UpdateNodeResourceTopologyReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateNodeResourceTopologyReply.Unmarshal(m, b)
+}
+func (m *UpdateNodeResourceTopologyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateNodeResourceTopologyReply.Marshal(b, m, deterministic)
+}
+func (m *UpdateNodeResourceTopologyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateNodeResourceTopologyReply.Merge(m, src)
+}
+func (m *UpdateNodeResourceTopologyReply) XXX_Size() int {
+	return xxx_messageInfo_UpdateNodeResourceTopologyReply.Size(m)
+}
+func (m *UpdateNodeResourceTopologyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateNodeResourceTopologyReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateNodeResourceTopologyReply proto.InternalMessageInfo
+
+// TopologyZone describes the resources of a topology zone.
+type TopologyZone struct {
+	Name                 string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string          `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Parent               string          `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	Resources            []*ZoneResource `protobuf:"bytes,4,rep,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *TopologyZone) Reset()         { *m = TopologyZone{} }
+func (m *TopologyZone) String() string { return proto.CompactTextString(m) }
+func (*TopologyZone) ProtoMessage()    {}
+func (*TopologyZone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{17}
+}
+
+func (m *TopologyZone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopologyZone.Unmarshal(m, b)
+}
+func (m *TopologyZone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopologyZone.Marshal(b, m, deterministic)
+}
+func (m *TopologyZone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopologyZone.Merge(m, src)
+}
+func (m *TopologyZone) XXX_Size() int {
+	return xxx_messageInfo_TopologyZone.Size(m)
+}
+func (m *TopologyZone) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopologyZone.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopologyZone proto.InternalMessageInfo
+
+func (m *TopologyZone) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TopologyZone) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TopologyZone) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *TopologyZone) GetResources() []*ZoneResource {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+// ZoneResource holds the amounts of a resource in a zone, as quantity strings.
+type ZoneResource struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Capacity             string   `protobuf:"bytes,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Allocatable          string   `protobuf:"bytes,3,opt,name=allocatable,proto3" json:"allocatable,omitempty"`
+	Available            string   `protobuf:"bytes,4,opt,name=available,proto3" json:"available,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ZoneResource) Reset()         { *m = ZoneResource{} }
+func (m *ZoneResource) String() string { return proto.CompactTextString(m) }
+func (*ZoneResource) ProtoMessage()    {}
+func (*ZoneResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{18}
+}
+
+func (m *ZoneResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ZoneResource.Unmarshal(m, b)
+}
+func (m *ZoneResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ZoneResource.Marshal(b, m, deterministic)
+}
+func (m *ZoneResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ZoneResource.Merge(m, src)
+}
+func (m *ZoneResource) XXX_Size() int {
+	return xxx_messageInfo_ZoneResource.Size(m)
+}
+func (m *ZoneResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_ZoneResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ZoneResource proto.InternalMessageInfo
+
+func (m *ZoneResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ZoneResource) GetCapacity() string {
+	if m != nil {
+		return m.Capacity
+	}
+	return ""
+}
+
+func (m *ZoneResource) GetAllocatable() string {
+	if m != nil {
+		return m.Allocatable
+	}
+	return ""
+}
+
+func (m *ZoneResource) GetAvailable() string {
+	if m != nil {
+		return m.Available
+	}
+	return ""
+}
+
+type PostPodEventsRequest struct {
+	// events are the events to post.
+	Events               []*PodEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PostPodEventsRequest) Reset()         { *m = PostPodEventsRequest{} }
+func (m *PostPodEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*PostPodEventsRequest) ProtoMessage()    {}
+func (*PostPodEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{19}
+}
+
+func (m *PostPodEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PostPodEventsRequest.Unmarshal(m, b)
+}
+func (m *PostPodEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PostPodEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *PostPodEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostPodEventsRequest.Merge(m, src)
+}
+func (m *PostPodEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_PostPodEventsRequest.Size(m)
+}
+func (m *PostPodEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostPodEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PostPodEventsRequest proto.InternalMessageInfo
+
+func (m *PostPodEventsRequest) GetEvents() []*PodEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type PostPodEventsReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PostPodEventsReply) Reset()         { *m = PostPodEventsReply{} }
+func (m *PostPodEventsReply) String() string { return proto.CompactTextString(m) }
+func (*PostPodEventsReply) ProtoMessage()    {}
+func (*PostPodEventsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{20}
+}
+
+func (m *PostPodEventsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PostPodEventsReply.Unmarshal(m, b)
+}
+func (m *PostPodEventsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PostPodEventsReply.Marshal(b, m, deterministic)
+}
+func (m *PostPodEventsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostPodEventsReply.Merge(m, src)
+}
+func (m *PostPodEventsReply) XXX_Size() int {
+	return xxx_messageInfo_PostPodEventsReply.Size(m)
+}
+func (m *PostPodEventsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostPodEventsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PostPodEventsReply proto.InternalMessageInfo
+
+// PodEvent is an event to post on a Pod object.
+type PodEvent struct {
+	// namespace, name and uid identify the pod.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Uid       string `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	// type is the type of the event, Normal or Warning.
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// reason is a short, machine understandable reason for the event.
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	// message is a human readable description of the event.
+	Message              string   `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PodEvent) Reset()         { *m = PodEvent{} }
+func (m *PodEvent) String() string { return proto.CompactTextString(m) }
+func (*PodEvent) ProtoMessage()    {}
+func (*PodEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{21}
+}
+
+func (m *PodEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodEvent.Unmarshal(m, b)
+}
+func (m *PodEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodEvent.Marshal(b, m, deterministic)
+}
+func (m *PodEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodEvent.Merge(m, src)
+}
+func (m *PodEvent) XXX_Size() int {
+	return xxx_messageInfo_PodEvent.Size(m)
+}
+func (m *PodEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodEvent proto.InternalMessageInfo
+
+func (m *PodEvent) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *PodEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PodEvent) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *PodEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PodEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *PodEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type GetPodResourcesRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// uid is the UID of the pod, checked if given.
+	Uid                  string   `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPodResourcesRequest) Reset()         { *m = GetPodResourcesRequest{} }
+func (m *GetPodResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPodResourcesRequest) ProtoMessage()    {}
+func (*GetPodResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{22}
+}
+
+func (m *GetPodResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPodResourcesRequest.Unmarshal(m, b)
+}
+func (m *GetPodResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPodResourcesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPodResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPodResourcesRequest.Merge(m, src)
+}
+func (m *GetPodResourcesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPodResourcesRequest.Size(m)
+}
+func (m *GetPodResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPodResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPodResourcesRequest proto.InternalMessageInfo
+
+func (m *GetPodResourcesRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GetPodResourcesRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetPodResourcesRequest) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+type GetPodResourcesReply struct {
+	// init_containers are the resource requirements of init containers by name.
+	InitContainers map[string]*ContainerResources `protobuf:"bytes,1,rep,name=init_containers,json=initContainers,proto3" json:"init_containers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// containers are the resource requirements of containers by name.
+	Containers           map[string]*ContainerResources `protobuf:"bytes,2,rep,name=containers,proto3" json:"containers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *GetPodResourcesReply) Reset()         { *m = GetPodResourcesReply{} }
+func (m *GetPodResourcesReply) String() string { return proto.CompactTextString(m) }
+func (*GetPodResourcesReply) ProtoMessage()    {}
+func (*GetPodResourcesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{23}
+}
+
+func (m *GetPodResourcesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPodResourcesReply.Unmarshal(m, b)
+}
+func (m *GetPodResourcesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPodResourcesReply.Marshal(b, m, deterministic)
+}
+func (m *GetPodResourcesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPodResourcesReply.Merge(m, src)
+}
+func (m *GetPodResourcesReply) XXX_Size() int {
+	return xxx_messageInfo_GetPodResourcesReply.Size(m)
+}
+func (m *GetPodResourcesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPodResourcesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPodResourcesReply proto.InternalMessageInfo
+
+func (m *GetPodResourcesReply) GetInitContainers() map[string]*ContainerResources {
+	if m != nil {
+		return m.InitContainers
+	}
+	return nil
+}
+
+func (m *GetPodResourcesReply) GetContainers() map[string]*ContainerResources {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
+// ContainerResources holds the requests and limits of a container, as quantity strings.
+type ContainerResources struct {
+	Requests             map[string]string `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Limits               map[string]string `protobuf:"bytes,2,rep,name=limits,proto3" json:"limits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ContainerResources) Reset()         { *m = ContainerResources{} }
+func (m *ContainerResources) String() string { return proto.CompactTextString(m) }
+func (*ContainerResources) ProtoMessage()    {}
+func (*ContainerResources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_47adca9da093f095, []int{24}
+}
+
+func (m *ContainerResources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerResources.Unmarshal(m, b)
+}
+func (m *ContainerResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerResources.Marshal(b, m, deterministic)
+}
+func (m *ContainerResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerResources.Merge(m, src)
+}
+func (m *ContainerResources) XXX_Size() int {
+	return xxx_messageInfo_ContainerResources.Size(m)
+}
+func (m *ContainerResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerResources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerResources proto.InternalMessageInfo
+
+func (m *ContainerResources) GetRequests() map[string]string {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *ContainerResources) GetLimits() map[string]string {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*GetNodeRequest)(nil), "v1.GetNodeRequest")
 	proto.RegisterType((*GetNodeReply)(nil), "v1.GetNodeReply")
@@ -442,6 +1175,103 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "v1.GetConfigReply.ConfigEntry")
 	proto.RegisterType((*HealthCheckRequest)(nil), "v1.HealthCheckRequest")
 	proto.RegisterType((*HealthCheckReply)(nil), "v1.HealthCheckReply")
+	proto.RegisterType((*DryRunConfigRequest)(nil), "v1.DryRunConfigRequest")
+	proto.RegisterMapType((map[string]string)(nil), "v1.DryRunConfigRequest.ConfigEntry")
+	proto.RegisterType((*DryRunConfigReply)(nil), "v1.DryRunConfigReply")
+	proto.RegisterMapType((map[string]*ModuleDiff)(nil), "v1.DryRunConfigReply.DiffEntry")
+	proto.RegisterType((*ModuleDiff)(nil), "v1.ModuleDiff")
+	proto.RegisterType((*FieldChange)(nil), "v1.FieldChange")
+	proto.RegisterType((*UpdateNodeResourceTopologyRequest)(nil), "v1.UpdateNodeResourceTopologyRequest")
+	proto.RegisterType((*UpdateNodeResourceTopologyReply)(nil), "v1.UpdateNodeResourceTopologyReply")
+	proto.RegisterType((*TopologyZone)(nil), "v1.TopologyZone")
+	proto.RegisterType((*ZoneResource)(nil), "v1.ZoneResource")
+	proto.RegisterType((*PostPodEventsRequest)(nil), "v1.PostPodEventsRequest")
+	proto.RegisterType((*PostPodEventsReply)(nil), "v1.PostPodEventsReply")
+	proto.RegisterType((*PodEvent)(nil), "v1.PodEvent")
+	proto.RegisterType((*GetPodResourcesRequest)(nil), "v1.GetPodResourcesRequest")
+	proto.RegisterType((*GetPodResourcesReply)(nil), "v1.GetPodResourcesReply")
+	proto.RegisterMapType((map[string]*ContainerResources)(nil), "v1.GetPodResourcesReply.ContainersEntry")
+	proto.RegisterMapType((map[string]*ContainerResources)(nil), "v1.GetPodResourcesReply.InitContainersEntry")
+	proto.RegisterType((*ContainerResources)(nil), "v1.ContainerResources")
+	proto.RegisterMapType((map[string]string)(nil), "v1.ContainerResources.LimitsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "v1.ContainerResources.RequestsEntry")
+}
+
+func init() { proto.RegisterFile("pkg/agent/api/v1/api.proto", fileDescriptor_47adca9da093f095) }
+
+var fileDescriptor_47adca9da093f095 = []byte{
+	// 1128 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x6e, 0x1b, 0xc5,
+	0x17, 0x8f, 0xd7, 0x8e, 0x13, 0x1f, 0x27, 0x8e, 0x3b, 0xf5, 0x3f, 0xdd, 0x6e, 0xff, 0xd0, 0x64,
+	0x9b, 0x42, 0x10, 0xc5, 0x51, 0x52, 0x89, 0x42, 0x43, 0xa5, 0x82, 0x1b, 0xd2, 0x22, 0x5a, 0x45,
+	0x0b, 0xb9, 0xa0, 0x42, 0x8a, 0xa6, 0xbb, 0x63, 0x67, 0xc9, 0x66, 0x67, 0xd9, 0x1d, 0x1b, 0xcc,
+	0x05, 0x0f, 0x80, 0xc4, 0x1d, 0x0f, 0xc0, 0x6d, 0x1f, 0x8d, 0x87, 0x40, 0x42, 0x67, 0x3e, 0xd6,
+	0xbb, 0xfe, 0x28, 0x8a, 0x54, 0xae, 0x3c, 0xf3, 0x3b, 0xdf, 0xe7, 0xec, 0x39, 0x73, 0x0c, 0x4e,
+	0x72, 0x31, 0xd8, 0xa3, 0x03, 0x16, 0x8b, 0x3d, 0x9a, 0x84, 0x7b, 0xa3, 0x7d, 0xfc, 0xe9, 0x26,
+	0x29, 0x17, 0x9c, 0x58, 0xa3, 0x7d, 0xb7, 0x0d, 0xad, 0x63, 0x26, 0x5e, 0xf0, 0x80, 0x79, 0xec,
+	0xc7, 0x21, 0xcb, 0x84, 0xeb, 0xc2, 0x5a, 0x8e, 0x24, 0xd1, 0x98, 0x10, 0xa8, 0xc5, 0x3c, 0x60,
+	0x76, 0x65, 0xab, 0xb2, 0xdb, 0xf0, 0xe4, 0xd9, 0x3d, 0x82, 0xc6, 0x57, 0x19, 0x8f, 0x4f, 0xa8,
+	0xf0, 0xcf, 0x49, 0x0b, 0x2c, 0x9e, 0x68, 0xb2, 0xc5, 0x13, 0x14, 0x48, 0xa8, 0x38, 0xb7, 0x2d,
+	0x25, 0x80, 0x67, 0xd2, 0x81, 0xe5, 0x11, 0x8d, 0x86, 0xcc, 0xae, 0x4a, 0x50, 0x5d, 0xdc, 0x43,
+	0x68, 0x4b, 0x15, 0x05, 0xf3, 0xe4, 0x7d, 0x58, 0x49, 0x10, 0x63, 0x99, 0x5d, 0xd9, 0xaa, 0xee,
+	0x36, 0x0f, 0xd6, 0xbb, 0xa3, 0xfd, 0x6e, 0x6e, 0xcd, 0x33, 0x54, 0xf4, 0xbc, 0x20, 0x9c, 0x44,
+	0x63, 0xf7, 0x75, 0x05, 0x6e, 0x9e, 0x26, 0x01, 0x15, 0x0c, 0xb1, 0x1e, 0x4d, 0xa8, 0x1f, 0x8a,
+	0xb1, 0x51, 0xfc, 0x1c, 0xc0, 0x57, 0x50, 0x98, 0xeb, 0xfe, 0x08, 0x75, 0x2f, 0x14, 0xe9, 0xf6,
+	0x72, 0xfe, 0xa3, 0x58, 0xa4, 0x63, 0xaf, 0xa0, 0xc0, 0x79, 0x04, 0x1b, 0x53, 0x64, 0xd2, 0x86,
+	0xea, 0x05, 0x1b, 0xeb, 0x4c, 0xe0, 0x71, 0x12, 0xb6, 0x55, 0x08, 0xfb, 0xa1, 0xf5, 0x49, 0xc5,
+	0xbd, 0x09, 0x37, 0xe6, 0xd9, 0xc5, 0x30, 0x08, 0xb4, 0x8f, 0x99, 0xe8, 0xf1, 0xb8, 0x1f, 0x0e,
+	0x4c, 0x51, 0xfe, 0xac, 0x40, 0xab, 0x00, 0x62, 0x5d, 0x6e, 0x41, 0x03, 0x6b, 0x71, 0x16, 0xd3,
+	0x4b, 0x53, 0x9c, 0x55, 0x04, 0x5e, 0xd0, 0x4b, 0x46, 0x3e, 0x86, 0xba, 0x2f, 0x79, 0x6d, 0x4b,
+	0x06, 0xfa, 0x2e, 0x06, 0x5a, 0x56, 0xd0, 0x55, 0x67, 0x15, 0x99, 0xe6, 0x76, 0x3e, 0x85, 0x66,
+	0x01, 0xbe, 0x52, 0x44, 0x1d, 0x20, 0x4f, 0x19, 0x8d, 0xc4, 0x79, 0xef, 0x9c, 0xf9, 0x17, 0xc6,
+	0xf1, 0x14, 0xda, 0x25, 0x14, 0x3d, 0xef, 0xc0, 0x32, 0x4b, 0x53, 0x9e, 0x6a, 0xbd, 0xea, 0x42,
+	0xb6, 0x61, 0x4d, 0x39, 0x71, 0x96, 0x09, 0x2a, 0x8c, 0x81, 0xa6, 0xc2, 0xbe, 0x41, 0x88, 0xdc,
+	0x85, 0x96, 0x66, 0x19, 0xca, 0xdc, 0x05, 0xf2, 0x73, 0xaa, 0x7a, 0xeb, 0x0a, 0x55, 0x09, 0x0d,
+	0xdc, 0xdf, 0x2b, 0x70, 0xfd, 0x49, 0x3a, 0xf6, 0x86, 0x71, 0x29, 0x89, 0xe4, 0x30, 0x4f, 0x8a,
+	0xaa, 0xfe, 0x1d, 0x4c, 0xca, 0x1c, 0xc6, 0xb7, 0x9d, 0x99, 0xd7, 0x15, 0xb8, 0x56, 0x36, 0x83,
+	0x59, 0xb8, 0x0f, 0xb5, 0x20, 0xec, 0xf7, 0xb5, 0x2f, 0xb7, 0x67, 0x7d, 0xc1, 0x1a, 0x3d, 0x09,
+	0xfb, 0x7d, 0xe5, 0x87, 0x64, 0x26, 0x9b, 0x50, 0x97, 0xd9, 0xca, 0x64, 0x5d, 0x1b, 0x9e, 0xbe,
+	0x39, 0xc7, 0xd0, 0xc8, 0x59, 0xe7, 0xf8, 0xb6, 0x53, 0xf4, 0xad, 0x79, 0xd0, 0x42, 0x63, 0xcf,
+	0x79, 0x30, 0x8c, 0x18, 0x4a, 0x15, 0x7d, 0x7d, 0x00, 0x30, 0x21, 0x90, 0x0f, 0x60, 0xc5, 0x3f,
+	0xa7, 0xf1, 0x20, 0x6f, 0x98, 0x0d, 0x94, 0xfc, 0x32, 0x64, 0x51, 0xd0, 0x93, 0xb8, 0x67, 0xe8,
+	0xee, 0x31, 0x34, 0x0b, 0x38, 0x66, 0xa3, 0x8f, 0x57, 0x53, 0x63, 0x79, 0x41, 0xcf, 0x78, 0x14,
+	0xe8, 0x0c, 0x55, 0xb9, 0x42, 0x62, 0xf6, 0x93, 0x1e, 0x0b, 0x78, 0x74, 0x7f, 0x86, 0xed, 0x49,
+	0x67, 0x78, 0x2c, 0xe3, 0xc3, 0xd4, 0x67, 0xdf, 0xf2, 0x84, 0x47, 0x7c, 0x90, 0x37, 0xf3, 0x87,
+	0x70, 0x4d, 0x68, 0xe8, 0x2c, 0xe1, 0x51, 0xe8, 0x9b, 0x9e, 0x6e, 0x78, 0x6d, 0x43, 0x38, 0xd1,
+	0x38, 0x79, 0x0f, 0x96, 0x7f, 0xe1, 0x31, 0xcb, 0x74, 0x2f, 0xb4, 0x31, 0x06, 0xa3, 0xf0, 0x25,
+	0x8f, 0x99, 0xa7, 0xc8, 0xee, 0x36, 0xdc, 0x7e, 0x93, 0x65, 0xec, 0xcd, 0x5f, 0x61, 0xad, 0x28,
+	0x29, 0x87, 0xe3, 0xa4, 0xff, 0xe4, 0x19, 0x31, 0x31, 0x4e, 0xcc, 0x77, 0x20, 0xcf, 0x58, 0xb7,
+	0x84, 0xa6, 0x2c, 0x16, 0x3a, 0x52, 0x7d, 0x23, 0x5d, 0x68, 0xa4, 0xda, 0x50, 0x66, 0xd7, 0x26,
+	0xee, 0x49, 0xb7, 0x34, 0xc1, 0x9b, 0xb0, 0xa0, 0xfd, 0x22, 0x69, 0xae, 0x7d, 0x07, 0x56, 0xf5,
+	0x9c, 0x1a, 0x6b, 0x1f, 0xf2, 0x3b, 0xd9, 0x82, 0x26, 0x8d, 0x22, 0xee, 0x53, 0x41, 0x5f, 0x45,
+	0x66, 0x1a, 0x17, 0x21, 0xf2, 0x7f, 0x68, 0xd0, 0x11, 0x0d, 0x23, 0x49, 0xaf, 0x49, 0xfa, 0x04,
+	0x70, 0x3f, 0x83, 0xce, 0x09, 0xcf, 0xc4, 0x09, 0x0f, 0x8e, 0x46, 0x2c, 0x16, 0x99, 0xa9, 0xc7,
+	0x0e, 0xd4, 0x99, 0x04, 0xf4, 0x77, 0xb2, 0x86, 0x41, 0x18, 0x2e, 0x4f, 0xd3, 0x70, 0x44, 0x4c,
+	0x49, 0x63, 0x4e, 0xff, 0xa8, 0xc0, 0xaa, 0x81, 0xd0, 0x3c, 0x06, 0x91, 0x25, 0xd4, 0x37, 0x51,
+	0x4d, 0x80, 0x3c, 0x5c, 0xab, 0x10, 0x6e, 0x1b, 0xaa, 0xc3, 0x30, 0x30, 0x5f, 0xd0, 0x30, 0x0c,
+	0xf2, 0x02, 0xd4, 0xca, 0x05, 0x48, 0x19, 0xcd, 0x78, 0x6c, 0x2f, 0xab, 0x02, 0xa8, 0x1b, 0xb1,
+	0x61, 0xe5, 0x92, 0x65, 0x19, 0x1d, 0x30, 0xbb, 0x2e, 0x09, 0xe6, 0xea, 0x7e, 0x0f, 0x9b, 0xc7,
+	0x0c, 0x7d, 0x35, 0xc9, 0xce, 0x83, 0x7d, 0x0b, 0x3e, 0xba, 0x7f, 0x59, 0xd0, 0x99, 0x51, 0x8f,
+	0x63, 0xe1, 0x14, 0x36, 0xc2, 0x38, 0x14, 0x67, 0x3e, 0x8f, 0x05, 0x0d, 0x63, 0x96, 0x9a, 0x94,
+	0xde, 0xd3, 0x23, 0x7c, 0x46, 0xa4, 0xfb, 0x2c, 0x0e, 0x45, 0x2f, 0x67, 0x57, 0xe3, 0xa2, 0x15,
+	0x96, 0x40, 0xf2, 0x14, 0xa0, 0xa0, 0x51, 0x35, 0xc2, 0xee, 0x42, 0x8d, 0xd3, 0xda, 0x0a, 0xb2,
+	0xce, 0x77, 0x70, 0x7d, 0x8e, 0xc1, 0x39, 0x43, 0xe7, 0x5e, 0x79, 0xe8, 0x6c, 0xa2, 0xb5, 0x5c,
+	0x6a, 0x62, 0x70, 0x32, 0x7c, 0x9c, 0x53, 0xd8, 0xf8, 0x0f, 0xd4, 0xba, 0xbf, 0x59, 0x40, 0x66,
+	0x39, 0xc8, 0x63, 0x58, 0x4d, 0x55, 0x45, 0x4d, 0x8a, 0x77, 0xe6, 0xeb, 0xea, 0xea, 0xc2, 0xeb,
+	0x64, 0xe4, 0x52, 0xe4, 0x21, 0xd4, 0xa3, 0xf0, 0x32, 0x14, 0x26, 0xa1, 0xee, 0x02, 0xf9, 0xaf,
+	0x25, 0x93, 0x7e, 0x4f, 0x94, 0x84, 0x73, 0x08, 0xeb, 0x25, 0xb5, 0x57, 0x79, 0x51, 0xf0, 0x31,
+	0x2a, 0xe8, 0xbc, 0x8a, 0xe8, 0xc1, 0xdf, 0x35, 0x58, 0xfe, 0x1c, 0xf7, 0x41, 0xb2, 0x0f, 0x2b,
+	0x7a, 0xd1, 0x23, 0x44, 0x7f, 0x09, 0x85, 0x45, 0xcc, 0x69, 0x97, 0x30, 0x6c, 0xd4, 0x25, 0xf2,
+	0x00, 0x1a, 0xf9, 0xce, 0x45, 0x3a, 0xb2, 0xc7, 0xa7, 0xf6, 0x37, 0x87, 0x4c, 0xa1, 0x4a, 0xd0,
+	0x03, 0x32, 0xbb, 0xee, 0x90, 0x77, 0xde, 0xb8, 0x7e, 0x39, 0xb7, 0x16, 0x91, 0x73, 0x67, 0xf2,
+	0x8d, 0x46, 0x39, 0x33, 0xbd, 0x36, 0x39, 0x64, 0x76, 0xed, 0x71, 0x97, 0xc8, 0x23, 0x68, 0x16,
+	0x76, 0x12, 0x22, 0xbf, 0xa0, 0xd9, 0xd5, 0xc5, 0xe9, 0xcc, 0xe0, 0x4a, 0xfc, 0x31, 0xac, 0x15,
+	0x1f, 0x6a, 0x72, 0x63, 0xc1, 0x1a, 0xe1, 0xfc, 0x6f, 0xee, 0x9b, 0xee, 0x2e, 0x91, 0x1f, 0xc0,
+	0x59, 0xfc, 0xd0, 0x90, 0xbb, 0xe5, 0xb0, 0x17, 0x3c, 0x81, 0xce, 0x9d, 0x7f, 0x63, 0x53, 0xb6,
+	0x7a, 0xb0, 0x5e, 0x9a, 0xb9, 0xc4, 0x56, 0xa3, 0x79, 0x76, 0x88, 0x3b, 0x9b, 0x73, 0x28, 0x4a,
+	0xc9, 0x33, 0xd8, 0x98, 0x9a, 0x13, 0xc4, 0x99, 0x3b, 0x3c, 0x94, 0x22, 0x7b, 0xd1, 0x60, 0x71,
+	0x97, 0xbe, 0xa8, 0xbd, 0xb4, 0x46, 0xfb, 0xaf, 0xea, 0xf2, 0x1f, 0xc8, 0xfd, 0x7f, 0x06, 0x00,
+	0x04, 0xb8, 0x81, 0xdd, 0x9f, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -461,6 +1291,10 @@ type AgentClient interface {
 	UpdateNodeCapacity(ctx context.Context, in *UpdateNodeCapacityRequest, opts ...grpc.CallOption) (*UpdateNodeCapacityReply, error)
 	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigReply, error)
 	HealthCheck(ctx context.Context, in *HealthCheckRequest, opts ...grpc.CallOption) (*HealthCheckReply, error)
+	DryRunConfig(ctx context.Context, in *DryRunConfigRequest, opts ...grpc.CallOption) (*DryRunConfigReply, error)
+	UpdateNodeResourceTopology(ctx context.Context, in *UpdateNodeResourceTopologyRequest, opts ...grpc.CallOption) (*UpdateNodeResourceTopologyReply, error)
+	PostPodEvents(ctx context.Context, in *PostPodEventsRequest, opts ...grpc.CallOption) (*PostPodEventsReply, error)
+	GetPodResources(ctx context.Context, in *GetPodResourcesRequest, opts ...grpc.CallOption) (*GetPodResourcesReply, error)
 }
 
 type agentClient struct {
@@ -516,6 +1350,42 @@ func (c *agentClient) HealthCheck(ctx context.Context, in *HealthCheckRequest, o
 	return out, nil
 }
 
+func (c *agentClient) DryRunConfig(ctx context.Context, in *DryRunConfigRequest, opts ...grpc.CallOption) (*DryRunConfigReply, error) {
+	out := new(DryRunConfigReply)
+	err := c.cc.Invoke(ctx, "/v1.Agent/DryRunConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UpdateNodeResourceTopology(ctx context.Context, in *UpdateNodeResourceTopologyRequest, opts ...grpc.CallOption) (*UpdateNodeResourceTopologyReply, error) {
+	out := new(UpdateNodeResourceTopologyReply)
+	err := c.cc.Invoke(ctx, "/v1.Agent/UpdateNodeResourceTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) PostPodEvents(ctx context.Context, in *PostPodEventsRequest, opts ...grpc.CallOption) (*PostPodEventsReply, error) {
+	out := new(PostPodEventsReply)
+	err := c.cc.Invoke(ctx, "/v1.Agent/PostPodEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetPodResources(ctx context.Context, in *GetPodResourcesRequest, opts ...grpc.CallOption) (*GetPodResourcesReply, error) {
+	out := new(GetPodResourcesReply)
+	err := c.cc.Invoke(ctx, "/v1.Agent/GetPodResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 type AgentServer interface {
 	GetNode(context.Context, *GetNodeRequest) (*GetNodeReply, error)
@@ -523,6 +1393,42 @@ type AgentServer interface {
 	UpdateNodeCapacity(context.Context, *UpdateNodeCapacityRequest) (*UpdateNodeCapacityReply, error)
 	GetConfig(context.Context, *GetConfigRequest) (*GetConfigReply, error)
 	HealthCheck(context.Context, *HealthCheckRequest) (*HealthCheckReply, error)
+	DryRunConfig(context.Context, *DryRunConfigRequest) (*DryRunConfigReply, error)
+	UpdateNodeResourceTopology(context.Context, *UpdateNodeResourceTopologyRequest) (*UpdateNodeResourceTopologyReply, error)
+	PostPodEvents(context.Context, *PostPodEventsRequest) (*PostPodEventsReply, error)
+	GetPodResources(context.Context, *GetPodResourcesRequest) (*GetPodResourcesReply, error)
+}
+
+// UnimplementedAgentServer can be embedded to have forward compatible implementations.
+type UnimplementedAgentServer struct {
+}
+
+func (*UnimplementedAgentServer) GetNode(ctx context.Context, req *GetNodeRequest) (*GetNodeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNode not implemented")
+}
+func (*UnimplementedAgentServer) PatchNode(ctx context.Context, req *PatchNodeRequest) (*PatchNodeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchNode not implemented")
+}
+func (*UnimplementedAgentServer) UpdateNodeCapacity(ctx context.Context, req *UpdateNodeCapacityRequest) (*UpdateNodeCapacityReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNodeCapacity not implemented")
+}
+func (*UnimplementedAgentServer) GetConfig(ctx context.Context, req *GetConfigRequest) (*GetConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (*UnimplementedAgentServer) HealthCheck(ctx context.Context, req *HealthCheckRequest) (*HealthCheckReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (*UnimplementedAgentServer) DryRunConfig(ctx context.Context, req *DryRunConfigRequest) (*DryRunConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunConfig not implemented")
+}
+func (*UnimplementedAgentServer) UpdateNodeResourceTopology(ctx context.Context, req *UpdateNodeResourceTopologyRequest) (*UpdateNodeResourceTopologyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNodeResourceTopology not implemented")
+}
+func (*UnimplementedAgentServer) PostPodEvents(ctx context.Context, req *PostPodEventsRequest) (*PostPodEventsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostPodEvents not implemented")
+}
+func (*UnimplementedAgentServer) GetPodResources(ctx context.Context, req *GetPodResourcesRequest) (*GetPodResourcesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPodResources not implemented")
 }
 
 func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
@@ -619,6 +1525,78 @@ func _Agent_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_DryRunConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).DryRunConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Agent/DryRunConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).DryRunConfig(ctx, req.(*DryRunConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_UpdateNodeResourceTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeResourceTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).UpdateNodeResourceTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Agent/UpdateNodeResourceTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).UpdateNodeResourceTopology(ctx, req.(*UpdateNodeResourceTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_PostPodEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostPodEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).PostPodEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Agent/PostPodEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).PostPodEvents(ctx, req.(*PostPodEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetPodResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPodResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetPodResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Agent/GetPodResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetPodResources(ctx, req.(*GetPodResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Agent_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.Agent",
 	HandlerType: (*AgentServer)(nil),
@@ -643,42 +1621,23 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HealthCheck",
 			Handler:    _Agent_HealthCheck_Handler,
 		},
+		{
+			MethodName: "DryRunConfig",
+			Handler:    _Agent_DryRunConfig_Handler,
+		},
+		{
+			MethodName: "UpdateNodeResourceTopology",
+			Handler:    _Agent_UpdateNodeResourceTopology_Handler,
+		},
+		{
+			MethodName: "PostPodEvents",
+			Handler:    _Agent_PostPodEvents_Handler,
+		},
+		{
+			MethodName: "GetPodResources",
+			Handler:    _Agent_GetPodResources_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "api.proto",
-}
-
-func init() { proto.RegisterFile("api.proto", fileDescriptor_api_c34572713b59108b) }
-
-var fileDescriptor_api_c34572713b59108b = []byte{
-	// 454 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xad, 0x37, 0x4d, 0x8b, 0x27, 0x50, 0xac, 0x91, 0x05, 0xae, 0x2b, 0x50, 0xb5, 0x17, 0x72,
-	0xc1, 0x52, 0x8a, 0xc4, 0xa7, 0x7a, 0x80, 0xa8, 0x2a, 0x42, 0xa2, 0x42, 0x96, 0xb8, 0x70, 0x41,
-	0x8b, 0xb3, 0x34, 0x51, 0x53, 0xef, 0xe2, 0x6c, 0x23, 0xf9, 0xdf, 0x70, 0xe5, 0x57, 0xf0, 0xd7,
-	0xd0, 0xec, 0xda, 0xc6, 0xb1, 0x09, 0x52, 0x6f, 0xe3, 0x37, 0x33, 0x6f, 0xe7, 0xcd, 0x1b, 0x83,
-	0x2f, 0xf4, 0x22, 0xd1, 0x85, 0x32, 0x0a, 0xd9, 0x7a, 0xc2, 0x03, 0x38, 0x38, 0x97, 0xe6, 0x42,
-	0xcd, 0x64, 0x2a, 0x7f, 0xdc, 0xc8, 0x95, 0xe1, 0x1c, 0xee, 0x36, 0x88, 0x5e, 0x96, 0x88, 0xb0,
-	0x9b, 0xab, 0x99, 0x8c, 0xbc, 0x63, 0x6f, 0xec, 0xa7, 0x36, 0xe6, 0x67, 0xe0, 0x7f, 0x58, 0xa9,
-	0xfc, 0x93, 0x30, 0xd9, 0x1c, 0x0f, 0x80, 0x29, 0x5d, 0xa5, 0x99, 0xd2, 0xd4, 0xa0, 0x85, 0x99,
-	0x47, 0xcc, 0x35, 0x50, 0x8c, 0x21, 0x0c, 0xd7, 0x62, 0x79, 0x23, 0xa3, 0x81, 0x05, 0xdd, 0x07,
-	0x7f, 0x03, 0x81, 0xa5, 0x68, 0x3d, 0x8f, 0x4f, 0x60, 0x5f, 0x13, 0x26, 0x57, 0x91, 0x77, 0x3c,
-	0x18, 0x8f, 0x4e, 0xee, 0x25, 0xeb, 0x49, 0xd2, 0xbc, 0x96, 0xd6, 0x59, 0x9a, 0xbc, 0xd5, 0xac,
-	0x97, 0x25, 0xff, 0xe5, 0xc1, 0xe1, 0x67, 0x3d, 0x13, 0x46, 0x12, 0x36, 0x15, 0x5a, 0x64, 0x0b,
-	0x53, 0xd6, 0xc4, 0x1f, 0x01, 0x32, 0x07, 0x2d, 0x1a, 0xee, 0xa7, 0xc4, 0xbd, 0xb5, 0x25, 0x99,
-	0x36, 0xf5, 0x67, 0xb9, 0x29, 0xca, 0xb4, 0x45, 0x10, 0x9f, 0xc2, 0xfd, 0x4e, 0x1a, 0x03, 0x18,
-	0x5c, 0xc9, 0xb2, 0xda, 0x04, 0x85, 0x7f, 0x65, 0xb3, 0x96, 0xec, 0xd7, 0xec, 0xa5, 0xc7, 0x0f,
-	0xe1, 0xe1, 0xbf, 0xde, 0x25, 0x19, 0x08, 0xc1, 0xb9, 0x34, 0x53, 0x95, 0x7f, 0x5f, 0x5c, 0xd6,
-	0xa6, 0xfc, 0xf4, 0xac, 0x4f, 0x35, 0x48, 0xbe, 0x1c, 0x81, 0x4f, 0x5e, 0x7c, 0xcd, 0xc5, 0x75,
-	0x6d, 0xce, 0x1d, 0x02, 0x2e, 0xc4, 0xb5, 0xc4, 0xe7, 0xb0, 0x97, 0xd9, 0xda, 0x88, 0x59, 0xa1,
-	0x8f, 0x49, 0xe8, 0x26, 0x41, 0xe2, 0x62, 0xa7, 0xac, 0xaa, 0x8e, 0x5f, 0xc1, 0xa8, 0x05, 0xdf,
-	0x4a, 0x51, 0x08, 0xf8, 0x5e, 0x8a, 0xa5, 0x99, 0x4f, 0xe7, 0x32, 0xbb, 0xaa, 0x07, 0x1f, 0x43,
-	0xb0, 0x81, 0xd2, 0xe4, 0x21, 0x0c, 0x65, 0x51, 0xa8, 0xa2, 0xe2, 0x75, 0x1f, 0x27, 0xbf, 0x19,
-	0x0c, 0xdf, 0x5e, 0xca, 0xdc, 0xe0, 0x04, 0xf6, 0xab, 0x0b, 0x44, 0xac, 0xe6, 0x6e, 0x5d, 0x48,
-	0x1c, 0x6c, 0x60, 0xb4, 0xb1, 0x1d, 0x7c, 0x01, 0x7e, 0x73, 0x0c, 0x18, 0x52, 0x41, 0xf7, 0xb0,
-	0x62, 0xec, 0xa0, 0xae, 0x31, 0x05, 0xec, 0xfb, 0x80, 0x8f, 0xfe, 0x7b, 0x17, 0xf1, 0xd1, 0xb6,
-	0x74, 0x33, 0x4c, 0xb3, 0x6a, 0x37, 0x4c, 0xd7, 0xcf, 0x18, 0xfb, 0x7e, 0xf0, 0x1d, 0x3c, 0x85,
-	0x51, 0x6b, 0x59, 0xf8, 0x80, 0x8a, 0xfa, 0x3b, 0x8d, 0xc3, 0x1e, 0x6e, 0xdb, 0xdf, 0xed, 0x7e,
-	0x61, 0xeb, 0xc9, 0xb7, 0x3d, 0xfb, 0x73, 0x3f, 0xfb, 0x13, 0x00, 0x00, 0xff, 0xff, 0x39, 0x76,
-	0xc1, 0x08, 0xe9, 0x03, 0x00, 0x00,
+	Metadata: "pkg/agent/api/v1/api.proto",
 }
@@ -25,6 +25,8 @@ import (
 	"encoding/json"
 
 	"google.golang.org/grpc"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	resmgr "github.com/intel/cri-resource-manager/pkg/apis/resmgr/v1alpha1"
 	resmgr_v1 "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config/api/v1"
@@ -46,7 +48,10 @@ type configUpdater interface {
 	Stop()
 	UpdateConfig(*resmgrConfig)
 	UpdateAdjustment(*resmgrAdjustment)
+	UpdatePod(*core_v1.Pod)
+	DryRunConfig(*resmgrConfig) (*resmgr_v1.DryRunReply, error)
 	StatusChan() chan *resmgrStatus
+	ConfigStatusChan() chan *resmgrConfigStatus
 }
 
 // updater implements configUpdater
@@ -55,7 +60,9 @@ type updater struct {
 	resmgrCli     resmgr_v1.ConfigClient
 	newConfig     chan *resmgrConfig
 	newAdjustment chan *resmgrAdjustment
+	newPod        chan *core_v1.Pod
 	newStatus     chan *resmgrStatus
+	configStatus  chan *resmgrConfigStatus
 }
 
 func newConfigUpdater(socket string) (configUpdater, error) {
@@ -69,7 +76,9 @@ func newConfigUpdater(socket string) (configUpdater, error) {
 
 	u.newConfig = make(chan *resmgrConfig)
 	u.newAdjustment = make(chan *resmgrAdjustment)
+	u.newPod = make(chan *core_v1.Pod)
 	u.newStatus = make(chan *resmgrStatus)
+	u.configStatus = make(chan *resmgrConfigStatus, 1)
 
 	return u, nil
 }
@@ -79,6 +88,7 @@ func (u *updater) Start() error {
 	go func() {
 		var pendingConfig *resmgrConfig
 		var pendingAdjustment *resmgrAdjustment
+		pendingPods := map[types.UID]*core_v1.Pod{}
 
 		var ratelimit <-chan time.Time
 
@@ -94,6 +104,12 @@ func (u *updater) Start() error {
 				pendingAdjustment = adjust
 				ratelimit = time.After(rateLimitTimeout)
 
+			case pod := <-u.newPod:
+				u.Info("scheduling update of pod %s/%s after %v rate-limiting timeout...",
+					pod.Namespace, pod.Name, rateLimitTimeout)
+				pendingPods[pod.UID] = pod
+				ratelimit = time.After(rateLimitTimeout)
+
 			case _ = <-ratelimit:
 				if pendingConfig != nil {
 					mgrErr, err := u.setConfig(pendingConfig)
@@ -104,6 +120,7 @@ func (u *updater) Start() error {
 						if mgrErr != nil {
 							u.Error("cri-resmgr configuration error: %v", mgrErr)
 						}
+						u.sendConfigStatus(&resmgrConfigStatus{err: mgrErr})
 						pendingConfig = nil
 						ratelimit = nil
 					}
@@ -126,6 +143,18 @@ func (u *updater) Start() error {
 					pendingAdjustment = nil
 					ratelimit = nil
 				}
+				for uid, pod := range pendingPods {
+					mgrErr, err := u.updatePod(pod)
+					if err != nil {
+						u.Error("failed to send pod update: %v", err)
+						ratelimit = time.After(retryTimeout)
+						continue
+					}
+					if mgrErr != nil {
+						u.Warn("cri-resmgr pod update error: %v", mgrErr)
+					}
+					delete(pendingPods, uid)
+				}
 			}
 		}
 	}()
@@ -144,10 +173,27 @@ func (u *updater) UpdateAdjustment(c *resmgrAdjustment) {
 	u.newAdjustment <- c
 }
 
+func (u *updater) UpdatePod(pod *core_v1.Pod) {
+	u.newPod <- pod
+}
+
 func (u *updater) StatusChan() chan *resmgrStatus {
 	return u.newStatus
 }
 
+func (u *updater) ConfigStatusChan() chan *resmgrConfigStatus {
+	return u.configStatus
+}
+
+// sendConfigStatus sends a config update status, replacing any unconsumed older one.
+func (u *updater) sendConfigStatus(status *resmgrConfigStatus) {
+	select {
+	case _ = <-u.configStatus:
+	default:
+	}
+	u.configStatus <- status
+}
+
 func (u *updater) setConfig(cfg *resmgrConfig) (error, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), setConfigTimeout)
 	defer cancel()
@@ -167,6 +213,41 @@ func (u *updater) setConfig(cfg *resmgrConfig) (error, error) {
 	}
 }
 
+func (u *updater) updatePod(pod *core_v1.Pod) (error, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), setConfigTimeout)
+	defer cancel()
+
+	req := &resmgr_v1.UpdatePodRequest{
+		Namespace:   pod.Namespace,
+		Name:        pod.Name,
+		Uid:         string(pod.UID),
+		Labels:      pod.Labels,
+		Annotations: pod.Annotations,
+	}
+	u.Debug("sending UpdatePod request to cri-resmgr")
+
+	reply, err := u.resmgrCli.UpdatePod(ctx, req, []grpc.CallOption{grpc.FailFast(false)}...)
+
+	switch {
+	case err != nil:
+		return nil, err
+	case reply.Error != "":
+		return fmt.Errorf("%s", reply.Error), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (u *updater) DryRunConfig(cfg *resmgrConfig) (*resmgr_v1.DryRunReply, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), setConfigTimeout)
+	defer cancel()
+
+	req := &resmgr_v1.DryRunRequest{NodeName: nodeName, Config: *cfg}
+	u.Debug("sending DryRun request to cri-resmgr")
+
+	return u.resmgrCli.DryRun(ctx, req, []grpc.CallOption{grpc.FailFast(false)}...)
+}
+
 func (u *updater) setAdjustment(adjust *resmgrAdjustment) (map[string]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), setConfigTimeout)
 	defer cancel()
@@ -174,10 +255,16 @@ func (u *updater) setAdjustment(adjust *resmgrAdjustment) (map[string]string, er
 	specs := map[string]*resmgr.AdjustmentSpec{}
 	for name, p := range *adjust {
 		specs[name] = &resmgr.AdjustmentSpec{
-			Scope:        p.Spec.NodeScope(nodeName),
-			Resources:    p.Spec.Resources,
-			Classes:      p.Spec.Classes,
-			ToptierLimit: p.Spec.ToptierLimit,
+			Scope:         p.Spec.NodeScope(nodeName),
+			Resources:     p.Spec.Resources,
+			Classes:       p.Spec.Classes,
+			ToptierLimit:  p.Spec.ToptierLimit,
+			CPU:           p.Spec.CPU,
+			MemoryType:    p.Spec.MemoryType,
+			ColdStart:     p.Spec.ColdStart,
+			Affinity:      p.Spec.Affinity,
+			AntiAffinity:  p.Spec.AntiAffinity,
+			PageMigration: p.Spec.PageMigration,
 		}
 	}
 	encoded, err := json.Marshal(specs)
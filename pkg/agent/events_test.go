@@ -0,0 +1,145 @@
+/*
+Copyright 2021 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/intel/cri-resource-manager/pkg/agent/api/v1"
+	"github.com/intel/cri-resource-manager/pkg/log"
+)
+
+// recordedEvent is an event recorded by fakeRecorder.
+type recordedEvent struct {
+	object  *core_v1.ObjectReference
+	evtType string
+	reason  string
+	message string
+}
+
+// fakeRecorder is a record.EventRecorder which records events for checking them.
+type fakeRecorder struct {
+	events []*recordedEvent
+}
+
+func (r *fakeRecorder) Event(object runtime.Object, evtType, reason, message string) {
+	ref, _ := object.(*core_v1.ObjectReference)
+	r.events = append(r.events, &recordedEvent{
+		object:  ref,
+		evtType: evtType,
+		reason:  reason,
+		message: message,
+	})
+}
+
+func (r *fakeRecorder) Eventf(object runtime.Object, evtType, reason, format string, args ...interface{}) {
+	r.Event(object, evtType, reason, fmt.Sprintf(format, args...))
+}
+
+func (r *fakeRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, evtType, reason, format string, args ...interface{}) {
+	r.Eventf(object, evtType, reason, format, args...)
+}
+
+func TestPostPodEvents(t *testing.T) {
+	allocationFailed := &v1.PodEvent{
+		Namespace: "default",
+		Name:      "pod0",
+		Uid:       "uid-0",
+		Type:      core_v1.EventTypeWarning,
+		Reason:    "AllocationFailed",
+		Message:   "failed to allocate resources for container ctr0",
+	}
+	memorySpilled := &v1.PodEvent{
+		Namespace: "default",
+		Name:      "pod1",
+		Uid:       "uid-1",
+		Type:      core_v1.EventTypeNormal,
+		Reason:    "MemorySpilled",
+		Message:   "memory of container ctr1 extended to NUMA nodes 0,1",
+	}
+
+	tcases := []struct {
+		name     string
+		events   []*v1.PodEvent
+		expected []*v1.PodEvent
+		fail     bool
+	}{
+		{
+			name:     "successfully posted events",
+			events:   []*v1.PodEvent{allocationFailed, memorySpilled},
+			expected: []*v1.PodEvent{allocationFailed, memorySpilled},
+		},
+		{
+			name: "invalid event type",
+			events: []*v1.PodEvent{
+				{Namespace: "default", Name: "pod0", Type: "Error", Reason: "AllocationFailed"},
+			},
+			fail: true,
+		},
+		{
+			name: "incomplete event",
+			events: []*v1.PodEvent{
+				{Namespace: "default", Type: core_v1.EventTypeWarning, Reason: "AllocationFailed"},
+			},
+			fail: true,
+		},
+		{
+			name:     "invalid events are skipped",
+			events:   []*v1.PodEvent{allocationFailed, {Type: core_v1.EventTypeNormal}, memorySpilled},
+			expected: []*v1.PodEvent{allocationFailed, memorySpilled},
+			fail:     true,
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &fakeRecorder{}
+			g := &grpcServer{
+				Logger:   log.NewLogger("agent-test"),
+				recorder: recorder,
+			}
+
+			_, err := g.PostPodEvents(context.Background(), &v1.PostPodEventsRequest{Events: tc.events})
+			if tc.fail && err == nil {
+				t.Errorf("expected an error, got none")
+			}
+			if !tc.fail && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if len(recorder.events) != len(tc.expected) {
+				t.Fatalf("expected %d events, got %d", len(tc.expected), len(recorder.events))
+			}
+			for i, e := range tc.expected {
+				r := recorder.events[i]
+				if r.object == nil || r.object.Kind != "Pod" || r.object.Namespace != e.Namespace ||
+					r.object.Name != e.Name || string(r.object.UID) != e.Uid {
+					t.Errorf("event %d: expected pod %s/%s (%s), got %+v", i, e.Namespace, e.Name, e.Uid, r.object)
+				}
+				if r.evtType != e.Type || r.reason != e.Reason || r.message != e.Message {
+					t.Errorf("event %d: expected %s %s %q, got %s %s %q", i,
+						e.Type, e.Reason, e.Message, r.evtType, r.reason, r.message)
+				}
+			}
+		})
+	}
+}
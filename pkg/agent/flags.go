@@ -29,6 +29,7 @@ type options struct {
 	configNs      string
 	configMapName string
 	labelName     string
+	stateDir      string
 }
 
 var opts = options{}
@@ -40,4 +41,5 @@ func init() {
 	flag.StringVar(&opts.configNs, "config-ns", "kube-system", "Kubernetes namespace where to look for config")
 	flag.StringVar(&opts.configMapName, "configmap-name", "cri-resmgr-config", "Name of the K8s ConfigMap to watch")
 	flag.StringVar(&opts.labelName, "label-name", kubernetes.ResmgrKey("group"), "Name of the label used to assign a node to a configuration group.")
+	flag.StringVar(&opts.stateDir, "state-dir", "/var/lib/cri-resmgr-agent", "Directory where to persist the last known configuration, empty string disables persistence")
 }
@@ -24,15 +24,21 @@ import (
 	"time"
 
 	core_v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	k8swatch "k8s.io/apimachinery/pkg/watch"
 	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcore_v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
 	resmgr "github.com/intel/cri-resource-manager/pkg/apis/resmgr/generated/clientset/versioned/typed/resmgr/v1alpha1"
+	resmgrv1alpha1 "github.com/intel/cri-resource-manager/pkg/apis/resmgr/v1alpha1"
 
 	agent_v1 "github.com/intel/cri-resource-manager/pkg/agent/api/v1"
 )
@@ -110,6 +116,116 @@ func patchNodeStatus(cli *k8sclient.Clientset, fields map[string]string) error {
 	return err
 }
 
+// updateNodeResourceTopology is a helper for creating or updating the NodeResourceTopology of our node.
+func updateNodeResourceTopology(cli *k8sclient.Clientset, extCli *resmgr.CriresmgrV1alpha1Client, policies []string, zones []*agent_v1.TopologyZone) error {
+	nrtZones := make([]resmgrv1alpha1.Zone, 0, len(zones))
+	for _, z := range zones {
+		zone := resmgrv1alpha1.Zone{
+			Name:   z.Name,
+			Type:   z.Type,
+			Parent: z.Parent,
+		}
+		for _, r := range z.Resources {
+			info := resmgrv1alpha1.ResourceInfo{Name: r.Name}
+			for _, q := range []struct {
+				value string
+				qty   *resource.Quantity
+			}{
+				{r.Capacity, &info.Capacity},
+				{r.Allocatable, &info.Allocatable},
+				{r.Available, &info.Available},
+			} {
+				qty, err := resource.ParseQuantity(q.value)
+				if err != nil {
+					return agentError("zone %s: invalid %s quantity %q: %v",
+						z.Name, r.Name, q.value, err)
+				}
+				*q.qty = qty
+			}
+			zone.Resources = append(zone.Resources, info)
+		}
+		nrtZones = append(nrtZones, zone)
+	}
+
+	nrtCli := extCli.NodeResourceTopologies()
+	nrt, err := nrtCli.Get(nodeName, meta_v1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return agentError("failed to get NodeResourceTopology %q: %v", nodeName, err)
+		}
+
+		node, err := getNodeObject(cli)
+		if err != nil {
+			return err
+		}
+		nrt = &resmgrv1alpha1.NodeResourceTopology{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name: nodeName,
+				OwnerReferences: []meta_v1.OwnerReference{
+					{
+						APIVersion: "v1",
+						Kind:       "Node",
+						Name:       node.Name,
+						UID:        node.UID,
+					},
+				},
+			},
+			TopologyPolicies: policies,
+			Zones:            nrtZones,
+		}
+		if _, err := nrtCli.Create(nrt); err != nil {
+			return agentError("failed to create NodeResourceTopology %q: %v", nodeName, err)
+		}
+		return nil
+	}
+
+	nrt.TopologyPolicies = policies
+	nrt.Zones = nrtZones
+	if _, err := nrtCli.Update(nrt); err != nil {
+		return agentError("failed to update NodeResourceTopology %q: %v", nodeName, err)
+	}
+
+	return nil
+}
+
+// newEventRecorder creates a recorder for posting events on objects of our node.
+//
+// The recorder aggregates similar events and rate-limits events per object,
+// so misbehaving workloads cannot flood the API server with events.
+func newEventRecorder(cli *k8sclient.Clientset) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcore_v1.EventSinkImpl{
+		Interface: cli.CoreV1().Events(""),
+	})
+	return broadcaster.NewRecorder(scheme.Scheme, core_v1.EventSource{
+		Component: "cri-resmgr",
+		Host:      nodeName,
+	})
+}
+
+// postPodEvent is a helper for posting an event on a Pod object.
+func postPodEvent(recorder record.EventRecorder, e *agent_v1.PodEvent) error {
+	switch e.Type {
+	case core_v1.EventTypeNormal, core_v1.EventTypeWarning:
+	default:
+		return agentError("invalid event type %q", e.Type)
+	}
+	if e.Namespace == "" || e.Name == "" || e.Reason == "" {
+		return agentError("incomplete pod event %v", e)
+	}
+
+	pod := &core_v1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  e.Namespace,
+		Name:       e.Name,
+		UID:        types.UID(e.Uid),
+	}
+	recorder.Event(pod, e.Type, e.Reason, e.Message)
+
+	return nil
+}
+
 // patchAdjustmentStatus is a helper for patching the status of a Adjustment CRD.
 func patchAdjustmentStatus(cli *resmgr.CriresmgrV1alpha1Client, status *resmgrStatus, names ...string) error {
 	return nil
@@ -136,6 +252,11 @@ type queryFn func(ns namespace, name string) (interface{}, error)
 const (
 	// SyntheticMissing is a synthetic initial event for currently non-existent object.
 	SyntheticMissing = k8swatch.EventType("SyntheticMissing")
+
+	// minWatchRetry is the initial delay for retrying to create a failed watch.
+	minWatchRetry = 1 * time.Second
+	// maxWatchRetry is the maximum delay for retrying to create a failed watch.
+	maxWatchRetry = 2 * time.Minute
 )
 
 func newWatch(parent *watcher, kind string, ns namespace, open openFn, query queryFn) *watch {
@@ -212,7 +333,7 @@ func newAdjustmentCRDWatch(parent *watcher, ns namespace) *watch {
 				return nil, err
 			}
 			if crds == nil || len(crds.Items) == 0 {
-				crds = nil
+				return nil, nil
 			}
 			return crds, nil
 		})
@@ -220,6 +341,30 @@ func newAdjustmentCRDWatch(parent *watcher, ns namespace) *watch {
 	return w
 }
 
+// newConfigCRDWatch creates a watch for k8s ResourceManagerConfig CRDs
+func newConfigCRDWatch(parent *watcher, ns namespace) *watch {
+	w := newWatch(parent, "ConfigCRD", ns,
+		func(ns namespace, name string) (k8swatch.Interface, error) {
+			k8w, err := parent.resmgrCli.ResourceManagerConfigs(string(ns)).Watch(meta_v1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return k8w, nil
+		},
+		func(ns namespace, name string) (interface{}, error) {
+			crds, err := parent.resmgrCli.ResourceManagerConfigs(string(ns)).List(meta_v1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			if crds == nil || len(crds.Items) == 0 {
+				return nil, nil
+			}
+			return crds, nil
+		})
+	w.Start("ConfigCRD")
+	return w
+}
+
 func (w *watch) Name() string {
 	ns, name := w.ns, w.name
 	if ns != "" {
@@ -243,6 +388,7 @@ func (w *watch) Query() (interface{}, error) {
 func (w *watch) Start(name string) {
 	w.Stop()
 	w.name = name
+	w.parent.currentConfig.setReachable(w, true)
 
 	if w.name == "" {
 		return
@@ -256,19 +402,43 @@ func (w *watch) Start(name string) {
 		var err error
 
 		// let the watcher know not to expect initial event
-		if objs, _ := w.queryfn(w.ns, w.name); objs == nil {
-			w.events <- k8swatch.Event{Type: SyntheticMissing}
-		}
+		queried := w.queryMissing()
+		missing := false
+		retry := minWatchRetry
 
 		for {
 			if events == nil {
-				w.parent.Info("creating %s watch", w.Name())
-				if k8w, err = w.openfn(w.ns, w.name); err != nil {
-					w.parent.Warn("failed to create %s watch: %v", w.Name(), err)
-					ratelimit = time.After(1 * time.Second)
-				} else {
+				w.parent.Debug("creating %s watch", w.Name())
+				k8w, err = w.openfn(w.ns, w.name)
+				switch {
+				case err == nil:
+					w.parent.Info("created %s watch", w.Name())
 					events = k8w.ResultChan()
 					ratelimit = nil
+					missing = false
+					retry = minWatchRetry
+					w.parent.currentConfig.setReachable(w, true)
+					if !queried {
+						queried = w.queryMissing()
+					}
+				case k8serrors.IsNotFound(err):
+					// the resource type is not known (yet), for instance a CRD is not installed
+					if !missing {
+						w.parent.Info("%s not available (%v), retrying in the background",
+							w.Name(), err)
+						missing = true
+					}
+					w.parent.currentConfig.setReachable(w, true)
+					if !queried {
+						w.events <- k8swatch.Event{Type: SyntheticMissing}
+						queried = true
+					}
+					ratelimit, retry = time.After(retry), nextWatchRetry(retry)
+				default:
+					w.parent.Warn("failed to create %s watch: %v", w.Name(), err)
+					missing = false
+					w.parent.currentConfig.setReachable(w, false)
+					ratelimit, retry = time.After(retry), nextWatchRetry(retry)
 				}
 			}
 
@@ -292,6 +462,29 @@ func (w *watch) Start(name string) {
 	}()
 }
 
+// nextWatchRetry returns the delay for the next attempt to create a watch.
+func nextWatchRetry(retry time.Duration) time.Duration {
+	if retry *= 2; retry > maxWatchRetry {
+		retry = maxWatchRetry
+	}
+	return retry
+}
+
+// queryMissing emits a SyntheticMissing event if the watched object does not exist.
+// It returns false if the API server could not tell whether the object exists.
+func (w *watch) queryMissing() bool {
+	objs, err := w.queryfn(w.ns, w.name)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		w.parent.Warn("failed to query %s: %v", w.Name(), err)
+		w.parent.currentConfig.setReachable(w, false)
+		return false
+	}
+	if objs == nil {
+		w.events <- k8swatch.Event{Type: SyntheticMissing}
+	}
+	return true
+}
+
 // Close closes a watch.
 func (w *watch) Stop() {
 	select {
@@ -0,0 +1,151 @@
+/*
+Copyright 2020 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"reflect"
+
+	core_v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/intel/cri-resource-manager/pkg/log"
+)
+
+// podChanSize is the maximum number of Pod updates waiting to be sent.
+const podChanSize = 64
+
+// Get a Pod running on this node
+type getPodFn func(namespace, name, uid string) (*core_v1.Pod, error)
+
+// podWatcher is the interface for tracking Pods scheduled to our node.
+type podWatcher interface {
+	Start() error
+	Stop()
+	GetPod(namespace, name, uid string) (*core_v1.Pod, error)
+	PodChan() <-chan *core_v1.Pod
+}
+
+// pods implements podWatcher.
+type pods struct {
+	log.Logger
+	cli        *k8sclient.Clientset // client for accessing k8s api
+	store      cache.Store          // Pods scheduled to our node
+	controller cache.Controller     // controller keeping store up to date
+	stop       chan struct{}        // channel for stopping the controller
+	podChan    chan *core_v1.Pod    // Pods with changed labels or annotations
+}
+
+// newPodWatcher creates a new podWatcher instance.
+func newPodWatcher(cli *k8sclient.Clientset) (podWatcher, error) {
+	p := &pods{
+		Logger:  log.NewLogger("pods"),
+		cli:     cli,
+		podChan: make(chan *core_v1.Pod, podChanSize),
+	}
+	return p, nil
+}
+
+// Start starts watching Pods scheduled to our node.
+func (p *pods) Start() error {
+	p.Info("starting pod watcher...")
+	if nodeName == "" {
+		return agentError("node name not set, NODE_NAME env variable should be set to match the name of this k8s Node")
+	}
+
+	selector := fields.OneTermEqualSelector("spec.nodeName", nodeName)
+	lw := cache.NewListWatchFromClient(p.cli.CoreV1().RESTClient(), "pods", meta_v1.NamespaceAll, selector)
+	p.store, p.controller = cache.NewInformer(lw, &core_v1.Pod{}, 0, cache.ResourceEventHandlerFuncs{
+		UpdateFunc: p.podUpdated,
+	})
+
+	p.stop = make(chan struct{})
+	go p.controller.Run(p.stop)
+
+	return nil
+}
+
+// Stop stops watching Pods.
+func (p *pods) Stop() {
+	if p.stop != nil {
+		close(p.stop)
+		p.stop = nil
+	}
+}
+
+// PodChan returns the channel for Pods with changed labels or annotations.
+func (p *pods) PodChan() <-chan *core_v1.Pod {
+	return p.podChan
+}
+
+// podUpdated checks an updated Pod for changes in labels or annotations.
+func (p *pods) podUpdated(oldObj, newObj interface{}) {
+	old, ok := oldObj.(*core_v1.Pod)
+	if !ok {
+		return
+	}
+	pod, ok := newObj.(*core_v1.Pod)
+	if !ok {
+		return
+	}
+
+	if reflect.DeepEqual(old.Labels, pod.Labels) && reflect.DeepEqual(old.Annotations, pod.Annotations) {
+		return
+	}
+
+	p.Info("labels or annotations of pod %s/%s changed", pod.Namespace, pod.Name)
+
+	select {
+	case p.podChan <- pod:
+	default:
+		p.Warn("pod update queue full, dropping update of pod %s/%s", pod.Namespace, pod.Name)
+	}
+}
+
+// GetPod looks up a Pod, falling back to querying the API server if we don't know it (yet).
+func (p *pods) GetPod(namespace, name, uid string) (*core_v1.Pod, error) {
+	if p.store != nil {
+		obj, ok, err := p.store.GetByKey(namespace + "/" + name)
+		if err != nil {
+			p.Warn("failed to look up pod %s/%s: %v", namespace, name, err)
+		}
+		if ok {
+			if pod, ok := obj.(*core_v1.Pod); ok && (uid == "" || string(pod.UID) == uid) {
+				return pod, nil
+			}
+		}
+	}
+
+	p.Debug("pod %s/%s not found in local store, querying API server", namespace, name)
+
+	pod, err := p.cli.CoreV1().Pods(namespace).Get(context.TODO(), name, meta_v1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, agentError("pod %s/%s not found", namespace, name)
+		}
+		return nil, agentError("failed to get pod %s/%s: %v", namespace, name, err)
+	}
+	if uid != "" && string(pod.UID) != uid {
+		return nil, agentError("pod %s/%s not found with UID %s", namespace, name, uid)
+	}
+
+	return pod, nil
+}
@@ -28,8 +28,11 @@ import (
 	"google.golang.org/grpc"
 	core_v1 "k8s.io/api/core/v1"
 	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 
 	v1 "github.com/intel/cri-resource-manager/pkg/agent/api/v1"
+	resmgr "github.com/intel/cri-resource-manager/pkg/apis/resmgr/generated/clientset/versioned/typed/resmgr/v1alpha1"
+	"github.com/intel/cri-resource-manager/pkg/auth"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/sockets"
 	"github.com/intel/cri-resource-manager/pkg/log"
 )
@@ -43,17 +46,27 @@ type agentServer interface {
 // server implements agentServer.
 type server struct {
 	log.Logger
-	cli       *k8sclient.Clientset // client for accessing k8s api
-	server    *grpc.Server         // gRPC server instance
-	getConfig getConfigFn          // Getter function for current config
+	cli       *k8sclient.Clientset            // client for accessing k8s api
+	extCli    *resmgr.CriresmgrV1alpha1Client // client for accessing our custom resources
+	recorder  record.EventRecorder            // recorder for posting events
+	server    *grpc.Server                    // gRPC server instance
+	getConfig getConfigFn                     // Getter function for current config
+	getState  getConfigStateFn                // Getter function for config freshness
+	dryRun    dryRunConfigFn                  // Function for dry-running config
+	getPod    getPodFn                        // Getter function for Pods on our node
 }
 
 // newAgentServer creates new agentServer instance.
-func newAgentServer(cli *k8sclient.Clientset, getFn getConfigFn) (agentServer, error) {
+func newAgentServer(cli *k8sclient.Clientset, extCli *resmgr.CriresmgrV1alpha1Client, getFn getConfigFn, stateFn getConfigStateFn, dryRunFn dryRunConfigFn, podFn getPodFn) (agentServer, error) {
 	s := &server{
 		Logger:    log.NewLogger("server"),
 		cli:       cli,
+		extCli:    extCli,
+		recorder:  newEventRecorder(cli),
 		getConfig: getFn,
+		getState:  stateFn,
+		dryRun:    dryRunFn,
+		getPod:    podFn,
 	}
 
 	return s, nil
@@ -77,12 +90,21 @@ func (s *server) Start(socket string) error {
 		return agentError("failed to listen to socket: %v", err)
 	}
 
-	serverOpts := []grpc.ServerOption{}
+	serverOpts, err := auth.ServerOptions(auth.AgentSocket)
+	if err != nil {
+		lis.Close()
+		return agentError("failed to set up socket access control: %v", err)
+	}
 	s.server = grpc.NewServer(serverOpts...)
 	gs := &grpcServer{
 		Logger:    s.Logger,
 		cli:       s.cli,
+		extCli:    s.extCli,
+		recorder:  s.recorder,
 		getConfig: s.getConfig,
+		getState:  s.getState,
+		dryRun:    s.dryRun,
+		getPod:    s.getPod,
 	}
 	v1.RegisterAgentServer(s.server, gs)
 
@@ -106,7 +128,12 @@ func (s *server) Stop() {
 type grpcServer struct {
 	log.Logger
 	cli       *k8sclient.Clientset
+	extCli    *resmgr.CriresmgrV1alpha1Client
+	recorder  record.EventRecorder
 	getConfig getConfigFn
+	getState  getConfigStateFn
+	dryRun    dryRunConfigFn
+	getPod    getPodFn
 }
 
 // GetNode gets K8s node object.
@@ -170,10 +197,91 @@ func (g *grpcServer) UpdateNodeCapacity(ctx context.Context, req *v1.UpdateNodeC
 	return rpl, err
 }
 
+// UpdateNodeResourceTopology creates or updates the NodeResourceTopology of the node.
+func (g *grpcServer) UpdateNodeResourceTopology(ctx context.Context, req *v1.UpdateNodeResourceTopologyRequest) (*v1.UpdateNodeResourceTopologyReply, error) {
+	g.Debug("received UpdateNodeResourceTopologyRequest: %v", req)
+	rpl := &v1.UpdateNodeResourceTopologyReply{}
+
+	err := updateNodeResourceTopology(g.cli, g.extCli, req.TopologyPolicies, req.Zones)
+
+	return rpl, err
+}
+
+// PostPodEvents posts events on Pod objects.
+func (g *grpcServer) PostPodEvents(ctx context.Context, req *v1.PostPodEventsRequest) (*v1.PostPodEventsReply, error) {
+	g.Debug("received PostPodEventsRequest: %v", req)
+	rpl := &v1.PostPodEventsReply{}
+
+	// skip invalid events, posting the rest and reporting the skipped ones
+	errs := []string{}
+	for _, e := range req.Events {
+		if err := postPodEvent(g.recorder, e); err != nil {
+			g.Warn("skipping pod event: %v", err)
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return rpl, agentError("failed to post %d of %d pod events: %s",
+			len(errs), len(req.Events), strings.Join(errs, ", "))
+	}
+
+	return rpl, nil
+}
+
+// GetPodResources gets the resource requirements of the containers of a Pod.
+func (g *grpcServer) GetPodResources(ctx context.Context, req *v1.GetPodResourcesRequest) (*v1.GetPodResourcesReply, error) {
+	g.Debug("received GetPodResourcesRequest: %v", req)
+	rpl := &v1.GetPodResourcesReply{}
+
+	if g.getPod == nil {
+		return rpl, agentError("pod watcher not available")
+	}
+
+	pod, err := g.getPod(req.Namespace, req.Name, req.Uid)
+	if err != nil {
+		return rpl, agentError("failed to get pod resources: %v", err)
+	}
+
+	rpl.InitContainers = make(map[string]*v1.ContainerResources)
+	for _, c := range pod.Spec.InitContainers {
+		rpl.InitContainers[c.Name] = containerResources(&c.Resources)
+	}
+	rpl.Containers = make(map[string]*v1.ContainerResources)
+	for _, c := range pod.Spec.Containers {
+		rpl.Containers[c.Name] = containerResources(&c.Resources)
+	}
+
+	return rpl, nil
+}
+
+// containerResources converts container resource requirements to their gRPC representation.
+func containerResources(r *core_v1.ResourceRequirements) *v1.ContainerResources {
+	res := &v1.ContainerResources{
+		Requests: make(map[string]string),
+		Limits:   make(map[string]string),
+	}
+	for name, qty := range r.Requests {
+		res.Requests[string(name)] = qty.String()
+	}
+	for name, qty := range r.Limits {
+		res.Limits[string(name)] = qty.String()
+	}
+	return res
+}
+
 // HealthCheck checks if the agent is in healthy state
 func (g *grpcServer) HealthCheck(ctx context.Context, req *v1.HealthCheckRequest) (*v1.HealthCheckReply, error) {
 	g.Debug("received HealthCheckRequest: %v", req)
-	return &v1.HealthCheckReply{}, nil
+	rpl := &v1.HealthCheckReply{}
+	if g.getState != nil {
+		state, updated := g.getState()
+		rpl.ConfigState = state
+		if !updated.IsZero() {
+			rpl.ConfigUpdated = updated.Unix()
+		}
+	}
+	return rpl, nil
 }
 
 func isNativeResource(name string) bool {
@@ -202,3 +310,31 @@ func (g *grpcServer) GetConfig(ctx context.Context, req *v1.GetConfigRequest) (*
 	}
 	return rpl, nil
 }
+
+// DryRunConfig checks what a cri-resmgr configuration would change without applying it.
+func (g *grpcServer) DryRunConfig(ctx context.Context, req *v1.DryRunConfigRequest) (*v1.DryRunConfigReply, error) {
+	g.Debug("received DryRunConfigRequest: %v", req)
+	rpl := &v1.DryRunConfigReply{}
+
+	cfg := resmgrConfig(req.Config)
+	if len(cfg) == 0 && g.getConfig != nil {
+		cfg = g.getConfig()
+	}
+
+	reply, err := g.dryRun(&cfg)
+	if err != nil {
+		return rpl, agentError("failed to dry-run configuration: %v", err)
+	}
+
+	rpl.Diff = make(map[string]*v1.ModuleDiff)
+	for module, diff := range reply.Diff {
+		md := &v1.ModuleDiff{}
+		for _, c := range diff.Changes {
+			md.Changes = append(md.Changes, &v1.FieldChange{Field: c.Field, Old: c.Old, New: c.New})
+		}
+		rpl.Diff[module] = md
+	}
+	rpl.Errors = reply.Errors
+
+	return rpl, nil
+}
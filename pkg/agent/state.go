@@ -0,0 +1,108 @@
+/*
+Copyright 2021 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// stateFile is the name of the file we persist our last known state in.
+	stateFile = "state.json"
+)
+
+const (
+	// configFresh is the state of configuration in sync with the API server.
+	configFresh = "fresh"
+	// configStale is the state of configuration after losing the API server.
+	configStale = "stale"
+	// configPersisted is the state of configuration loaded from disk.
+	configPersisted = "persisted"
+	// configNone is the state of having no configuration from any source.
+	configNone = "none"
+)
+
+// Sources of configuration and adjustments we need to sync with.
+const (
+	sourceNodeConfig  = "node ConfigMap"
+	sourceGroupConfig = "group ConfigMap"
+	sourceConfigCRD   = "ResourceManagerConfig CRDs"
+	sourceAdjustments = "Adjustment CRDs"
+)
+
+// configSources are the sources we need to sync with to have fresh configuration.
+var configSources = []string{sourceNodeConfig, sourceGroupConfig, sourceConfigCRD}
+
+// persistedState is the last known state received from the API server.
+type persistedState struct {
+	// Config is the last configuration received.
+	Config resmgrConfig `json:"config,omitempty"`
+	// Kind is the kind of configuration, for instance node or group.
+	Kind string `json:"kind,omitempty"`
+	// Adjustments are the last external adjustments received.
+	Adjustments resmgrAdjustment `json:"adjustments,omitempty"`
+	// Updated is the last time the state was received.
+	Updated time.Time `json:"updated"`
+}
+
+// loadState loads persisted state from the given directory.
+func loadState(dir string) (*persistedState, error) {
+	path := filepath.Join(dir, stateFile)
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, agentError("failed to read persisted state %q: %v", path, err)
+	}
+
+	state := &persistedState{}
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, agentError("failed to parse persisted state %q: %v", path, err)
+	}
+
+	return state, nil
+}
+
+// saveState persists state in the given directory.
+func saveState(dir string, state *persistedState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return agentError("failed to marshal state: %v", err)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return agentError("failed to create state directory %q: %v", dir, err)
+	}
+
+	// Write a temporary file and rename it, to never leave a partial state behind.
+	path := filepath.Join(dir, stateFile)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, raw, 0600); err != nil {
+		return agentError("failed to write state %q: %v", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return agentError("failed to save state %q: %v", path, err)
+	}
+
+	return nil
+}
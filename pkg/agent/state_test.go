@@ -0,0 +1,225 @@
+/*
+Copyright 2021 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8swatch "k8s.io/apimachinery/pkg/watch"
+
+	resmgr "github.com/intel/cri-resource-manager/pkg/apis/resmgr/v1alpha1"
+	"github.com/intel/cri-resource-manager/pkg/log"
+)
+
+// testPersistedState returns persisted state with some configuration and adjustments.
+func testPersistedState() *persistedState {
+	return &persistedState{
+		Config: resmgrConfig{"policy": "Active: topology-aware\n"},
+		Kind:   "node",
+		Adjustments: resmgrAdjustment{
+			"adjust-0": &resmgr.Adjustment{ObjectMeta: meta_v1.ObjectMeta{Name: "adjust-0"}},
+		},
+		Updated: time.Now().Round(0).UTC(),
+	}
+}
+
+func TestStatePersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "agent-state-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	state, err := loadState(dir)
+	if err != nil || state != nil {
+		t.Errorf("expected no state and no error without a state file, got %v, %v", state, err)
+	}
+
+	saved := testPersistedState()
+	if err := saveState(filepath.Join(dir, "state"), saved); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+	loaded, err := loadState(filepath.Join(dir, "state"))
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Config, saved.Config) || loaded.Kind != saved.Kind ||
+		!loaded.Updated.Equal(saved.Updated) {
+		t.Errorf("expected loaded state %+v, got %+v", saved, loaded)
+	}
+	if adjust, ok := loaded.Adjustments["adjust-0"]; !ok || adjust.Name != "adjust-0" {
+		t.Errorf("expected adjustment adjust-0 in loaded state, got %v", loaded.Adjustments)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, stateFile), []byte("{"), 0600); err != nil {
+		t.Fatalf("failed to write state file: %v", err)
+	}
+	if _, err := loadState(dir); err == nil {
+		t.Errorf("expected an error for a corrupted state file")
+	}
+}
+
+func TestSendPersisted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "agent-state-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	stateDir := opts.stateDir
+	opts.stateDir = dir
+	defer func() { opts.stateDir = stateDir }()
+
+	w := &watcher{
+		Logger:         log.NewLogger("agent-test"),
+		currentConfig:  newCachedConfig(),
+		configChan:     make(chan resmgrConfig, 1),
+		adjustmentChan: make(chan resmgrAdjustment, 1),
+	}
+	w.currentConfig.persisted = testPersistedState()
+
+	w.sendPersisted()
+
+	select {
+	case cfg := <-w.configChan:
+		if !reflect.DeepEqual(cfg, w.currentConfig.persisted.Config) {
+			t.Errorf("expected persisted configuration %v, got %v", w.currentConfig.persisted.Config, cfg)
+		}
+	default:
+		t.Errorf("persisted configuration not pushed")
+	}
+	select {
+	case adjust := <-w.adjustmentChan:
+		if _, ok := adjust["adjust-0"]; !ok || len(adjust) != 1 {
+			t.Errorf("expected persisted adjustments, got %v", adjust)
+		}
+	default:
+		t.Errorf("persisted adjustments not pushed")
+	}
+
+	if state, _ := w.currentConfig.getState(); state != configPersisted {
+		t.Errorf("expected state %q, got %q", configPersisted, state)
+	}
+	if _, err := os.Stat(filepath.Join(dir, stateFile)); !os.IsNotExist(err) {
+		t.Errorf("expected persisted state not to be overwritten before sync")
+	}
+
+	// without persisted adjustments we should not push empty ones before sync
+	w.currentConfig.persisted.Adjustments = nil
+	w.sendPersisted()
+	<-w.configChan
+	select {
+	case adjust := <-w.adjustmentChan:
+		t.Errorf("unexpected adjustments pushed: %v", adjust)
+	default:
+	}
+}
+
+func TestConfigState(t *testing.T) {
+	c := newCachedConfig()
+	nodew, cfgw := &watch{kind: "Node"}, &watch{kind: "ConfigMap"}
+
+	expectState := func(expected string) {
+		t.Helper()
+		if state, _ := c.getState(); state != expected {
+			t.Errorf("expected configuration state %q, got %q", expected, state)
+		}
+	}
+
+	expectState(configNone)
+	c.persisted = testPersistedState()
+	expectState(configPersisted)
+
+	for _, source := range configSources {
+		c.markSynced(source)
+	}
+	expectState(configFresh)
+
+	c.setReachable(nodew, false)
+	c.setReachable(cfgw, false)
+	expectState(configStale)
+
+	// one watch recovering must not mask another one still failing
+	c.setReachable(nodew, true)
+	expectState(configStale)
+	c.markSynced(sourceAdjustments)
+	expectState(configStale)
+
+	c.setReachable(cfgw, true)
+	expectState(configFresh)
+}
+
+func TestWatchNotFound(t *testing.T) {
+	w := &watcher{
+		Logger:        log.NewLogger("agent-test"),
+		currentConfig: newCachedConfig(),
+	}
+
+	var lock sync.Mutex
+	opened := 0
+	notFound := k8serrors.NewNotFound(schema.GroupResource{Resource: "test"}, "test")
+	tw := newWatch(w, "Test", "default",
+		func(namespace, string) (k8swatch.Interface, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			opened++
+			return nil, notFound
+		},
+		func(namespace, string) (interface{}, error) {
+			return nil, notFound
+		})
+	tw.Start("test")
+	defer tw.Stop()
+
+	select {
+	case e := <-tw.ResultChan():
+		if e.Type != SyntheticMissing {
+			t.Errorf("expected %s event, got %v", SyntheticMissing, e.Type)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for %s event", SyntheticMissing)
+	}
+
+	time.Sleep(minWatchRetry / 2)
+	lock.Lock()
+	if opened != 1 {
+		t.Errorf("expected a single attempt to create the watch, got %d", opened)
+	}
+	lock.Unlock()
+
+	w.currentConfig.Lock()
+	if len(w.currentConfig.unreachable) != 0 {
+		t.Errorf("watch for a missing resource should not be unreachable")
+	}
+	w.currentConfig.Unlock()
+
+	if retry := nextWatchRetry(maxWatchRetry / 2); retry != maxWatchRetry {
+		t.Errorf("expected retry delay %v, got %v", maxWatchRetry, retry)
+	}
+	if retry := nextWatchRetry(maxWatchRetry); retry != maxWatchRetry {
+		t.Errorf("expected retry delay %v, got %v", maxWatchRetry, retry)
+	}
+}
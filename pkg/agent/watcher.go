@@ -35,12 +35,21 @@ import (
 
 type cachedConfig struct {
 	sync.RWMutex
-	nodeCfg  *resmgrConfig    // node-specific configuration
-	groupCfg *resmgrConfig    // group-specific configuration
-	group    string           // group name, "" for default
-	inscope  resmgrAdjustment // external adjustments that apply to this node
-	ignored  resmgrAdjustment // external adjustments that do not apply to this node
-	status   *resmgrStatus    // latest adjustment update status
+	nodeCfg  *resmgrConfig                            // node-specific configuration
+	groupCfg *resmgrConfig                            // group-specific configuration
+	group    string                                   // group name, "" for default
+	labels   map[string]string                        // node labels, for selecting config CRDs
+	crdCfgs  map[string]*resmgr.ResourceManagerConfig // config CRDs
+	inuse    *resmgr.ResourceManagerConfig            // config CRD last pushed, if any
+	inuseErr error                                    // error converting the config CRD in use
+	inscope  resmgrAdjustment                         // external adjustments that apply to this node
+	ignored  resmgrAdjustment                         // external adjustments that do not apply to this node
+	status   *resmgrStatus                            // latest adjustment update status
+
+	persisted   *persistedState // last known state loaded from disk, served until synced
+	synced      map[string]bool // sources which have delivered their state
+	unreachable map[*watch]bool // watches which have lost the API server
+	updated     time.Time       // when we last received state from the API server
 }
 
 // k8sWatcher is our interface to K8s control plane watcher
@@ -57,6 +66,10 @@ type k8sWatcher interface {
 	AdjustmentChan() <-chan resmgrAdjustment
 	// Update the node Status for adjustment updates.
 	UpdateStatus(*resmgrStatus) error
+	// Update the node Status of the config CRD in use.
+	UpdateConfigStatus(*resmgrConfigStatus) error
+	// Get the freshness of configuration and the time it was last received.
+	GetConfigState() (string, time.Time)
 }
 
 // watcher implements k8sWatcher
@@ -82,6 +95,16 @@ func newK8sWatcher(k8sCli *k8sclient.Clientset, resmgrCli *resmgrcli.CriresmgrV1
 		adjustmentChan: make(chan resmgrAdjustment, 1),
 	}
 
+	if opts.stateDir != "" {
+		state, err := loadState(opts.stateDir)
+		if err != nil {
+			w.Warn("ignoring persisted state: %v", err)
+		} else if state != nil {
+			w.Info("loaded last known configuration, received at %s", state.Updated)
+			w.currentConfig.persisted = state
+		}
+	}
+
 	return w, nil
 }
 
@@ -124,6 +147,11 @@ func (w *watcher) GetConfig() resmgrConfig {
 	return cfg
 }
 
+// GetConfigState returns the freshness of configuration and the time it was last received.
+func (w *watcher) GetConfigState() (string, time.Time) {
+	return w.currentConfig.getState()
+}
+
 // UpdateStatus updates the node status for adjustment updates.
 func (w *watcher) UpdateStatus(status *resmgrStatus) error {
 	w.currentConfig.setStatus(status)
@@ -239,20 +267,130 @@ func (w *watcher) patchAdjustment(adjust *resmgr.Adjustment, inscope bool, error
 	return nil
 }
 
+// UpdateConfigStatus updates the node status of config CRDs.
+func (w *watcher) UpdateConfigStatus(status *resmgrConfigStatus) error {
+	inuse, others, err := w.currentConfig.getConfigCRDs()
+
+	errCnt := 0
+	if inuse != nil {
+		nodeStatus := resmgr.ResourceManagerConfigNodeStatus{
+			Generation: inuse.Generation,
+			Status:     resmgr.ConfigApplied,
+		}
+		if err == nil {
+			err = status.err
+		}
+		if err != nil {
+			nodeStatus.Status = resmgr.ConfigFailed
+			nodeStatus.Error = err.Error()
+		}
+		if err := w.patchConfigStatus(inuse, &nodeStatus); err != nil {
+			w.Error("%v", err)
+			errCnt++
+		}
+	}
+	for _, crd := range others {
+		if _, ok := crd.Status.Nodes[nodeName]; !ok {
+			continue
+		}
+		if err := w.patchConfigStatus(crd, nil); err != nil {
+			w.Error("%v", err)
+			errCnt++
+		}
+	}
+	if errCnt > 0 {
+		return agentError("some config status updates failed")
+	}
+
+	return nil
+}
+
+// patchConfigStatus sets or, if status is nil, removes our node status for a config CRD.
+func (w *watcher) patchConfigStatus(crd *resmgr.ResourceManagerConfig, status *resmgr.ResourceManagerConfigNodeStatus) error {
+	var nodeStatus interface{}
+	if status != nil {
+		nodeStatus = status
+	}
+	pdata, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"nodes": map[string]interface{}{
+				nodeName: nodeStatus,
+			},
+		},
+	})
+	if err != nil {
+		return agentError("failed to create config status patch: %v", err)
+	}
+
+	ptype := pkgtypes.MergePatchType
+
+	w.Debug("patching status of config %s with %v...", crd.Name, string(pdata))
+
+	_, err = w.resmgrCli.ResourceManagerConfigs(opts.configNs).Patch(crd.Name, ptype, pdata, "status")
+	if err != nil {
+		return agentError("failed to patch ResourceManagerConfig CRD %q: %v", crd.Name, err)
+	}
+
+	return nil
+}
+
 // sendConfig sends the current configuration.
 func (w *watcher) sendConfig() {
 	cfg, kind := w.currentConfig.getConfig()
+	if crd, err := w.currentConfig.updateConfigInUse(); err != nil {
+		w.Error("failed to convert ResourceManagerConfig %s: %v", crd.Name, err)
+	}
 	w.Info("pushing %s configuration to client", kind)
 	w.configChan <- cfg
+	w.saveState()
 }
 
 // sendAdjustment sends the current overridden policies.
 func (w *watcher) sendAdjustment() {
 	inscope, _ := w.currentConfig.getAdjustment()
 	w.adjustmentChan <- inscope
+	w.saveState()
+}
+
+// sendPersisted pushes the last known state, without waiting for the API server.
+func (w *watcher) sendPersisted() {
+	w.sendConfig()
+	if w.currentConfig.hasPersistedAdjustments() {
+		w.Info("pushing persisted adjustments to client")
+		w.sendAdjustment()
+	}
+}
+
+// markSynced marks a source synced, pushing the result if this brings us in sync.
+func (w *watcher) markSynced(source string) {
+	cfgSynced, adjSynced := w.currentConfig.markSynced(source)
+	if cfgSynced {
+		w.Info("configuration in sync with the API server")
+		w.sendConfig()
+	}
+	if adjSynced {
+		w.Info("adjustments in sync with the API server")
+		w.sendAdjustment()
+	}
+}
+
+// saveState persists the last known state received from the API server.
+func (w *watcher) saveState() {
+	if opts.stateDir == "" {
+		return
+	}
+	state := w.currentConfig.getPersistentState()
+	if state == nil {
+		return
+	}
+	if err := saveState(opts.stateDir, state); err != nil {
+		w.Warn("failed to persist state: %v", err)
+	}
 }
 
 func (w *watcher) watch() error {
+	w.sendPersisted()
+
 	nodew := newNodeWatch(w)
 	group := ""
 
@@ -263,14 +401,15 @@ func (w *watcher) watch() error {
 	} else {
 		group = node.(*core_v1.Node).Labels[opts.labelName]
 		w.Info("configuration group is set to '%s'", group)
+		w.currentConfig.setLabels(node.(*core_v1.Node).Labels)
 	}
 
 	cfgw := newConfigMapWatch(w, opts.configMapName+".node."+nodeName, namespace(opts.configNs))
 	grpw := newConfigMapWatch(w, groupMapName(group), namespace(opts.configNs))
 	crdw := newAdjustmentCRDWatch(w, namespace(opts.configNs))
+	rmcw := newConfigCRDWatch(w, namespace(opts.configNs))
 
 	w.Info("watcher running")
-	w.sendConfig()
 
 	for {
 		select {
@@ -280,6 +419,7 @@ func (w *watcher) watch() error {
 			cfgw.Stop()
 			grpw.Stop()
 			crdw.Stop()
+			rmcw.Stop()
 			return nil
 
 		case e, ok := <-nodew.ResultChan():
@@ -287,12 +427,17 @@ func (w *watcher) watch() error {
 				switch e.Type {
 				case k8swatch.Added, k8swatch.Modified:
 					w.Info("node (%s) configuration updated", nodeName)
-					label, _ := e.Object.(*core_v1.Node).Labels[opts.labelName]
+					labels := e.Object.(*core_v1.Node).Labels
+					label, _ := labels[opts.labelName]
 					if group != label {
 						group = label
 						w.Info("configuration group is set to '%s'", group)
 						grpw.Start(groupMapName(group))
 					}
+					if w.currentConfig.setLabels(labels) {
+						w.Info("node labels changed the ResourceManagerConfig in use")
+						w.sendConfig()
+					}
 				case k8swatch.Deleted:
 					w.Warn("Hmm, our node got removed...")
 				}
@@ -313,6 +458,31 @@ func (w *watcher) watch() error {
 					w.currentConfig.setNode(nil)
 					w.sendConfig()
 				}
+				w.markSynced(sourceNodeConfig)
+				continue
+			}
+
+		case e, ok := <-rmcw.ResultChan():
+			if ok {
+				switch e.Type {
+				case k8swatch.Added, k8swatch.Modified:
+					crd := e.Object.(*resmgr.ResourceManagerConfig)
+					w.Info("ResourceManagerConfig CRD %s updated", crd.Name)
+					if w.currentConfig.setConfigCRD(crd) {
+						w.sendConfig()
+					}
+
+				case k8swatch.Deleted:
+					crd := e.Object.(*resmgr.ResourceManagerConfig)
+					w.Info("ResourceManagerConfig CRD %s deleted", crd.Name)
+					if w.currentConfig.deleteConfigCRD(crd) {
+						w.sendConfig()
+					}
+
+				case SyntheticMissing:
+					w.Info("No ResourceManagerConfig CRD(s)")
+				}
+				w.markSynced(sourceConfigCRD)
 				continue
 			}
 
@@ -331,6 +501,7 @@ func (w *watcher) watch() error {
 						w.sendConfig()
 					}
 				}
+				w.markSynced(sourceGroupConfig)
 				continue
 			}
 
@@ -354,6 +525,7 @@ func (w *watcher) watch() error {
 					w.Info("No Adjustment CRD(s)")
 					w.sendAdjustment()
 				}
+				w.markSynced(sourceAdjustments)
 				continue
 			}
 		}
@@ -374,8 +546,11 @@ func groupMapName(group string) string {
 // newCacheConfig creates a new cachedConfig instance.
 func newCachedConfig() cachedConfig {
 	return cachedConfig{
-		inscope: resmgrAdjustment{},
-		ignored: resmgrAdjustment{},
+		crdCfgs:     map[string]*resmgr.ResourceManagerConfig{},
+		inscope:     resmgrAdjustment{},
+		ignored:     resmgrAdjustment{},
+		synced:      map[string]bool{},
+		unreachable: map[*watch]bool{},
 	}
 }
 
@@ -387,6 +562,14 @@ func (c *cachedConfig) getConfig() (resmgrConfig, string) {
 	var cfg *resmgrConfig
 	var kind string
 
+	if !c.configSynced() && c.persisted != nil && c.persisted.Config != nil {
+		return c.persisted.Config, "persisted " + c.persisted.Kind
+	}
+
+	if crd, data, err := c.getConfigCRD(); crd != nil && err == nil {
+		return data, "ResourceManagerConfig " + crd.Name
+	}
+
 	switch {
 	case c.nodeCfg != nil:
 		kind = "node"
@@ -414,6 +597,14 @@ func (c *cachedConfig) getAdjustment() (resmgrAdjustment, resmgrAdjustment) {
 	c.RLock()
 	defer c.RUnlock()
 
+	if !c.synced[sourceAdjustments] && c.persisted != nil && c.persisted.Adjustments != nil {
+		inscope := resmgrAdjustment{}
+		for name, value := range c.persisted.Adjustments {
+			inscope[name] = value
+		}
+		return inscope, resmgrAdjustment{}
+	}
+
 	inscope := resmgrAdjustment{}
 	for name, value := range c.inscope {
 		inscope[name] = value
@@ -432,7 +623,7 @@ func (c *cachedConfig) setNode(data *map[string]string) bool {
 	defer c.Unlock()
 
 	c.nodeCfg = (*resmgrConfig)(data)
-	return true
+	return !c.usingConfigCRD()
 }
 
 // set group-specific or default configuration
@@ -442,7 +633,99 @@ func (c *cachedConfig) setGroup(group string, data *map[string]string) bool {
 
 	c.groupCfg = (*resmgrConfig)(data)
 	c.group = group
-	return c.nodeCfg == nil
+	return c.nodeCfg == nil && !c.usingConfigCRD()
+}
+
+// getConfigCRD returns the config CRD selected for this node, converted to config data.
+func (c *cachedConfig) getConfigCRD() (*resmgr.ResourceManagerConfig, resmgrConfig, error) {
+	crds := make([]*resmgr.ResourceManagerConfig, 0, len(c.crdCfgs))
+	for _, crd := range c.crdCfgs {
+		crds = append(crds, crd)
+	}
+	crd := resmgr.SelectConfig(crds, c.labels)
+	if crd == nil {
+		return nil, nil, nil
+	}
+	data, err := crd.Spec.ToConfigData()
+	return crd, resmgrConfig(data), err
+}
+
+// check if we are using a config CRD instead of ConfigMaps
+func (c *cachedConfig) usingConfigCRD() bool {
+	crd, _, err := c.getConfigCRD()
+	return crd != nil && err == nil
+}
+
+// set node labels, return true if this changes the config CRD in use
+func (c *cachedConfig) setLabels(labels map[string]string) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	old, _, _ := c.getConfigCRD()
+	c.labels = labels
+	crd, _, _ := c.getConfigCRD()
+	return configCRDChanged(old, crd)
+}
+
+// update a config CRD, return true if this changes the config CRD in use
+func (c *cachedConfig) setConfigCRD(crd *resmgr.ResourceManagerConfig) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	old, _, _ := c.getConfigCRD()
+	c.crdCfgs[crd.Name] = crd
+	updated, _, _ := c.getConfigCRD()
+	return configCRDChanged(old, updated)
+}
+
+// delete a config CRD, return true if this changes the config CRD in use
+func (c *cachedConfig) deleteConfigCRD(crd *resmgr.ResourceManagerConfig) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	old, _, _ := c.getConfigCRD()
+	delete(c.crdCfgs, crd.Name)
+	updated, _, _ := c.getConfigCRD()
+	return configCRDChanged(old, updated)
+}
+
+// updateConfigInUse marks the currently selected config CRD, if any, as the one in use
+func (c *cachedConfig) updateConfigInUse() (*resmgr.ResourceManagerConfig, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.inuse, _, c.inuseErr = c.getConfigCRD()
+	return c.inuse, c.inuseErr
+}
+
+// getConfigCRDs returns the config CRD in use, the rest of the config CRDs, and any conversion error
+func (c *cachedConfig) getConfigCRDs() (*resmgr.ResourceManagerConfig, []*resmgr.ResourceManagerConfig, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	others := make([]*resmgr.ResourceManagerConfig, 0, len(c.crdCfgs))
+	for name, crd := range c.crdCfgs {
+		if c.inuse == nil || c.inuse.Name != name {
+			others = append(others, crd)
+		}
+	}
+	return c.inuse, others, c.inuseErr
+}
+
+// configCRDChanged checks if the config CRD in use changed
+//
+// Only the generation is compared, since status updates, including the ones
+// we do ourselves after pushing configuration, bump the resource version.
+func configCRDChanged(old, crd *resmgr.ResourceManagerConfig) bool {
+	switch {
+	case old == nil && crd == nil:
+		return false
+	case old == nil || crd == nil:
+		return true
+	case old.Name != crd.Name:
+		return true
+	}
+	return old.Generation != crd.Generation
 }
 
 // setAdjustment is a helper method for updating external adjustments
@@ -539,3 +822,88 @@ func (c *cachedConfig) getStatus() *resmgrStatus {
 	defer c.RUnlock()
 	return c.status
 }
+
+// check if all configuration sources have delivered their state
+func (c *cachedConfig) configSynced() bool {
+	for _, source := range configSources {
+		if !c.synced[source] {
+			return false
+		}
+	}
+	return true
+}
+
+// mark a source synced, return whether this brings configuration or adjustments in sync
+func (c *cachedConfig) markSynced(source string) (bool, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	cfgSynced, adjSynced := c.configSynced(), c.synced[sourceAdjustments]
+	c.synced[source] = true
+	c.updated = time.Now()
+
+	return !cfgSynced && c.configSynced(), !adjSynced && c.synced[sourceAdjustments]
+}
+
+// set whether the API server is reachable by the given watch
+func (c *cachedConfig) setReachable(w *watch, reachable bool) {
+	c.Lock()
+	defer c.Unlock()
+	if reachable {
+		delete(c.unreachable, w)
+	} else {
+		c.unreachable[w] = true
+	}
+}
+
+// check if we have persisted adjustments to serve until synced
+func (c *cachedConfig) hasPersistedAdjustments() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return !c.synced[sourceAdjustments] && c.persisted != nil && c.persisted.Adjustments != nil
+}
+
+// get the freshness of configuration and the time it was last received
+func (c *cachedConfig) getState() (string, time.Time) {
+	c.RLock()
+	defer c.RUnlock()
+
+	switch {
+	case c.configSynced() && len(c.unreachable) == 0:
+		return configFresh, c.updated
+	case c.configSynced():
+		return configStale, c.updated
+	case c.persisted != nil:
+		return configPersisted, c.persisted.Updated
+	}
+	return configNone, time.Time{}
+}
+
+// get the state to persist, nil if we have not received anything to persist yet
+func (c *cachedConfig) getPersistentState() *persistedState {
+	c.RLock()
+	cfgSynced, adjSynced := c.configSynced(), c.synced[sourceAdjustments]
+	c.RUnlock()
+
+	if !cfgSynced && !adjSynced {
+		return nil
+	}
+
+	state := &persistedState{}
+	if c.persisted != nil {
+		*state = *c.persisted
+	}
+
+	if cfgSynced {
+		state.Config, state.Kind = c.getConfig()
+	}
+	if adjSynced {
+		state.Adjustments, _ = c.getAdjustment()
+	}
+
+	c.RLock()
+	state.Updated = c.updated
+	c.RUnlock()
+
+	return state
+}
@@ -17,3 +17,7 @@
 package v1alpha1
 
 type AdjustmentExpansion interface{}
+
+type NodeResourceTopologyExpansion interface{}
+
+type ResourceManagerConfigExpansion interface{}
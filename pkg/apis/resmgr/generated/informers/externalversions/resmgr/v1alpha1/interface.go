@@ -24,6 +24,10 @@ import (
 type Interface interface {
 	// Adjustments returns a AdjustmentInformer.
 	Adjustments() AdjustmentInformer
+	// NodeResourceTopologies returns a NodeResourceTopologyInformer.
+	NodeResourceTopologies() NodeResourceTopologyInformer
+	// ResourceManagerConfigs returns a ResourceManagerConfigInformer.
+	ResourceManagerConfigs() ResourceManagerConfigInformer
 }
 
 type version struct {
@@ -41,3 +45,13 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Adjustments() AdjustmentInformer {
 	return &adjustmentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// NodeResourceTopologies returns a NodeResourceTopologyInformer.
+func (v *version) NodeResourceTopologies() NodeResourceTopologyInformer {
+	return &nodeResourceTopologyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ResourceManagerConfigs returns a ResourceManagerConfigInformer.
+func (v *version) ResourceManagerConfigs() ResourceManagerConfigInformer {
+	return &resourceManagerConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
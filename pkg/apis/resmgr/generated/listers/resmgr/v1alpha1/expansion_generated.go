@@ -23,3 +23,15 @@ type AdjustmentListerExpansion interface{}
 // AdjustmentNamespaceListerExpansion allows custom methods to be added to
 // AdjustmentNamespaceLister.
 type AdjustmentNamespaceListerExpansion interface{}
+
+// NodeResourceTopologyListerExpansion allows custom methods to be added to
+// NodeResourceTopologyLister.
+type NodeResourceTopologyListerExpansion interface{}
+
+// ResourceManagerConfigListerExpansion allows custom methods to be added to
+// ResourceManagerConfigLister.
+type ResourceManagerConfigListerExpansion interface{}
+
+// ResourceManagerConfigNamespaceListerExpansion allows custom methods to be added to
+// ResourceManagerConfigNamespaceLister.
+type ResourceManagerConfigNamespaceListerExpansion interface{}
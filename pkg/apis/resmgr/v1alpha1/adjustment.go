@@ -16,7 +16,9 @@ package v1alpha1
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
 	resmgr "github.com/intel/cri-resource-manager/pkg/apis/resmgr"
 	corev1 "k8s.io/api/core/v1"
@@ -68,6 +70,51 @@ func (spec *AdjustmentSpec) GetBlockIOClass() (string, bool) {
 	return *spec.Classes.BlockIO, true
 }
 
+// GetIsolatedCPUPreference returns the isolated CPU preference for this adjustment.
+func (spec *AdjustmentSpec) GetIsolatedCPUPreference() (bool, bool) {
+	if spec.CPU == nil || spec.CPU.PreferIsolated == nil {
+		return false, false
+	}
+	return *spec.CPU.PreferIsolated, true
+}
+
+// GetSharedCPUPreference returns the shared CPU preference for this adjustment.
+func (spec *AdjustmentSpec) GetSharedCPUPreference() (bool, bool) {
+	if spec.CPU == nil || spec.CPU.PreferShared == nil {
+		return false, false
+	}
+	return *spec.CPU.PreferShared, true
+}
+
+// GetMemoryType returns the memory type preference for this adjustment.
+func (spec *AdjustmentSpec) GetMemoryType() (string, bool) {
+	if spec.MemoryType == nil {
+		return "", false
+	}
+	return *spec.MemoryType, true
+}
+
+// GetColdStartDuration returns the cold start duration for this adjustment.
+func (spec *AdjustmentSpec) GetColdStartDuration() (time.Duration, bool) {
+	if spec.ColdStart == nil {
+		return 0, false
+	}
+	return spec.ColdStart.Duration.Duration, true
+}
+
+// GetDemotionPreference returns the page demotion preference for this adjustment.
+func (spec *AdjustmentSpec) GetDemotionPreference() (bool, bool) {
+	if spec.PageMigration == nil || spec.PageMigration.Demote == nil {
+		return false, false
+	}
+	return *spec.PageMigration.Demote, true
+}
+
+// HasAffinity checks if this adjustment overrides container affinities.
+func (spec *AdjustmentSpec) HasAffinity() bool {
+	return spec.Affinity != nil || spec.AntiAffinity != nil
+}
+
 // IsNodeInScope tests if the node is within the scope of this spec.
 func (spec *AdjustmentSpec) IsNodeInScope(node string) bool {
 	if len(spec.Scope) == 0 {
@@ -109,6 +156,8 @@ func (spec *AdjustmentSpec) Compare(other *AdjustmentSpec) bool {
 		return false
 	case spec.ToptierLimit != nil && spec.ToptierLimit.Value() != other.ToptierLimit.Value():
 		return false
+	case !spec.comparePreferences(other):
+		return false
 	}
 	return true
 }
@@ -121,10 +170,38 @@ func (spec *AdjustmentSpec) Verify() error {
 	if err := spec.verifyToptierLimit(); err != nil {
 		return err
 	}
+	if err := spec.verifyMemoryType(); err != nil {
+		return err
+	}
+	if err := spec.verifyColdStart(); err != nil {
+		return err
+	}
+	if err := spec.verifyAffinity(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// Check if the policy preferences in this spec are identical to another one.
+func (spec *AdjustmentSpec) comparePreferences(other *AdjustmentSpec) bool {
+	switch {
+	case !reflect.DeepEqual(spec.CPU, other.CPU):
+		return false
+	case !reflect.DeepEqual(spec.MemoryType, other.MemoryType):
+		return false
+	case !reflect.DeepEqual(spec.ColdStart, other.ColdStart):
+		return false
+	case !reflect.DeepEqual(spec.Affinity, other.Affinity):
+		return false
+	case !reflect.DeepEqual(spec.AntiAffinity, other.AntiAffinity):
+		return false
+	case !reflect.DeepEqual(spec.PageMigration, other.PageMigration):
+		return false
+	}
+	return true
+}
+
 // Check if the resources in this spec are identical to another one.
 func (spec *AdjustmentSpec) compareResources(other *AdjustmentSpec) bool {
 	switch {
@@ -236,6 +313,68 @@ func (spec *AdjustmentSpec) verifyToptierLimit() error {
 	return nil
 }
 
+// verifyMemoryType verifies the memory type preference of this spec.
+func (spec *AdjustmentSpec) verifyMemoryType() error {
+	if spec.MemoryType == nil {
+		return nil
+	}
+
+	for _, mtype := range strings.Split(*spec.MemoryType, ",") {
+		switch strings.ToLower(mtype) {
+		case "dram", "pmem", "hbm", "mixed":
+		default:
+			return apiError("invalid memoryType %q", *spec.MemoryType)
+		}
+	}
+
+	return nil
+}
+
+// verifyColdStart verifies the cold start preference of this spec.
+func (spec *AdjustmentSpec) verifyColdStart() error {
+	if spec.ColdStart == nil {
+		return nil
+	}
+
+	d := spec.ColdStart.Duration.Duration
+	if d < 0 || d > time.Hour {
+		return apiError("coldStart duration %s out of range", d)
+	}
+
+	return nil
+}
+
+// verifyAffinity verifies the affinities and anti-affinities of this spec.
+func (spec *AdjustmentSpec) verifyAffinity() error {
+	for _, affinities := range [][]ContainerAffinity{spec.Affinity, spec.AntiAffinity} {
+		for _, a := range affinities {
+			if err := a.Verify(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Verify checks the affinity for obvious errors.
+func (a *ContainerAffinity) Verify() error {
+	if a.Match == nil {
+		return apiError("invalid affinity: missing match expression")
+	}
+	if err := a.Match.Validate(); err != nil {
+		return apiError("invalid affinity match expression %s: %v", a.Match, err)
+	}
+	if a.Scope != nil {
+		if err := a.Scope.Validate(); err != nil {
+			return apiError("invalid affinity scope expression %s: %v", a.Scope, err)
+		}
+	}
+	if a.Weight < 0 {
+		return apiError("invalid affinity weight %d", a.Weight)
+	}
+	return nil
+}
+
 // IsNodeInScope tests if the node is within this scope.
 func (scope *AdjustmentScope) IsNodeInScope(node string) bool {
 	if len(scope.Nodes) == 0 {
@@ -0,0 +1,198 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// configuration modules we generate data for
+	policyModule  = "policy"
+	controlModule = "resource-manager.control"
+	rdtModule     = "rdt"
+	blockioModule = "blockio"
+	loggerModule  = "logger"
+	dumpModule    = "dump"
+)
+
+// HasPrecedenceOver checks if the config takes precedence over the other one.
+//
+// Higher precedence wins. Among configs with equal precedence the one with
+// the lexically smaller name wins, to keep the selection deterministic.
+func (c *ResourceManagerConfig) HasPrecedenceOver(o *ResourceManagerConfig) bool {
+	if o == nil {
+		return true
+	}
+	if c.Spec.Precedence != o.Spec.Precedence {
+		return c.Spec.Precedence > o.Spec.Precedence
+	}
+	return c.Name < o.Name
+}
+
+// SelectsNode checks if the config applies to a node with the given labels.
+func (spec *ResourceManagerConfigSpec) SelectsNode(labels map[string]string) bool {
+	for key, value := range spec.NodeSelector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// SelectConfig returns the config with the highest precedence for the node.
+func SelectConfig(configs []*ResourceManagerConfig, labels map[string]string) *ResourceManagerConfig {
+	var selected *ResourceManagerConfig
+	for _, c := range configs {
+		if c.Spec.SelectsNode(labels) && c.HasPrecedenceOver(selected) {
+			selected = c
+		}
+	}
+	return selected
+}
+
+// ToConfigData converts the spec to cri-resmgr configuration data.
+//
+// The data is keyed by configuration module, with each module configuration
+// encoded as a string, as expected by the cri-resmgr SetConfig request. Any
+// module left unspecified is omitted and therefore reset to its defaults.
+func (spec *ResourceManagerConfigSpec) ToConfigData() (map[string]string, error) {
+	modules := map[string]interface{}{}
+
+	if p := spec.Policy; p != nil {
+		cfg := map[string]interface{}{
+			"Active": p.Active,
+		}
+		if len(p.AvailableResources) > 0 {
+			cfg["AvailableResources"] = p.AvailableResources
+		}
+		if len(p.ReservedResources) > 0 {
+			cfg["ReservedResources"] = p.ReservedResources
+		}
+		for name, raw := range p.Options {
+			opts, err := decodeRaw(&raw)
+			if err != nil {
+				return nil, configError("invalid %s policy options: %v", name, err)
+			}
+			cfg[name] = opts
+		}
+		modules[policyModule] = cfg
+	}
+
+	if c := spec.Controllers; c != nil {
+		if len(c.Modes) > 0 {
+			modules[controlModule] = map[string]interface{}{
+				"Controllers": c.Modes,
+			}
+		}
+		if c.RDT != nil {
+			cfg, err := decodeRaw(c.RDT)
+			if err != nil {
+				return nil, configError("invalid RDT configuration: %v", err)
+			}
+			modules[rdtModule] = cfg
+		}
+		if c.BlockIO != nil {
+			cfg, err := decodeRaw(c.BlockIO)
+			if err != nil {
+				return nil, configError("invalid Block I/O configuration: %v", err)
+			}
+			modules[blockioModule] = cfg
+		}
+	}
+
+	if l := spec.Logger; l != nil {
+		cfg := map[string]interface{}{}
+		if len(l.Debug) > 0 {
+			cfg["Debug"] = strings.Join(l.Debug, ",")
+		}
+		if len(l.Levels) > 0 {
+			sources := make([]string, 0, len(l.Levels))
+			for src := range l.Levels {
+				sources = append(sources, src)
+			}
+			sort.Strings(sources)
+			levels := make([]string, 0, len(sources))
+			for _, src := range sources {
+				levels = append(levels, l.Levels[src]+":"+src)
+			}
+			cfg["Levels"] = strings.Join(levels, ",")
+		}
+		if l.Format != "" {
+			cfg["Format"] = l.Format
+		}
+		if l.LogSource != nil {
+			cfg["LogSource"] = *l.LogSource
+		}
+		modules[loggerModule] = cfg
+	}
+
+	if d := spec.Dump; d != nil {
+		cfg := map[string]interface{}{}
+		if d.Config != "" {
+			cfg["Config"] = d.Config
+		}
+		if d.File != "" {
+			cfg["File"] = d.File
+		}
+		if d.Debug != nil {
+			cfg["Debug"] = *d.Debug
+		}
+		if len(d.RedactEnv) > 0 {
+			cfg["RedactEnv"] = d.RedactEnv
+		}
+		if len(d.RedactAnnotations) > 0 {
+			cfg["RedactAnnotations"] = d.RedactAnnotations
+		}
+		if d.RedactMounts != nil {
+			cfg["RedactMounts"] = *d.RedactMounts
+		}
+		modules[dumpModule] = cfg
+	}
+
+	data := make(map[string]string, len(modules))
+	for module, cfg := range modules {
+		// JSON is valid YAML, which is what cri-resmgr expects.
+		encoded, err := json.Marshal(cfg)
+		if err != nil {
+			return nil, configError("failed to encode %s configuration: %v", module, err)
+		}
+		data[module] = string(encoded)
+	}
+
+	return data, nil
+}
+
+// decodeRaw decodes a raw extension into a generic object.
+func decodeRaw(raw *runtime.RawExtension) (interface{}, error) {
+	var obj interface{}
+	if len(raw.Raw) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	if err := json.Unmarshal(raw.Raw, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// configError returns a format error specific to the config API.
+func configError(format string, args ...interface{}) error {
+	return fmt.Errorf("config API error: "+format, args...)
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSelectConfig(t *testing.T) {
+	config := func(name string, precedence int32, selector map[string]string) *ResourceManagerConfig {
+		return &ResourceManagerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: ResourceManagerConfigSpec{
+				NodeSelector: selector,
+				Precedence:   precedence,
+			},
+		}
+	}
+	configs := []*ResourceManagerConfig{
+		config("default", 0, nil),
+		config("gpu", 10, map[string]string{"gpu": "true"}),
+		config("gpu-b", 20, map[string]string{"gpu": "true", "zone": "b"}),
+		config("another-gpu", 10, map[string]string{"gpu": "true"}),
+	}
+
+	tcases := []struct {
+		labels   map[string]string
+		expected string
+	}{
+		{nil, "default"},
+		{map[string]string{"zone": "b"}, "default"},
+		{map[string]string{"gpu": "true"}, "another-gpu"},
+		{map[string]string{"gpu": "true", "zone": "b"}, "gpu-b"},
+		{map[string]string{"gpu": "false", "zone": "b"}, "default"},
+	}
+	for _, tc := range tcases {
+		selected := SelectConfig(configs, tc.labels)
+		if selected == nil || selected.Name != tc.expected {
+			t.Errorf("labels %v: expected config %s, got %v", tc.labels, tc.expected, selected)
+		}
+	}
+
+	if selected := SelectConfig(configs[1:3], nil); selected != nil {
+		t.Errorf("expected no config to be selected, got %s", selected.Name)
+	}
+}
+
+func TestToConfigData(t *testing.T) {
+	logSource := true
+	spec := &ResourceManagerConfigSpec{
+		Policy: &PolicyConfig{
+			Active:            "topology-aware",
+			ReservedResources: map[string]string{"cpu": "750m"},
+			Options: map[string]runtime.RawExtension{
+				"topology-aware": {Raw: []byte(`{"PinMemory":false}`)},
+			},
+		},
+		Controllers: &ControllersConfig{
+			Modes: map[string]string{"rdt": "disabled"},
+		},
+		Logger: &LoggerConfig{
+			Debug:     []string{"policy", "cache"},
+			Levels:    map[string]string{"cache": "warn", "agent": "error"},
+			LogSource: &logSource,
+		},
+	}
+
+	data, err := spec.ToConfigData()
+	if err != nil {
+		t.Fatalf("failed to convert spec: %v", err)
+	}
+
+	expected := map[string]string{
+		"policy":                   `{"Active":"topology-aware","ReservedResources":{"cpu":"750m"},"topology-aware":{"PinMemory":false}}`,
+		"resource-manager.control": `{"Controllers":{"rdt":"disabled"}}`,
+		"logger":                   `{"Debug":"policy,cache","Levels":"error:agent,warn:cache","LogSource":true}`,
+	}
+	if len(data) != len(expected) {
+		t.Errorf("expected modules %v, got %v", expected, data)
+	}
+	for module, cfg := range expected {
+		if data[module] != cfg {
+			t.Errorf("module %s: expected %s, got %s", module, cfg, data[module])
+		}
+	}
+
+	spec.Controllers.RDT = &runtime.RawExtension{Raw: []byte("{")}
+	if _, err := spec.ToConfigData(); err == nil {
+		t.Errorf("expected error for invalid RDT configuration, got none")
+	}
+}
@@ -42,6 +42,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Adjustment{},
 		&AdjustmentList{},
+		&ResourceManagerConfig{},
+		&ResourceManagerConfigList{},
+		&NodeResourceTopology{},
+		&NodeResourceTopologyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -18,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	resapi "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	resmgr "github.com/intel/cri-resource-manager/pkg/apis/resmgr"
 )
@@ -29,6 +30,21 @@ const (
 	Plural    string = "adjustments"            // Plural is Kind in plural form.
 	Singular  string = "adjustment"             // Singular is Kind in singular form.
 	Name      string = Plural + "." + GroupName // Name is the full name of our CRD.
+
+	ConfigKind     string = "ResourceManagerConfig"        // ConfigKind is the object kind of our config CRD.
+	ConfigPlural   string = "resourcemanagerconfigs"       // ConfigPlural is ConfigKind in plural form.
+	ConfigSingular string = "resourcemanagerconfig"        // ConfigSingular is ConfigKind in singular form.
+	ConfigName     string = ConfigPlural + "." + GroupName // ConfigName is the full name of our config CRD.
+
+	TopologyKind     string = "NodeResourceTopology"           // TopologyKind is the object kind of our topology CRD.
+	TopologyPlural   string = "noderesourcetopologies"         // TopologyPlural is TopologyKind in plural form.
+	TopologySingular string = "noderesourcetopology"           // TopologySingular is TopologyKind in singular form.
+	TopologyName     string = TopologyPlural + "." + GroupName // TopologyName is the full name of our topology CRD.
+)
+
+const (
+	ConfigApplied string = "Success" // ConfigApplied is the node status of a successfully applied config.
+	ConfigFailed  string = "Failure" // ConfigFailed is the node status of a config failed to apply.
 )
 
 // +genclient
@@ -45,10 +61,16 @@ type Adjustment struct {
 
 // AdjustmentSpec specifies the scope for an external adjustment.
 type AdjustmentSpec struct {
-	Scope        []AdjustmentScope            `json:"scope"`
-	Resources    *corev1.ResourceRequirements `json:"resources"`
-	Classes      *Classes                     `json:"classes"`
-	ToptierLimit *resapi.Quantity             `json:"toptierLimit"`
+	Scope         []AdjustmentScope            `json:"scope"`
+	Resources     *corev1.ResourceRequirements `json:"resources"`
+	Classes       *Classes                     `json:"classes"`
+	ToptierLimit  *resapi.Quantity             `json:"toptierLimit"`
+	CPU           *CPUPreferences              `json:"cpu,omitempty"`
+	MemoryType    *string                      `json:"memoryType,omitempty"`
+	ColdStart     *ColdStart                   `json:"coldStart,omitempty"`
+	Affinity      []ContainerAffinity          `json:"affinity,omitempty"`
+	AntiAffinity  []ContainerAffinity          `json:"antiAffinity,omitempty"`
+	PageMigration *PageMigration               `json:"pageMigration,omitempty"`
 }
 
 // AdjustmentStatus represents the status of applying an adjustment.
@@ -73,6 +95,29 @@ type Classes struct {
 	RDT     *string `json:"rdt"`
 }
 
+// CPUPreferences defines isolated and shared CPU preferences.
+type CPUPreferences struct {
+	PreferIsolated *bool `json:"preferIsolated,omitempty"`
+	PreferShared   *bool `json:"preferShared,omitempty"`
+}
+
+// ColdStart defines the cold start period for memory allocation.
+type ColdStart struct {
+	Duration metav1.Duration `json:"duration"`
+}
+
+// ContainerAffinity defines an affinity or anti-affinity for containers.
+type ContainerAffinity struct {
+	Scope  *resmgr.Expression `json:"scope,omitempty"`
+	Match  *resmgr.Expression `json:"match"`
+	Weight int32              `json:"weight,omitempty"`
+}
+
+// PageMigration defines page migration preferences.
+type PageMigration struct {
+	Demote *bool `json:"demote,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // AdjustmentList is a list of Adjustments.
@@ -82,3 +127,125 @@ type AdjustmentList struct {
 
 	Items []Adjustment `json:"items"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourceManagerConfig is a CRD used to configure cri-resmgr on a set of nodes.
+type ResourceManagerConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceManagerConfigSpec   `json:"spec"`
+	Status ResourceManagerConfigStatus `json:"status,omitempty"`
+}
+
+// ResourceManagerConfigSpec is the configuration and the set of nodes it applies to.
+type ResourceManagerConfigSpec struct {
+	// NodeSelector selects nodes by labels, an empty selector selects all nodes.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Precedence of this config over others selecting the same node, higher wins.
+	Precedence int32 `json:"precedence,omitempty"`
+
+	Policy      *PolicyConfig      `json:"policy,omitempty"`
+	Controllers *ControllersConfig `json:"controllers,omitempty"`
+	Logger      *LoggerConfig      `json:"logger,omitempty"`
+	Dump        *DumpConfig        `json:"dump,omitempty"`
+}
+
+// PolicyConfig is the configuration of the policy layer and policies.
+type PolicyConfig struct {
+	Active             string                          `json:"active"`
+	AvailableResources map[string]string               `json:"availableResources,omitempty"`
+	ReservedResources  map[string]string               `json:"reservedResources,omitempty"`
+	Options            map[string]runtime.RawExtension `json:"options,omitempty"`
+}
+
+// ControllersConfig is the configuration of resource controllers.
+type ControllersConfig struct {
+	Modes   map[string]string     `json:"modes,omitempty"`
+	RDT     *runtime.RawExtension `json:"rdt,omitempty"`
+	BlockIO *runtime.RawExtension `json:"blockio,omitempty"`
+}
+
+// LoggerConfig is the configuration of logging.
+type LoggerConfig struct {
+	Debug     []string          `json:"debug,omitempty"`
+	Levels    map[string]string `json:"levels,omitempty"`
+	Format    string            `json:"format,omitempty"`
+	LogSource *bool             `json:"logSource,omitempty"`
+}
+
+// DumpConfig is the configuration of message dumping.
+type DumpConfig struct {
+	Config            string   `json:"config,omitempty"`
+	File              string   `json:"file,omitempty"`
+	Debug             *bool    `json:"debug,omitempty"`
+	RedactEnv         []string `json:"redactEnv,omitempty"`
+	RedactAnnotations []string `json:"redactAnnotations,omitempty"`
+	RedactMounts      *bool    `json:"redactMounts,omitempty"`
+}
+
+// ResourceManagerConfigStatus represents the status of applying a config.
+type ResourceManagerConfigStatus struct {
+	Nodes map[string]ResourceManagerConfigNodeStatus `json:"nodes,omitempty"`
+}
+
+// ResourceManagerConfigNodeStatus represents the status of a config on a node.
+type ResourceManagerConfigNodeStatus struct {
+	Generation int64  `json:"generation"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourceManagerConfigList is a list of ResourceManagerConfigs.
+type ResourceManagerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ResourceManagerConfig `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeResourceTopology describes the resources available in the topology zones of a node.
+type NodeResourceTopology struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// TopologyPolicies are the policies used to allocate resources on the node.
+	TopologyPolicies []string `json:"topologyPolicies"`
+	// Zones are the topology zones of the node.
+	Zones []Zone `json:"zones"`
+}
+
+// Zone is a topology zone, for instance a socket, a die or a NUMA node.
+type Zone struct {
+	Name      string         `json:"name"`
+	Type      string         `json:"type"`
+	Parent    string         `json:"parent,omitempty"`
+	Resources []ResourceInfo `json:"resources,omitempty"`
+}
+
+// ResourceInfo is the amount of a resource in a topology zone.
+type ResourceInfo struct {
+	Name        string          `json:"name"`
+	Capacity    resapi.Quantity `json:"capacity"`
+	Allocatable resapi.Quantity `json:"allocatable"`
+	Available   resapi.Quantity `json:"available"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeResourceTopologyList is a list of NodeResourceTopologies.
+type NodeResourceTopologyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NodeResourceTopology `json:"items"`
+}
@@ -0,0 +1,227 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+//
+// This package implements peer credential (SO_PEERCRED) based authorization
+// of gRPC requests received over our unix domain sockets. An access policy is
+// a set of per-socket rules, with an optional rule for each gRPC method and a
+// default rule for the rest of the methods. A rule lists the users, groups and
+// executables allowed to make a request. Without an access policy for a socket
+// all requests are allowed, leaving access control to filesystem permissions.
+//
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+)
+
+const (
+	// ConfigSocket is the name of the cri-resmgr configuration socket.
+	ConfigSocket = "config"
+	// AgentSocket is the name of the cri-resmgr-agent socket.
+	AgentSocket = "agent"
+	// RelaySocket is the name of the cri-resmgr CRI relay socket.
+	RelaySocket = "relay"
+	// ControlSocket is the name of the cri-resmgr control socket.
+	ControlSocket = "control"
+)
+
+// Policy is a set of access rules for our sockets.
+type Policy map[string]*SocketPolicy
+
+// SocketPolicy describes who is allowed to call the gRPC methods of a socket.
+type SocketPolicy struct {
+	// Default is the rule for methods without a rule of their own.
+	Default *Rule `json:"default,omitempty"`
+	// Methods are per-method rules, keyed by method name, with or without service.
+	Methods map[string]*Rule `json:"methods,omitempty"`
+}
+
+// Rule describes the peers allowed to make a request.
+//
+// A peer is allowed if its user ID or any of its group IDs is listed, or if
+// neither user nor group IDs are listed. If executables are listed, the peer
+// executable must also match one of them. Executables can be glob patterns.
+type Rule struct {
+	// UIDs are the user IDs allowed.
+	UIDs []uint32 `json:"uids,omitempty"`
+	// GIDs are the group IDs allowed.
+	GIDs []uint32 `json:"gids,omitempty"`
+	// Executables are the paths of executables allowed.
+	Executables []string `json:"executables,omitempty"`
+}
+
+// Our logger instance.
+var log = logger.NewLogger("auth")
+
+// Our active access policy.
+var policy struct {
+	sync.Once
+	Policy
+	err error
+}
+
+// LoadPolicy loads an access policy from the given file.
+func LoadPolicy(path string) (Policy, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, authError("failed to read access policy %q: %v", path, err)
+	}
+
+	p := Policy{}
+	if err := yaml.UnmarshalStrict(raw, &p); err != nil {
+		return nil, authError("failed to parse access policy %q: %v", path, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, authError("invalid access policy %q: %v", path, err)
+	}
+
+	return p, nil
+}
+
+// getPolicy returns the access policy given on the command line.
+func getPolicy() (Policy, error) {
+	policy.Do(func() {
+		if opt.PolicyFile == "" {
+			return
+		}
+		policy.Policy, policy.err = LoadPolicy(opt.PolicyFile)
+		if policy.err == nil {
+			log.Info("loaded socket access policy %s", opt.PolicyFile)
+		}
+	})
+	return policy.Policy, policy.err
+}
+
+// validate checks the policy for obvious errors.
+func (p Policy) validate() error {
+	for socket, sp := range p {
+		switch socket {
+		case ConfigSocket, AgentSocket, RelaySocket, ControlSocket:
+		default:
+			return authError("unknown socket %q", socket)
+		}
+		if sp == nil {
+			continue
+		}
+		rules := []*Rule{sp.Default}
+		for _, r := range sp.Methods {
+			rules = append(rules, r)
+		}
+		for _, r := range rules {
+			if r == nil {
+				continue
+			}
+			for _, exe := range r.Executables {
+				if _, err := filepath.Match(exe, ""); err != nil {
+					return authError("socket %s: invalid executable pattern %q: %v",
+						socket, exe, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Authorize checks if the peer is allowed to call the given method over the socket.
+func (p Policy) Authorize(socket, method string, peer *Peer) error {
+	sp, ok := p[socket]
+	if !ok {
+		return nil
+	}
+
+	rule := sp.lookup(method)
+	switch {
+	case rule == nil:
+		return authError("no rule allows %s", method)
+	case peer == nil:
+		return authError("unknown peer credentials")
+	case !rule.allows(peer):
+		return authError("peer not allowed to call %s", method)
+	}
+
+	return nil
+}
+
+// lookup returns the rule for the given (full) method name.
+func (sp *SocketPolicy) lookup(method string) *Rule {
+	if sp == nil {
+		return nil
+	}
+	if r, ok := sp.Methods[method]; ok {
+		return r
+	}
+	name := method[strings.LastIndex(method, "/")+1:]
+	if r, ok := sp.Methods[name]; ok {
+		return r
+	}
+	if service := strings.TrimPrefix(method, "/"); service != method {
+		if r, ok := sp.Methods[service]; ok {
+			return r
+		}
+	}
+	return sp.Default
+}
+
+// allows checks if the rule allows the given peer.
+func (r *Rule) allows(peer *Peer) bool {
+	if len(r.UIDs) > 0 || len(r.GIDs) > 0 {
+		idOK := false
+		for _, uid := range r.UIDs {
+			if uid == peer.UID {
+				idOK = true
+				break
+			}
+		}
+		for _, gid := range r.GIDs {
+			if idOK {
+				break
+			}
+			for _, pgid := range peer.GIDs {
+				if gid == pgid {
+					idOK = true
+					break
+				}
+			}
+		}
+		if !idOK {
+			return false
+		}
+	}
+
+	if len(r.Executables) == 0 {
+		return true
+	}
+	for _, exe := range r.Executables {
+		if ok, _ := filepath.Match(exe, peer.Executable); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authError returns a formatted auth-specific error.
+func authError(format string, args ...interface{}) error {
+	return fmt.Errorf("auth: "+format, args...)
+}
@@ -0,0 +1,134 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testPolicy = `
+config:
+  default:
+    uids: [0]
+  methods:
+    GetConfig:
+      gids: [1000]
+    /v1.Config/ListConfigHistory:
+      uids: [1001]
+      executables: ["/usr/bin/*"]
+relay:
+  methods:
+    ListContainers: {}
+`
+
+func TestAuthorize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "policy.yaml")
+	if err := ioutil.WriteFile(path, []byte(testPolicy), 0644); err != nil {
+		t.Fatalf("failed to write test policy: %v", err)
+	}
+	p, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("failed to load test policy: %v", err)
+	}
+
+	root := &Peer{UID: 0, GIDs: []uint32{0}, Executable: "/usr/bin/cri-resmgr-agent"}
+	monitor := &Peer{UID: 1001, GIDs: []uint32{1001, 1000}, Executable: "/usr/bin/monitor"}
+	other := &Peer{UID: 1001, GIDs: []uint32{1001}, Executable: "/opt/bin/monitor"}
+
+	tcases := []struct {
+		socket  string
+		method  string
+		peer    *Peer
+		allowed bool
+	}{
+		{ConfigSocket, "/v1.Config/SetConfig", root, true},
+		{ConfigSocket, "/v1.Config/SetConfig", monitor, false},
+		{ConfigSocket, "/v1.Config/GetConfig", monitor, true},
+		{ConfigSocket, "/v1.Config/GetConfig", other, false},
+		{ConfigSocket, "/v1.Config/ListConfigHistory", monitor, true},
+		{ConfigSocket, "/v1.Config/ListConfigHistory", other, false},
+		{ConfigSocket, "/v1.Config/SetConfig", nil, false},
+		{RelaySocket, "/runtime.v1alpha2.RuntimeService/ListContainers", other, true},
+		{RelaySocket, "/runtime.v1alpha2.RuntimeService/RunPodSandbox", root, false},
+		{AgentSocket, "/v1.Agent/GetNode", other, true},
+	}
+
+	for _, tc := range tcases {
+		err := p.Authorize(tc.socket, tc.method, tc.peer)
+		if tc.allowed && err != nil {
+			t.Errorf("%s %s by %s: unexpectedly denied: %v", tc.socket, tc.method, tc.peer, err)
+		}
+		if !tc.allowed && err == nil {
+			t.Errorf("%s %s by %s: unexpectedly allowed", tc.socket, tc.method, tc.peer)
+		}
+	}
+
+	if err := ioutil.WriteFile(path, []byte("cnofig: {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write test policy: %v", err)
+	}
+	if _, err := LoadPolicy(path); err == nil {
+		t.Errorf("expected error for unknown socket, got none")
+	}
+}
+
+func TestGetPeer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "test.sock")
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to listen on socket: %v", err)
+	}
+	defer lis.Close()
+
+	go func() {
+		conn, err := net.Dial("unix", socket)
+		if err == nil {
+			defer conn.Close()
+			conn.Read(make([]byte, 1))
+		}
+	}()
+
+	conn, err := lis.Accept()
+	if err != nil {
+		t.Fatalf("failed to accept connection: %v", err)
+	}
+	defer conn.Close()
+
+	peer, err := GetPeer(conn)
+	if err != nil {
+		t.Fatalf("failed to get peer credentials: %v", err)
+	}
+	if peer.PID != int32(os.Getpid()) || peer.UID != uint32(os.Getuid()) {
+		t.Errorf("unexpected peer %s, expected pid %d, uid %d", peer, os.Getpid(), os.Getuid())
+	}
+	if exe, _ := os.Executable(); exe != "" && peer.Executable != exe {
+		t.Errorf("unexpected peer executable %q, expected %q", peer.Executable, exe)
+	}
+}
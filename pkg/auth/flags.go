@@ -0,0 +1,37 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"flag"
+)
+
+// Access control options configurable via the command line.
+//
+// The access policy is deliberately not part of the dynamic configuration.
+// Otherwise anyone allowed to update the configuration could grant itself
+// access to anything else.
+type options struct {
+	PolicyFile string // socket access policy file
+}
+
+// Access control command line options.
+var opt = options{}
+
+// Register us for command line option processing.
+func init() {
+	flag.StringVar(&opt.PolicyFile, "socket-access-policy", "",
+		"YAML file with the peer credential based access policy for our gRPC sockets.")
+}
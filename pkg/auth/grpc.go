@@ -0,0 +1,126 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AuthType is the authentication type of our peer credentials.
+	AuthType = "peercred"
+)
+
+// AuthInfo is the gRPC authentication info for a unix domain socket peer.
+type AuthInfo struct {
+	Peer *Peer
+}
+
+// AuthType returns the authentication type of the info.
+func (ai *AuthInfo) AuthType() string {
+	return AuthType
+}
+
+// peerCredentials are gRPC transport credentials for capturing peer credentials.
+type peerCredentials struct{}
+
+// ServerHandshake captures the peer credentials of an accepted connection.
+func (peerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	p, err := GetPeer(conn)
+	if err != nil {
+		log.Warn("%v", err)
+		return conn, &AuthInfo{}, nil
+	}
+	return conn, &AuthInfo{Peer: p}, nil
+}
+
+// ClientHandshake is a no-op, peer credentials are only used on the server side.
+func (peerCredentials) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, &AuthInfo{}, nil
+}
+
+// Info returns the protocol info of the credentials.
+func (peerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: AuthType}
+}
+
+// Clone returns a copy of the credentials.
+func (c peerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+// OverrideServerName is a no-op for peer credentials.
+func (peerCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// ServerOptions returns gRPC server options for authorizing requests to the socket.
+func ServerOptions(socket string) ([]grpc.ServerOption, error) {
+	p, err := getPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p[socket]; !ok {
+		return []grpc.ServerOption{}, nil
+	}
+
+	log.Info("enforcing access policy on %s socket", socket)
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, p, socket, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), p, socket, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+
+	return []grpc.ServerOption{
+		grpc.Creds(peerCredentials{}),
+		grpc.UnaryInterceptor(unary),
+		grpc.StreamInterceptor(stream),
+	}, nil
+}
+
+// authorize checks the request against the policy, audit-logging any denied ones.
+func authorize(ctx context.Context, p Policy, socket, method string) error {
+	var peerInfo *Peer
+	if pr, ok := peer.FromContext(ctx); ok {
+		if ai, ok := pr.AuthInfo.(*AuthInfo); ok {
+			peerInfo = ai.Peer
+		}
+	}
+
+	if err := p.Authorize(socket, method, peerInfo); err != nil {
+		log.Warn("audit: DENIED %s socket request %s from %s: %v", socket, method, peerInfo, err)
+		return status.Errorf(codes.PermissionDenied, "permission denied: %v", err)
+	}
+
+	log.Debug("audit: allowed %s socket request %s from %s", socket, method, peerInfo)
+	return nil
+}
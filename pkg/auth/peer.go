@@ -0,0 +1,116 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// Peer describes the process on the other end of a unix domain socket.
+type Peer struct {
+	// PID is the process ID of the peer.
+	PID int32
+	// UID is the user ID of the peer.
+	UID uint32
+	// GIDs are the primary and supplementary group IDs of the peer.
+	GIDs []uint32
+	// Executable is the path of the peer executable, if known.
+	Executable string
+}
+
+// GetPeer returns the credentials of the peer of a unix domain socket connection.
+func GetPeer(conn net.Conn) (*Peer, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, authError("can't get peer credentials for %T connection", conn)
+	}
+
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return nil, authError("failed to get raw connection: %v", err)
+	}
+
+	var cred *unix.Ucred
+	var credErr error
+	err = raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err == nil {
+		err = credErr
+	}
+	if err != nil {
+		return nil, authError("failed to get peer credentials: %v", err)
+	}
+
+	peer := &Peer{
+		PID:  cred.Pid,
+		UID:  cred.Uid,
+		GIDs: []uint32{cred.Gid},
+	}
+
+	if exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", cred.Pid)); err == nil {
+		peer.Executable = exe
+	}
+	peer.GIDs = append(peer.GIDs, supplementaryGroups(cred.Pid, cred.Gid)...)
+
+	return peer, nil
+}
+
+// supplementaryGroups returns the supplementary group IDs of a process.
+func supplementaryGroups(pid int32, primary uint32) []uint32 {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	gids := []uint32{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if !strings.HasPrefix(line, "Groups:") {
+			continue
+		}
+		for _, field := range strings.Fields(strings.TrimPrefix(line, "Groups:")) {
+			gid, err := strconv.ParseUint(field, 10, 32)
+			if err != nil || uint32(gid) == primary {
+				continue
+			}
+			gids = append(gids, uint32(gid))
+		}
+		break
+	}
+
+	return gids
+}
+
+// String returns the peer as a string.
+func (p *Peer) String() string {
+	if p == nil {
+		return "<unknown peer>"
+	}
+	exe := p.Executable
+	if exe == "" {
+		exe = "<unknown>"
+	}
+	return fmt.Sprintf("pid %d (uid %d, gids %v, exe %s)", p.PID, p.UID, p.GIDs, exe)
+}
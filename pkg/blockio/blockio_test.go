@@ -20,6 +20,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-multierror"
+
 	"github.com/intel/cri-resource-manager/pkg/cgroups"
 	"github.com/intel/cri-resource-manager/pkg/testutils"
 )
@@ -296,3 +298,63 @@ func (mpf mockPlatform) configurableBlockDevices(devWildcards []string) ([]Block
 	}
 	return blockDevices, nil
 }
+
+func TestValidateOptions(t *testing.T) {
+	tcases := []struct {
+		name                    string
+		classes                 map[string][]DevicesParameters
+		expectedErrorCount      int
+		expectedErrorSubstrings []string
+	}{
+		{
+			name: "valid classes",
+			classes: map[string][]DevicesParameters{
+				"slowreader": {
+					{Weight: "100"},
+					{Devices: []string{"/dev/sda"}, ThrottleReadBps: "1M"},
+				},
+			},
+		},
+		{
+			name: "invalid parameters",
+			classes: map[string][]DevicesParameters{
+				"bad": {
+					{Weight: "5"},
+					{Devices: []string{"/dev/sda"}, ThrottleWriteIOPS: "many"},
+				},
+			},
+			expectedErrorCount:      2,
+			expectedErrorSubstrings: []string{"\"Weight\"", "\"ThrottleWriteIOPS\""},
+		},
+		{
+			name: "throttling without devices",
+			classes: map[string][]DevicesParameters{
+				"nodevs": {{ThrottleReadBps: "1M"}},
+			},
+			expectedErrorCount:      1,
+			expectedErrorSubstrings: []string{"without Devices"},
+		},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateOptions(&options{Classes: tc.classes})
+			if tc.expectedErrorCount == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected %d errors, got none", tc.expectedErrorCount)
+			}
+			if errs := err.(*multierror.Error).Errors; len(errs) != tc.expectedErrorCount {
+				t.Errorf("expected %d errors, got %d: %v", tc.expectedErrorCount, len(errs), err)
+			}
+			for _, substring := range tc.expectedErrorSubstrings {
+				if !strings.Contains(err.Error(), substring) {
+					t.Errorf("expected error to contain %q, got %v", substring, err)
+				}
+			}
+		})
+	}
+}
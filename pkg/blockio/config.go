@@ -17,6 +17,10 @@ limitations under the License.
 package blockio
 
 import (
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+
 	pkgcfg "github.com/intel/cri-resource-manager/pkg/config"
 )
 
@@ -44,6 +48,42 @@ func defaultOptions() interface{} {
 	return &options{}
 }
 
+// validateOptions checks candidate options without applying them.
+func validateOptions(cfg interface{}) error {
+	o := cfg.(*options)
+	classes := make([]string, 0, len(o.Classes))
+	for class := range o.Classes {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	var errors *multierror.Error
+	for _, class := range classes {
+		for _, dp := range o.Classes[class] {
+			for _, field := range []struct {
+				name, value string
+				min, max    int64
+			}{
+				{"Weight", dp.Weight, 10, 1000},
+				{"ThrottleReadBps", dp.ThrottleReadBps, 0, -1},
+				{"ThrottleWriteBps", dp.ThrottleWriteBps, 0, -1},
+				{"ThrottleReadIOPS", dp.ThrottleReadIOPS, 0, -1},
+				{"ThrottleWriteIOPS", dp.ThrottleWriteIOPS, 0, -1},
+			} {
+				if _, err := parseAndValidateInt64(field.name, field.value, -1, field.min, field.max); err != nil {
+					errors = multierror.Append(errors, blockioError("class %q: %v", class, err))
+				}
+			}
+			if dp.Devices == nil && (dp.ThrottleReadBps != "" || dp.ThrottleWriteBps != "" ||
+				dp.ThrottleReadIOPS != "" || dp.ThrottleWriteIOPS != "") {
+				errors = multierror.Append(errors, blockioError("class %q: throttling without Devices", class))
+			}
+		}
+	}
+	return errors.ErrorOrNil()
+}
+
 func init() {
-	pkgcfg.Register(ConfigModuleName, "Block I/O class control", opt, defaultOptions)
+	pkgcfg.Register(ConfigModuleName, "Block I/O class control", opt, defaultOptions,
+		pkgcfg.WithValidate(validateOptions))
 }
@@ -31,6 +31,9 @@ type GetConfigFn func() interface{}
 // NotifyFn is used to notify a module about configuration changes.
 type NotifyFn func(Event, Source) error
 
+// ValidateFn is used to check candidate configuration of a module without applying it.
+type ValidateFn func(interface{}) error
+
 // Event describes what triggered an invocation of a configuration notification callback.
 type Event string
 
@@ -64,6 +67,7 @@ type Module struct {
 	children    map[string]*Module // modules nested under this module
 	getdefault  GetConfigFn        // getter for default configuration
 	notifiers   []NotifyFn         // update notification callbacks
+	validators  []ValidateFn       // validation callbacks
 	noValidate  bool               // omit data validation
 }
 
@@ -97,6 +101,15 @@ func SetConfigFromFile(path string) error {
 	return setconfig(data, ConfigFile)
 }
 
+// SetConfigFromFiles updates the configuration from the merged content of the given files.
+func SetConfigFromFiles(paths ...string) error {
+	data, err := DataFromFiles(paths...)
+	if err != nil {
+		return configError("failed to apply configuration from files: %v", err)
+	}
+	return setconfig(data, ConfigFile)
+}
+
 // GetModule looks up the module for the given path, implicitly creating it if necessary.
 func GetModule(path string) *Module {
 	return lookup(path)
@@ -107,6 +120,11 @@ func (m *Module) AddNotify(fn NotifyFn) error {
 	return WithNotify(fn).apply(m)
 }
 
+// AddValidate attaches the given validation callback to the module.
+func (m *Module) AddValidate(fn ValidateFn) error {
+	return WithValidate(fn).apply(m)
+}
+
 // Register registers a unit of configuration data to be handled by this package.
 func Register(path, description string, ptr interface{}, getfn GetConfigFn, opts ...Option) *Module {
 	m := lookup(path)
@@ -259,6 +277,11 @@ func (m *Module) apply(cfg Data) error {
 
 	log.Debug("module %s: applying module configuration...", m.path)
 
+	return m.decode(cfg, m.ptr)
+}
+
+// decode resets the given module configuration data to defaults then decodes cfg into it.
+func (m *Module) decode(cfg Data, ptr interface{}) error {
 	// First, reset module config to defaults
 	defcfg, err := DataFromObject(m.getdefault())
 	if err != nil {
@@ -268,7 +291,7 @@ func (m *Module) apply(cfg Data) error {
 	if err != nil {
 		return configError("module %s: failed to marshal default configuration: %v", m.path, err)
 	}
-	if err = yaml.Unmarshal(raw, m.ptr); err != nil {
+	if err = yaml.Unmarshal(raw, ptr); err != nil {
 		return configError("module %s: failed to pre-reset to default configuration: %v", m.path, err)
 	}
 
@@ -278,7 +301,7 @@ func (m *Module) apply(cfg Data) error {
 		if err != nil {
 			return configError("module %s: failed to marshal configuration: %v", m.path, err)
 		}
-		if err = yaml.Unmarshal(raw, m.ptr); err != nil {
+		if err = yaml.Unmarshal(raw, ptr); err != nil {
 			return configError("module %s: failed to apply configuration: %v", m.path, err)
 		}
 	}
@@ -63,6 +63,35 @@ func DataFromFile(path string) (Data, error) {
 	return data, nil
 }
 
+// DataFromFiles unmarshals and merges the content of the given files, in the given order.
+//
+// Nested maps are merged recursively. Any other value from a later file overrides the
+// value of the same key from earlier files.
+func DataFromFiles(paths ...string) (Data, error) {
+	data := make(Data)
+	for _, path := range paths {
+		fragment, err := DataFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data.merge(fragment)
+	}
+	return data, nil
+}
+
+// StringMap remarshals configuration data into a map of per-key YAML strings.
+func (d Data) StringMap() (map[string]string, error) {
+	smap := make(map[string]string)
+	for key, val := range d {
+		raw, err := yaml.Marshal(val)
+		if err != nil {
+			return nil, configError("failed to marshal data for key %q: %v", key, err)
+		}
+		smap[key] = string(raw)
+	}
+	return smap, nil
+}
+
 // copy does a shallow copy of the given data.
 func (d Data) copy() Data {
 	data := make(Data)
@@ -72,6 +101,21 @@ func (d Data) copy() Data {
 	return data
 }
 
+// merge recursively merges the given data into this one.
+func (d Data) merge(other Data) {
+	for key, val := range other {
+		newm, newIsMap := val.(map[string]interface{})
+		oldm, oldIsMap := d[key].(map[string]interface{})
+		if newIsMap && oldIsMap {
+			merged := Data(oldm)
+			merged.merge(Data(newm))
+			d[key] = map[string]interface{}(merged)
+		} else {
+			d[key] = val
+		}
+	}
+}
+
 // split splits up the given data to module- and child-specific parts.
 func (d Data) split(hasChild func(string) bool) (Data, Data) {
 	mod, sub := make(Data), make(Data)
@@ -0,0 +1,90 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestDataFromFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"base.yaml": `
+policy:
+  Active: topology-aware
+  ReservedResources:
+    CPU: 750m
+logger:
+  Debug: resource-manager
+`,
+		"10-policy.yaml": `
+policy:
+  Active: static-pools
+`,
+		"20-logger.yaml": `
+logger:
+  Debug: cache
+dump:
+  Config: full:.*
+`,
+	}
+	paths := []string{}
+	for _, name := range []string{"base.yaml", "10-policy.yaml", "20-logger.yaml"} {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(files[name]), 0644); err != nil {
+			t.Fatalf("failed to write test file %s: %v", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	data, err := DataFromFiles(paths...)
+	if err != nil {
+		t.Fatalf("failed to load data from files: %v", err)
+	}
+
+	expected := make(Data)
+	err = yaml.Unmarshal([]byte(`
+policy:
+  Active: static-pools
+  ReservedResources:
+    CPU: 750m
+logger:
+  Debug: cache
+dump:
+  Config: full:.*
+`), &expected)
+	if err != nil {
+		t.Fatalf("failed to unmarshal expected data: %v", err)
+	}
+
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected merged data %v, got %v", expected, data)
+	}
+
+	if _, err := DataFromFiles(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("expected error for missing file, got none")
+	}
+}
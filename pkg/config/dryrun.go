@@ -0,0 +1,125 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Change describes a change in the effective value of a configuration field.
+type Change struct {
+	// Field is the path of the field within its module, in dotted notation.
+	Field string
+	// Old is the current effective value of the field, nil if unset.
+	Old interface{}
+	// New is the effective value of the field with the new configuration, nil if unset.
+	New interface{}
+}
+
+// Diff is a per-module diff of effective configuration values.
+type Diff map[string][]*Change
+
+// DryRun checks what the given configuration data would change without applying it.
+//
+// The returned diff contains the changed effective values of all modules which the
+// data could be decoded for. The returned errors are all the errors found checking
+// the data against the configuration schema and by the validation functions of the
+// modules, which stand in for the update notifications of a real update.
+func DryRun(data Data) (Diff, []error) {
+	diff := make(Diff)
+	main.diff(data.copy(), diff)
+	return diff, main.verify(data.copy())
+}
+
+// DryRunFromStringMap does a dry-run of configuration data from an external source.
+func DryRunFromStringMap(cfg map[string]string) (Diff, []error) {
+	data, err := DataFromStringMap(cfg)
+	if err != nil {
+		return nil, []error{configError("failed to dry-run configuration: %v", err)}
+	}
+	return DryRun(data)
+}
+
+// diff collects the changes the given data would cause to the module and its children.
+func (m *Module) diff(data Data, diff Diff) {
+	modcfg, subcfg := data.split(m.hasChild)
+
+	if !m.isImplicit() {
+		scratch := reflect.New(reflect.TypeOf(m.ptr).Elem()).Interface()
+		if err := m.decode(modcfg, scratch); err != nil {
+			log.Debug("module %s: skipping diff, failed to decode data: %v", m.path, err)
+		} else {
+			oldcfg, err := DataFromObject(m.ptr)
+			if err != nil {
+				log.Error("module %s: failed to get configuration: %v", m.path, err)
+				return
+			}
+			newcfg, err := DataFromObject(scratch)
+			if err != nil {
+				log.Error("module %s: failed to get new configuration: %v", m.path, err)
+				return
+			}
+			if changes := diffValues("", map[string]interface{}(oldcfg),
+				map[string]interface{}(newcfg)); len(changes) > 0 {
+				diff[m.path] = changes
+			}
+		}
+	}
+
+	for name, child := range m.children {
+		childcfg, err := subcfg.pick(name, true)
+		if err != nil {
+			continue
+		}
+		child.diff(childcfg, diff)
+	}
+}
+
+// diffValues returns the changes between two generic (YAML-decoded) values.
+func diffValues(field string, oldv, newv interface{}) []*Change {
+	oldm, oldIsMap := oldv.(map[string]interface{})
+	newm, newIsMap := newv.(map[string]interface{})
+
+	if !oldIsMap || !newIsMap {
+		if reflect.DeepEqual(oldv, newv) {
+			return nil
+		}
+		return []*Change{{Field: field, Old: oldv, New: newv}}
+	}
+
+	keys := map[string]struct{}{}
+	for key := range oldm {
+		keys[key] = struct{}{}
+	}
+	for key := range newm {
+		keys[key] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	changes := []*Change{}
+	for _, key := range sorted {
+		path := key
+		if field != "" {
+			path = field + "." + key
+		}
+		changes = append(changes, diffValues(path, oldm[key], newm[key])...)
+	}
+	return changes
+}
@@ -28,6 +28,25 @@ func WithNotify(fn NotifyFn) Option {
 	})
 }
 
+// WithValidate specifies a function to be called to check configuration without applying it.
+//
+// The function is called with a pointer to a scratch copy of the module configuration data
+// and it must not alter any runtime state. It is used for offline validation and dry-runs of
+// configuration. Components which reject configuration in their update notification callback
+// should attach a validation function doing the same checks, so that dry-runs catch those.
+func WithValidate(fn ValidateFn) Option {
+	return newFuncOption(func(o interface{}) error {
+		switch o.(type) {
+		case *Module:
+			m := o.(*Module)
+			m.validators = append(m.validators, fn)
+		default:
+			return configError("WithValidate is not valid for object of type %T", o)
+		}
+		return nil
+	})
+}
+
 // WithoutDataValidation specifies that data passed to this module should not be validated.
 func WithoutDataValidation() Option {
 	return newFuncOption(func(o interface{}) error {
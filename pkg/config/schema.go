@@ -0,0 +1,302 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// SchemaVersion is the JSON Schema draft our generated schemas conform to.
+	SchemaVersion = "http://json-schema.org/draft-07/schema#"
+)
+
+// Schema is a JSON Schema describing (a part of) the configuration.
+type Schema struct {
+	// Version is the JSON Schema version, only set for the root schema.
+	Version string `json:"$schema,omitempty"`
+	// Title is the title of the schema.
+	Title string `json:"title,omitempty"`
+	// Description is the description of the described data.
+	Description string `json:"description,omitempty"`
+	// Type is the JSON type of the described data.
+	Type string `json:"type,omitempty"`
+	// Properties describe the properties of an object.
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties is either false, or describes any other properties of an object.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+	// Items describes the items of an array.
+	Items *Schema `json:"items,omitempty"`
+	// Minimum is the minimum value of a number.
+	Minimum *float64 `json:"minimum,omitempty"`
+	// Default is the default value of the described data.
+	Default interface{} `json:"default,omitempty"`
+
+	caseless bool // property names match case-insensitively, like in encoding/json
+}
+
+// GetSchema returns a JSON Schema for the full configuration.
+func GetSchema() *Schema {
+	s := main.schema()
+	s.Version = SchemaVersion
+	s.Title = "CRI Resource Manager configuration"
+	return s
+}
+
+// JSON returns the schema as indented JSON.
+func (s *Schema) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// schema returns a JSON Schema for the module and its submodules.
+func (m *Module) schema() *Schema {
+	s := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	if !m.isImplicit() {
+		s.Description = m.description
+		if m.help != "" {
+			s.Description += "\n\n" + m.help
+		}
+		defaults, err := DataFromObject(m.getdefault())
+		if err != nil {
+			log.Error("module %s: failed to get default configuration: %v", m.path, err)
+		}
+		for name, field := range getStructFields(reflect.TypeOf(m.ptr).Elem()) {
+			fs := typeSchema(field.Type, map[reflect.Type]bool{})
+			if value, ok := defaults[name]; ok {
+				fs.Default = value
+			}
+			s.Properties[name] = fs
+		}
+	}
+
+	for name, child := range m.children {
+		s.Properties[name] = child.schema()
+	}
+
+	if !m.noValidate {
+		s.AdditionalProperties = false
+	}
+
+	return s
+}
+
+// getStructFields returns the JSON-visible fields of a struct, including embedded ones.
+func getStructFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+
+	var get func(t reflect.Type)
+	get = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			switch {
+			case f.Type.Kind() == reflect.Struct && f.Anonymous:
+				get(f.Type)
+			case f.PkgPath != "" || f.Tag.Get("json") == "-":
+				continue
+			default:
+				fields[fieldName(f)] = f
+			}
+		}
+	}
+
+	get(typ)
+
+	return fields
+}
+
+// Types with custom JSON/text (un)marshalling, which we can't describe by reflection.
+var (
+	jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// typeSchema returns a JSON Schema for data of the given type.
+func typeSchema(typ reflect.Type, seen map[reflect.Type]bool) *Schema {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Implements(jsonUnmarshaler) || reflect.PtrTo(typ).Implements(jsonUnmarshaler) ||
+		typ.Implements(textUnmarshaler) || reflect.PtrTo(typ).Implements(textUnmarshaler) {
+		return &Schema{Description: "Data of type " + typ.String() + "."}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: typeSchema(typ.Elem(), seen)}
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return &Schema{Type: "object"}
+		}
+		return &Schema{Type: "object", AdditionalProperties: typeSchema(typ.Elem(), seen)}
+	case reflect.Struct:
+		if seen[typ] {
+			return &Schema{Type: "object"}
+		}
+		seen[typ] = true
+		defer delete(seen, typ)
+		s := &Schema{
+			Type:                 "object",
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: false,
+			caseless:             true,
+		}
+		for name, field := range getStructFields(typ) {
+			s.Properties[name] = typeSchema(field.Type, seen)
+		}
+		return s
+	}
+
+	return &Schema{}
+}
+
+// check checks the given data against the schema, returning any errors found.
+func (s *Schema) check(path string, data interface{}) []error {
+	if s == nil || data == nil {
+		return nil
+	}
+
+	errs := []error{}
+	switch s.Type {
+	case "boolean":
+		if _, ok := data.(bool); !ok {
+			return []error{typeError(path, s.Type, data)}
+		}
+	case "integer", "number":
+		value, ok := data.(float64)
+		if !ok {
+			return []error{typeError(path, s.Type, data)}
+		}
+		if s.Type == "integer" && value != math.Trunc(value) {
+			return []error{typeError(path, s.Type, data)}
+		}
+		if s.Minimum != nil && value < *s.Minimum {
+			errs = append(errs, configError("%s: value %v is less than minimum %v",
+				path, value, *s.Minimum))
+		}
+	case "string":
+		// scalars are converted to strings when YAML is decoded into a string
+		switch data.(type) {
+		case string, float64, bool:
+		default:
+			return []error{typeError(path, s.Type, data)}
+		}
+	case "array":
+		items, ok := data.([]interface{})
+		if !ok {
+			return []error{typeError(path, s.Type, data)}
+		}
+		for idx, item := range items {
+			errs = append(errs, s.Items.check(fmt.Sprintf("%s[%d]", path, idx), item)...)
+		}
+	case "object":
+		obj, ok := data.(map[string]interface{})
+		if !ok {
+			d, ok := data.(Data)
+			if !ok {
+				return []error{typeError(path, s.Type, data)}
+			}
+			obj = d
+		}
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if ps, ok := s.property(key); ok {
+				errs = append(errs, ps.check(path+"."+key, obj[key])...)
+				continue
+			}
+			switch additional := s.AdditionalProperties.(type) {
+			case bool:
+				if !additional {
+					errs = append(errs, unknownError(path, key, s.Properties))
+				}
+			case *Schema:
+				errs = append(errs, additional.check(path+"."+key, obj[key])...)
+			}
+		}
+	}
+
+	return errs
+}
+
+// property looks up the schema for the given property.
+func (s *Schema) property(name string) (*Schema, bool) {
+	if ps, ok := s.Properties[name]; ok {
+		return ps, true
+	}
+	if s.caseless {
+		for key, ps := range s.Properties {
+			if strings.EqualFold(key, name) {
+				return ps, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// typeError returns an error about data of unexpected type.
+func typeError(path, expected string, data interface{}) error {
+	actual := "unknown"
+	switch data.(type) {
+	case bool:
+		actual = "boolean"
+	case float64:
+		actual = "number"
+	case string:
+		actual = "string"
+	case []interface{}:
+		actual = "array"
+	case map[string]interface{}, Data:
+		actual = "object"
+	}
+	return configError("%s: expected %s, got %s", path, expected, actual)
+}
+
+// unknownError returns an error about an unknown property, with a hint about similar ones.
+func unknownError(path, key string, properties map[string]*Schema) error {
+	for name := range properties {
+		if strings.EqualFold(name, key) {
+			return configError("%s: unknown field %q (did you mean %q?)", path, key, name)
+		}
+	}
+	return configError("%s: unknown field %q", path, key)
+}
@@ -0,0 +1,132 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ValidateFile checks the configuration in the given file without applying it.
+//
+// The file can contain either plain configuration data or a ConfigMap with the
+// configuration stored as YAML strings in its data, the way the node agent uses.
+func ValidateFile(path string) error {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return configError("failed to read file %q: %v", path, err)
+	}
+
+	data := make(Data)
+	if err := yaml.Unmarshal(raw, &data); err != nil {
+		return configError("failed to load configuration from file %q: %v", path, err)
+	}
+
+	if kind, ok := data["kind"]; ok && kind == "ConfigMap" {
+		cm := struct {
+			Data map[string]string `json:"data"`
+		}{}
+		if err := yaml.Unmarshal(raw, &cm); err != nil {
+			return configError("failed to load ConfigMap from file %q: %v", path, err)
+		}
+		if data, err = DataFromStringMap(cm.Data); err != nil {
+			return configError("failed to load configuration from ConfigMap %q: %v", path, err)
+		}
+	}
+
+	return Validate(data)
+}
+
+// Validate checks the given configuration data without applying it.
+//
+// The data is checked against the configuration schema, then decoded into a
+// scratch copy of the configuration of each module, which is in turn passed
+// to the validation functions of the module. All errors found are returned.
+func Validate(data Data) error {
+	errs := main.verify(data.copy())
+	if len(errs) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, "  - "+strings.TrimPrefix(err.Error(), "config error: "))
+	}
+	return configError("invalid configuration:\n%s", strings.Join(msgs, "\n"))
+}
+
+// verify checks data for the module and its children, returning any errors found.
+func (m *Module) verify(data Data) []error {
+	errs := []error{}
+	schema := m.schema()
+
+	modcfg, subcfg := data.split(m.hasChild)
+	keys := make([]string, 0, len(modcfg))
+	for key := range modcfg {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fs, ok := schema.Properties[key]
+		if !ok || m.isImplicit() {
+			if !m.noValidate {
+				errs = append(errs, unknownError(m.path, key, schema.Properties))
+			}
+			continue
+		}
+		errs = append(errs, fs.check(m.path+"."+key, modcfg[key])...)
+	}
+
+	if !m.isImplicit() && len(errs) == 0 {
+		scratch := reflect.New(reflect.TypeOf(m.ptr).Elem()).Interface()
+		if err := m.decode(modcfg, scratch); err != nil {
+			errs = append(errs, err)
+		} else {
+			for _, fn := range m.validators {
+				if err := fn(scratch); err != nil {
+					errs = append(errs, configError("module %s: %v", m.path, err))
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(m.children))
+	for name := range m.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := m.children[name]
+		childcfg, err := subcfg.pick(name, true)
+		if err != nil {
+			errs = append(errs, configError("module %s: failed to pick configuration for child %s: %v",
+				m.path, child.path, err))
+			continue
+		}
+		errs = append(errs, child.verify(childcfg)...)
+	}
+
+	for name := range subcfg {
+		errs = append(errs, unknownError(m.path, name, schema.Properties))
+	}
+
+	return errs
+}
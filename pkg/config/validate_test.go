@@ -0,0 +1,249 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+type testNested struct {
+	Name  string
+	Count uint
+}
+
+type testOptions struct {
+	Enabled bool
+	Limit   int
+	Mode    string
+	Nested  testNested
+	List    []testNested
+	Period  Duration
+}
+
+func TestValidate(t *testing.T) {
+	opt := &testOptions{}
+	defaults := func() interface{} { return &testOptions{Limit: 10, Mode: "auto"} }
+	notified := false
+	Register("test-validate", "Test validation.", opt, defaults,
+		WithNotify(func(Event, Source) error {
+			notified = true
+			return nil
+		}),
+		WithValidate(func(cfg interface{}) error {
+			if o := cfg.(*testOptions); o.Mode != "auto" && o.Mode != "manual" {
+				return fmt.Errorf("invalid mode %q", o.Mode)
+			}
+			return nil
+		}))
+
+	tcases := []struct {
+		name   string
+		config string
+		errors []string
+	}{
+		{
+			name: "valid configuration",
+			config: `
+test-validate:
+  Enabled: true
+  Limit: 5
+  Mode: manual
+  Nested:
+    name: foo
+    Count: 1
+  List:
+    - Name: 1
+  Period: 10s
+`,
+		},
+		{
+			name: "type errors",
+			config: `
+test-validate:
+  Enabled: "yes"
+  Limit: 1.5
+  Nested:
+    Count: -1
+  List: foo
+`,
+			errors: []string{
+				"test-validate.Enabled: expected boolean, got string",
+				"test-validate.Limit: expected integer, got number",
+				"test-validate.Nested.Count: value -1 is less than minimum 0",
+				"test-validate.List: expected array, got string",
+			},
+		},
+		{
+			name: "unknown fields",
+			config: `
+test-validate:
+  enabled: true
+  Nested:
+    Nmae: foo
+test-typo: {}
+`,
+			errors: []string{
+				`test-validate: unknown field "enabled" (did you mean "Enabled"?)`,
+				`test-validate.Nested: unknown field "Nmae"`,
+				`main: unknown field "test-typo"`,
+			},
+		},
+		{
+			name: "semantic errors",
+			config: `
+test-validate:
+  Mode: foo
+`,
+			errors: []string{
+				`module test-validate: invalid mode "foo"`,
+			},
+		},
+		{
+			name: "decoding errors",
+			config: `
+test-validate:
+  Period: foo
+`,
+			errors: []string{
+				"module test-validate: failed to apply configuration",
+			},
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := make(Data)
+			if err := yaml.Unmarshal([]byte(tc.config), &data); err != nil {
+				t.Fatalf("failed to unmarshal test configuration: %v", err)
+			}
+			err := Validate(data)
+			if len(tc.errors) == 0 {
+				if err != nil {
+					t.Errorf("unexpected validation error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected validation errors, got none")
+			}
+			for _, expected := range tc.errors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error %q, got %v", expected, err)
+				}
+			}
+		})
+	}
+
+	if notified {
+		t.Errorf("validation unexpectedly notified module")
+	}
+	if opt.Limit != 0 || opt.Mode != "" {
+		t.Errorf("validation unexpectedly altered module configuration: %+v", *opt)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	opt := &testOptions{Limit: 10, Mode: "auto"}
+	defaults := func() interface{} { return &testOptions{Limit: 10, Mode: "auto"} }
+	Register("test-dryrun", "Test dry-run.", opt, defaults)
+
+	data := make(Data)
+	cfg := `
+test-dryrun:
+  Limit: 20
+  Nested:
+    Name: foo
+`
+	if err := yaml.Unmarshal([]byte(cfg), &data); err != nil {
+		t.Fatalf("failed to unmarshal test configuration: %v", err)
+	}
+
+	diff, errs := DryRun(data)
+	if len(errs) != 0 {
+		t.Errorf("unexpected dry-run errors: %v", errs)
+	}
+
+	changes := diff["test-dryrun"]
+	expected := map[string][2]interface{}{
+		"Limit":       {10.0, 20.0},
+		"Nested.Name": {"", "foo"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for _, c := range changes {
+		values, ok := expected[c.Field]
+		if !ok {
+			t.Errorf("unexpected change of field %s", c.Field)
+			continue
+		}
+		if c.Old != values[0] || c.New != values[1] {
+			t.Errorf("field %s: expected %v => %v, got %v => %v",
+				c.Field, values[0], values[1], c.Old, c.New)
+		}
+	}
+
+	if opt.Limit != 10 || opt.Nested.Name != "" {
+		t.Errorf("dry-run unexpectedly altered module configuration: %+v", *opt)
+	}
+}
+
+func TestDryRunErrors(t *testing.T) {
+	opt := &testOptions{}
+	defaults := func() interface{} { return &testOptions{} }
+	m := Register("test-dryrun-errors", "Test dry-run errors.", opt, defaults,
+		WithValidate(func(cfg interface{}) error {
+			if cfg.(*testOptions).Limit > 100 {
+				return fmt.Errorf("limit too high")
+			}
+			return nil
+		}))
+	m.AddValidate(func(cfg interface{}) error {
+		if cfg.(*testOptions).Mode == "manual" {
+			return fmt.Errorf("manual mode not supported")
+		}
+		return nil
+	})
+
+	data := make(Data)
+	cfg := `
+test-dryrun-errors:
+  Limit: 200
+  Mode: manual
+`
+	if err := yaml.Unmarshal([]byte(cfg), &data); err != nil {
+		t.Fatalf("failed to unmarshal test configuration: %v", err)
+	}
+
+	diff, errs := DryRun(data)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 dry-run errors, got %d: %v", len(errs), errs)
+	}
+	for i, expected := range []string{"limit too high", "manual mode not supported"} {
+		if !strings.Contains(errs[i].Error(), expected) {
+			t.Errorf("expected error %d to contain %q, got %v", i, expected, errs[i])
+		}
+	}
+	if len(diff["test-dryrun-errors"]) != 2 {
+		t.Errorf("expected diff despite errors, got %v", diff)
+	}
+	if opt.Limit != 0 || opt.Mode != "" {
+		t.Errorf("dry-run unexpectedly altered module configuration: %+v", *opt)
+	}
+}
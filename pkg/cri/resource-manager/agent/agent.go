@@ -25,7 +25,9 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	core_v1 "k8s.io/api/core/v1"
+	resapi "k8s.io/apimachinery/pkg/api/resource"
 
 	agent_v1 "github.com/intel/cri-resource-manager/pkg/agent/api/v1"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config"
@@ -33,10 +35,15 @@ import (
 
 // Interface describe interfaces of cri-resource-manager agent
 type Interface interface {
+	IsConnected() bool
+
 	GetNode(time.Duration) (core_v1.Node, error)
 	PatchNode([]*agent_v1.JsonPatch, time.Duration) error
 	UpdateNodeCapacity(map[string]string, time.Duration) error
 	GetConfig(time.Duration) (*config.RawConfig, error)
+	UpdateNodeResourceTopology([]string, []*agent_v1.TopologyZone, time.Duration) error
+	PostPodEvents([]*agent_v1.PodEvent, time.Duration) error
+	GetPodResources(string, string, string, time.Duration) (map[string]core_v1.ResourceRequirements, map[string]core_v1.ResourceRequirements, error)
 
 	GetLabels(time.Duration) (map[string]string, error)
 	SetLabels(map[string]string, time.Duration) error
@@ -55,7 +62,8 @@ type Interface interface {
 
 // agentInterface implements Interface
 type agentInterface struct {
-	cli agent_v1.AgentClient
+	conn *grpc.ClientConn
+	cli  agent_v1.AgentClient
 }
 
 // NewAgentInterface connects to cri-resource-manager-agent gRPC server
@@ -76,11 +84,17 @@ func NewAgentInterface(socket string) (Interface, error) {
 	if err != nil {
 		return nil, agentError("failed to connect to cri-resmgr agent: %v", err)
 	}
+	a.conn = conn
 	a.cli = agent_v1.NewAgentClient(conn)
 
 	return a, nil
 }
 
+// IsConnected checks if we have an established connection to the agent.
+func (a *agentInterface) IsConnected() bool {
+	return a.conn.GetState() == connectivity.Ready
+}
+
 func (a *agentInterface) GetNode(timeout time.Duration) (core_v1.Node, error) {
 	ctx, cancel, callOpts := prepareCall(timeout)
 	defer cancel()
@@ -141,6 +155,93 @@ func (a *agentInterface) GetConfig(timeout time.Duration) (*config.RawConfig, er
 	return &config.RawConfig{NodeName: rpl.NodeName, Data: rpl.Config}, nil
 }
 
+func (a *agentInterface) UpdateNodeResourceTopology(policies []string, zones []*agent_v1.TopologyZone, timeout time.Duration) error {
+	ctx, cancel, callOpts := prepareCall(timeout)
+	defer cancel()
+
+	req := &agent_v1.UpdateNodeResourceTopologyRequest{
+		TopologyPolicies: policies,
+		Zones:            zones,
+	}
+	_, err := a.cli.UpdateNodeResourceTopology(ctx, req, callOpts...)
+	if err != nil {
+		return agentError("failed to update node resource topology: %v", err)
+	}
+	return nil
+}
+
+func (a *agentInterface) PostPodEvents(events []*agent_v1.PodEvent, timeout time.Duration) error {
+	ctx, cancel, callOpts := prepareCall(timeout)
+	defer cancel()
+
+	req := &agent_v1.PostPodEventsRequest{
+		Events: events,
+	}
+	_, err := a.cli.PostPodEvents(ctx, req, callOpts...)
+	if err != nil {
+		return agentError("failed to post pod events: %v", err)
+	}
+	return nil
+}
+
+func (a *agentInterface) GetPodResources(namespace, name, uid string, timeout time.Duration) (map[string]core_v1.ResourceRequirements, map[string]core_v1.ResourceRequirements, error) {
+	ctx, cancel, callOpts := prepareCall(timeout)
+	defer cancel()
+
+	req := &agent_v1.GetPodResourcesRequest{
+		Namespace: namespace,
+		Name:      name,
+		Uid:       uid,
+	}
+	rpl, err := a.cli.GetPodResources(ctx, req, callOpts...)
+	if err != nil {
+		return nil, nil, agentError("failed to get pod resources: %v", err)
+	}
+
+	initContainers, err := resourceRequirements(rpl.InitContainers)
+	if err != nil {
+		return nil, nil, err
+	}
+	containers, err := resourceRequirements(rpl.Containers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return initContainers, containers, nil
+}
+
+// resourceRequirements converts container resources from their gRPC representation.
+func resourceRequirements(resources map[string]*agent_v1.ContainerResources) (map[string]core_v1.ResourceRequirements, error) {
+	reqs := make(map[string]core_v1.ResourceRequirements, len(resources))
+	for container, res := range resources {
+		r := core_v1.ResourceRequirements{}
+		if len(res.GetRequests()) > 0 {
+			r.Requests = make(core_v1.ResourceList)
+		}
+		for name, value := range res.GetRequests() {
+			qty, err := resapi.ParseQuantity(value)
+			if err != nil {
+				return nil, agentError("invalid request %s=%q for container %s: %v",
+					name, value, container, err)
+			}
+			r.Requests[core_v1.ResourceName(name)] = qty
+		}
+		if len(res.GetLimits()) > 0 {
+			r.Limits = make(core_v1.ResourceList)
+		}
+		for name, value := range res.GetLimits() {
+			qty, err := resapi.ParseQuantity(value)
+			if err != nil {
+				return nil, agentError("invalid limit %s=%q for container %s: %v",
+					name, value, container, err)
+			}
+			r.Limits[core_v1.ResourceName(name)] = qty
+		}
+		reqs[container] = r
+	}
+	return reqs, nil
+}
+
 const (
 	// PatchAdd specifies an add operation.
 	PatchAdd string = "add"
@@ -0,0 +1,136 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+	"github.com/intel/cri-resource-manager/pkg/metrics"
+)
+
+// assignmentDesc describes our per-container assignment info metric.
+var assignmentDesc = prometheus.NewDesc(
+	"cri_resmgr_container_assignment",
+	"Resource assignment of a container managed by cri-resmgr, always 1.",
+	[]string{
+		"namespace",
+		"pod",
+		"container",
+		"container_id",
+		"pool",
+		"cpuset",
+		"mems",
+		"rdt_class",
+		"blockio_class",
+		"cpu_type",
+	}, nil,
+)
+
+// assignmentCollector exports the current resource assignments of containers.
+type assignmentCollector struct {
+	sync.RWMutex
+	assignments map[string][]string // label values per container cache ID
+}
+
+// Our container assignment collector.
+var assignments = &assignmentCollector{
+	assignments: make(map[string][]string),
+}
+
+// update updates the exported assignment of a container.
+func (c *assignmentCollector) update(id string, labels []string) {
+	c.Lock()
+	defer c.Unlock()
+	c.assignments[id] = labels
+}
+
+// remove removes the exported assignment of a container.
+func (c *assignmentCollector) remove(id string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.assignments, id)
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *assignmentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- assignmentDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *assignmentCollector) Collect(ch chan<- prometheus.Metric) {
+	c.RLock()
+	defer c.RUnlock()
+
+	for _, labels := range c.assignments {
+		ch <- prometheus.MustNewConstMetric(assignmentDesc, prometheus.GaugeValue, 1, labels...)
+	}
+}
+
+// updateAssignment updates the exported resource assignment of a container.
+func (m *resmgr) updateAssignment(c cache.Container) {
+	switch c.GetState() {
+	case cache.ContainerStateCreating, cache.ContainerStateCreated, cache.ContainerStateRunning:
+	default:
+		assignments.remove(c.GetCacheID())
+		return
+	}
+
+	podName := ""
+	if pod, ok := c.GetPod(); ok {
+		podName = pod.GetName()
+	}
+	pool, _ := m.policy.GetContainerPool(c)
+	cpuType, _ := m.policy.GetContainerCPUType(c)
+
+	assignments.update(c.GetCacheID(), []string{
+		c.GetNamespace(),
+		podName,
+		c.GetName(),
+		c.GetID(),
+		pool,
+		c.GetCpusetCpus(),
+		c.GetCpusetMems(),
+		c.GetRDTClass(),
+		c.GetBlockIOClass(),
+		cpuType,
+	})
+}
+
+// removeAssignment removes the exported resource assignment of a container.
+func (m *resmgr) removeAssignment(c cache.Container) {
+	assignments.remove(c.GetCacheID())
+}
+
+// updateAllAssignments updates the exported resource assignments of all containers.
+func (m *resmgr) updateAllAssignments() {
+	for _, c := range m.cache.GetContainers() {
+		m.updateAssignment(c)
+	}
+}
+
+// Register our container assignment collector.
+func init() {
+	err := metrics.RegisterCollector("assignments",
+		func() (prometheus.Collector, error) {
+			return assignments, nil
+		})
+	if err != nil {
+		logger.Get("resource-manager").Error("failed to register assignment metrics collector: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/policy"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/timeline"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+)
+
+// fakePolicy is a policy.Policy which only reports container pools and CPU types.
+type fakePolicy struct {
+	policy.Policy
+}
+
+func (p *fakePolicy) GetContainerPool(c cache.Container) (string, bool) {
+	return "pool0", true
+}
+
+func (p *fakePolicy) GetContainerCPUType(c cache.Container) (string, bool) {
+	return "normal", true
+}
+
+const assignmentHeader = `
+# HELP cri_resmgr_container_assignment Resource assignment of a container managed by cri-resmgr, always 1.
+# TYPE cri_resmgr_container_assignment gauge
+`
+
+func TestAssignmentMetrics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "assignment-metrics-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cch, err := cache.NewCache(cache.Options{CacheDir: dir})
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+
+	saved := assignments.assignments
+	assignments.assignments = map[string][]string{}
+	defer func() { assignments.assignments = saved }()
+
+	m := &resmgr{
+		Logger: logger.NewLogger("resource-manager-test"),
+		cache:  cch,
+		policy: &fakePolicy{},
+	}
+
+	cch.InsertPod("pod-id-0", &criapi.RunPodSandboxRequest{
+		Config: &criapi.PodSandboxConfig{
+			Metadata: &criapi.PodSandboxMetadata{Namespace: "default", Name: "pod0", Uid: "uid-0"},
+			Linux:    &criapi.LinuxPodSandboxConfig{CgroupParent: "/kubepods.slice/kubepods-poduid_0"},
+		},
+	}, nil, nil)
+	c, err := cch.InsertContainer(&criapi.Container{
+		Id:           "ctr-id-0",
+		PodSandboxId: "pod-id-0",
+		Metadata:     &criapi.ContainerMetadata{Name: "ctr0"},
+		State:        criapi.ContainerState_CONTAINER_RUNNING,
+	})
+	if err != nil {
+		t.Fatalf("failed to insert container: %v", err)
+	}
+	c.SetCpusetCpus("0-1")
+	c.SetCpusetMems("0")
+	c.SetRDTClass("gold")
+	c.SetBlockIOClass("slowreader")
+
+	expectMetrics := func(what, expected string) {
+		t.Helper()
+		if err := testutil.CollectAndCompare(assignments, strings.NewReader(expected),
+			"cri_resmgr_container_assignment"); err != nil {
+			t.Errorf("%s: %v", what, err)
+		}
+	}
+
+	m.recordAllocation(c, timeline.ActionAllocate, "test")
+	expectMetrics("timeline recording", "")
+
+	m.updateAssignment(c)
+	expectMetrics("allocation", assignmentHeader+
+		`cri_resmgr_container_assignment{blockio_class="slowreader",container="ctr0",`+
+		`container_id="ctr-id-0",cpu_type="normal",cpuset="0-1",mems="0",namespace="default",`+
+		`pod="pod0",pool="pool0",rdt_class="gold"} 1
+`)
+
+	c.SetCpusetCpus("2-3")
+	m.updateAssignment(c)
+	expectMetrics("update", assignmentHeader+
+		`cri_resmgr_container_assignment{blockio_class="slowreader",container="ctr0",`+
+		`container_id="ctr-id-0",cpu_type="normal",cpuset="2-3",mems="0",namespace="default",`+
+		`pod="pod0",pool="pool0",rdt_class="gold"} 1
+`)
+
+	m.removeAssignment(c)
+	expectMetrics("release", "")
+
+	m.updateAllAssignments()
+	if len(assignments.assignments) != 1 {
+		t.Errorf("expected assignment of running container to be exported again")
+	}
+	c.UpdateState(cache.ContainerStateExited)
+	m.updateAllAssignments()
+	expectMetrics("exited container", "")
+}
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	cri "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
@@ -58,6 +59,16 @@ const (
 	BlockIOClassKey = "blockioclass" + "." + kubernetes.ResmgrKeyNamespace
 	// ToptierLimitKey is the pod annotation key for specifying container top tier memory limits.
 	ToptierLimitKey = "toptierlimit" + "." + kubernetes.ResmgrKeyNamespace
+	// PreferIsolatedCPUsKey is the pod annotation key for specifying isolated CPU preference.
+	PreferIsolatedCPUsKey = "prefer-isolated-cpus" + "." + kubernetes.ResmgrKeyNamespace
+	// PreferSharedCPUsKey is the pod annotation key for specifying shared CPU preference.
+	PreferSharedCPUsKey = "prefer-shared-cpus" + "." + kubernetes.ResmgrKeyNamespace
+	// MemoryTypeKey is the pod annotation key for specifying the type of memory to allocate.
+	MemoryTypeKey = "memory-type" + "." + kubernetes.ResmgrKeyNamespace
+	// ColdStartKey is the pod annotation key for specifying container cold start preference.
+	ColdStartKey = "cold-start" + "." + kubernetes.ResmgrKeyNamespace
+	// PageMigrationKey is the pod annotation key for opting out of page demotion.
+	PageMigrationKey = "page-migration" + "." + kubernetes.ResmgrKeyNamespace
 
 	// RDTClassPodQoS denotes that the RDTClass should be taken from PodQosClass
 	RDTClassPodQoS = "/PodQos"
@@ -405,6 +416,8 @@ type Container interface {
 	SetTag(string, string) (string, bool)
 	// DeleteTag deletes the given tag, returning its deleted value.
 	DeleteTag(string) (string, bool)
+	// GetTags returns a copy of all the tags of the container.
+	GetTags() map[string]string
 }
 
 // A cached container.
@@ -518,11 +531,17 @@ type Cachable interface {
 // itself upon startup.
 type Cache interface {
 	// InsertPod inserts a pod into the cache, using a runtime request or reply.
-	InsertPod(id string, msg interface{}, status *PodStatus) Pod
+	// Unless the pod is annotated with its resource requirements, the given
+	// resources are used, or if they are nil, they are looked up using the
+	// PodResources function given in the cache options.
+	InsertPod(id string, msg interface{}, status *PodStatus, resources *PodResourceRequirements) Pod
 	// DeletePod deletes a pod from the cache.
 	DeletePod(id string) Pod
 	// LookupPod looks up a pod in the cache.
 	LookupPod(id string) (Pod, bool)
+	// UpdatePodMetadata updates the labels and annotations of the pod with the given UID.
+	// If they changed, it returns the affected containers, marked with pending changes.
+	UpdatePodMetadata(uid string, labels, annotations map[string]string) ([]Container, error)
 	// InsertContainer inserts a container into the cache, using a runtime request or reply.
 	InsertContainer(msg interface{}) (Container, error)
 	// UpdateContainerID updates a containers runtime id.
@@ -573,12 +592,20 @@ type Cache interface {
 	GetConfig() *config.RawConfig
 	// ResetConfig clears any stored configuration from the cache.
 	ResetConfig() error
+	// RecordConfig records an applied configuration in the configuration history.
+	RecordConfig(*config.RawConfig, string) *config.ConfigVersion
+	// GetConfigHistory returns the configuration history, oldest version first.
+	GetConfigHistory() []*config.ConfigVersion
+	// LookupConfigVersion looks up the given version in the configuration history.
+	LookupConfigVersion(uint64) (*config.ConfigVersion, bool)
 
 	// SetAdjustment updates external adjustments and containers based this.
 	SetAdjustment(*config.Adjustment) (bool, map[string]error)
 
 	// Save requests a cache save.
 	Save() error
+	// Snapshot takes a restorable snapshot of the current state of the cache.
+	Snapshot() ([]byte, error)
 
 	// RefreshPods purges/inserts stale/new pods/containers using a pod sandbox list response.
 	RefreshPods(*cri.ListPodSandboxResponse, map[string]*PodStatus) ([]Pod, []Pod, []Container)
@@ -596,6 +623,8 @@ type Cache interface {
 const (
 	// CacheVersion is the running version of the cache.
 	CacheVersion = "1"
+	// MaxConfigHistory is the maximum number of configurations kept in the history.
+	MaxConfigHistory = 16
 )
 
 // permissions describe preferred/expected ownership and permissions for a file or directory.
@@ -623,24 +652,32 @@ type cache struct {
 	Containers map[string]*container // known/cache containers
 	NextID     uint64                // next container cache id to use
 
-	Cfg        *config.RawConfig      // cached/current configuration
-	External   *config.Adjustment     // cached/current external adjustments
-	PolicyName string                 // name of the active policy
-	policyData map[string]interface{} // opaque policy data
-	PolicyJSON map[string]string      // ditto in raw, unmarshaled form
+	Cfg        *config.RawConfig       // cached/current configuration
+	History    []*config.ConfigVersion // history of applied configurations
+	External   *config.Adjustment      // cached/current external adjustments
+	PolicyName string                  // name of the active policy
+	policyData map[string]interface{}  // opaque policy data
+	PolicyJSON map[string]string       // ditto in raw, unmarshaled form
 
 	pending map[string]struct{} // cache IDs of containers with pending changes
 
 	implicit map[string]*ImplicitAffinity // implicit affinities
+
+	podResources PodResourcesFn // lookup for pod resource requirements
 }
 
 // Make sure cache implements Cache.
 var _ Cache = &cache{}
 
+// PodResourcesFn looks up the resource requirements of a pod by namespace, name, and UID.
+type PodResourcesFn func(namespace, name, uid string) (*PodResourceRequirements, error)
+
 // Options contains the configurable cache options.
 type Options struct {
 	// CacheDir is the directory the cache should save its state in.
 	CacheDir string
+	// PodResources, if set, is used to look up resource requirements of unannotated pods.
+	PodResources PodResourcesFn
 }
 
 // NewCache instantiates a new cache. Load it from the given path if it exists.
@@ -655,6 +692,8 @@ func NewCache(options Options) (Cache, error) {
 		policyData: make(map[string]interface{}),
 		PolicyJSON: make(map[string]string),
 		implicit:   make(map[string]*ImplicitAffinity),
+
+		podResources: options.PodResources,
 	}
 
 	if _, err := cch.checkPerm("cache", cch.filePath, false, cacheFilePerm); err != nil {
@@ -716,17 +755,62 @@ func (cch *cache) GetConfig() *config.RawConfig {
 
 // ResetConfig clears any stored configuration from the cache.
 func (cch *cache) ResetConfig() error {
-	old := cch.Cfg
+	old, history := cch.Cfg, cch.History
 	cch.Cfg = nil
+	cch.History = nil
 
 	if err := cch.Save(); err != nil {
-		cch.Cfg = old
+		cch.Cfg, cch.History = old, history
 		return err
 	}
 
 	return nil
 }
 
+// RecordConfig records an applied configuration in the configuration history.
+func (cch *cache) RecordConfig(cfg *config.RawConfig, source string) *config.ConfigVersion {
+	version := uint64(1)
+	if cnt := len(cch.History); cnt > 0 {
+		version = cch.History[cnt-1].Version + 1
+	}
+
+	entry := &config.ConfigVersion{
+		Version:  version,
+		Source:   source,
+		Time:     time.Now(),
+		Checksum: cfg.Checksum(),
+		Config:   cfg,
+	}
+
+	cch.History = append(cch.History, entry)
+	if cnt := len(cch.History); cnt > MaxConfigHistory {
+		cch.History = cch.History[cnt-MaxConfigHistory:]
+	}
+
+	if err := cch.Save(); err != nil {
+		cch.Error("failed to save configuration history: %v", err)
+	}
+
+	return entry
+}
+
+// GetConfigHistory returns the configuration history, oldest version first.
+func (cch *cache) GetConfigHistory() []*config.ConfigVersion {
+	history := make([]*config.ConfigVersion, len(cch.History))
+	copy(history, cch.History)
+	return history
+}
+
+// LookupConfigVersion looks up the given version in the configuration history.
+func (cch *cache) LookupConfigVersion(version uint64) (*config.ConfigVersion, bool) {
+	for _, entry := range cch.History {
+		if entry.Version == version {
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
 // SetAdjustment updates external adjustments and containers based on this.
 func (cch *cache) SetAdjustment(external *config.Adjustment) (bool, map[string]error) {
 	effective := map[*container]string{}
@@ -853,16 +937,16 @@ func (cch *cache) createCacheID(c *container) string {
 }
 
 // Insert a pod into the cache.
-func (cch *cache) InsertPod(id string, msg interface{}, status *PodStatus) Pod {
+func (cch *cache) InsertPod(id string, msg interface{}, status *PodStatus, resources *PodResourceRequirements) Pod {
 	var err error
 
 	p := &pod{cache: cch, ID: id}
 
 	switch msg.(type) {
 	case *cri.RunPodSandboxRequest:
-		err = p.fromRunRequest(msg.(*cri.RunPodSandboxRequest))
+		err = p.fromRunRequest(msg.(*cri.RunPodSandboxRequest), resources)
 	case *cri.PodSandbox:
-		err = p.fromListResponse(msg.(*cri.PodSandbox), status)
+		err = p.fromListResponse(msg.(*cri.PodSandbox), status, resources)
 	default:
 		err = fmt.Errorf("cannot create pod from message %T", msg)
 	}
@@ -900,6 +984,91 @@ func (cch *cache) LookupPod(id string) (Pod, bool) {
 	return p, ok
 }
 
+// Update the labels and annotations of a pod.
+func (cch *cache) UpdatePodMetadata(uid string, labels, annotations map[string]string) ([]Container, error) {
+	var p *pod
+
+	for id, pod := range cch.Pods {
+		if id == pod.ID && pod.UID == uid {
+			p = pod
+			break
+		}
+	}
+	if p == nil {
+		return nil, cacheError("no pod with UID %s", uid)
+	}
+
+	oldLabels, oldAnnotations := p.Labels, p.Annotations
+	if !p.updateMetadata(labels, annotations) {
+		return nil, nil
+	}
+
+	if !cch.isMetadataChangeRelevant(oldLabels, p.Labels, oldAnnotations, p.Annotations) {
+		cch.Debug("metadata of pod %s/%s changed, but none of the keys we use", p.Namespace, p.Name)
+		cch.Save()
+		return nil, nil
+	}
+
+	cch.Info("metadata of pod %s/%s changed, updating containers...", p.Namespace, p.Name)
+
+	containers := p.GetContainers()
+	for _, c := range containers {
+		if err := c.(*container).setDefaults(); err != nil {
+			cch.Error("%v", err)
+		}
+		c.(*container).markPending(allControllers...)
+	}
+
+	cch.Save()
+
+	return containers, nil
+}
+
+// Check if changed pod metadata affects any of its containers. This is the case
+// if an annotation we interpret, or a label used by any affinity or adjustment
+// scope expression has changed.
+func (cch *cache) isMetadataChangeRelevant(oldLabels, labels, oldAnnotations, annotations map[string]string) bool {
+	for _, key := range changedKeys(oldAnnotations, annotations) {
+		if isResmgrAnnotation(key) {
+			return true
+		}
+	}
+
+	changed := changedKeys(oldLabels, labels)
+	if len(changed) == 0 {
+		return false
+	}
+
+	expressions := []*resmgr.Expression{}
+	for id, c := range cch.Containers {
+		if id != c.CacheID {
+			continue
+		}
+		for _, a := range c.GetAffinity() {
+			expressions = append(expressions, a.Scope, a.Match)
+		}
+	}
+	if cch.External != nil {
+		for _, spec := range cch.External.Adjustments {
+			for _, scope := range spec.Scope {
+				expressions = append(expressions, scope.Containers...)
+			}
+		}
+	}
+
+	// Look for label keys in expression keys, which may refer to multiple
+	// labels, possibly of other objects. Matching too much is harmless here.
+	for _, key := range changed {
+		for _, expr := range expressions {
+			if expr != nil && strings.Contains(expr.Key, key) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // Insert a container into the cache.
 func (cch *cache) InsertContainer(msg interface{}) (Container, error) {
 	var err error
@@ -1030,7 +1199,7 @@ func (cch *cache) RefreshPods(msg *cri.ListPodSandboxResponse, status map[string
 		valid[item.Id] = struct{}{}
 		if _, ok := cch.Pods[item.Id]; !ok {
 			cch.Debug("inserting discovered pod %s...", item.Id)
-			pod := cch.InsertPod(item.Id, item, status[item.Id])
+			pod := cch.InsertPod(item.Id, item, status[item.Id], nil)
 			add = append(add, pod)
 		}
 	}
@@ -1434,6 +1603,7 @@ type snapshot struct {
 	Containers map[string]*container
 	NextID     uint64
 	Cfg        *config.RawConfig
+	History    []*config.ConfigVersion
 	PolicyName string
 	PolicyJSON map[string]string
 }
@@ -1445,6 +1615,7 @@ func (cch *cache) Snapshot() ([]byte, error) {
 		Pods:       make(map[string]*pod),
 		Containers: make(map[string]*container),
 		Cfg:        cch.Cfg,
+		History:    cch.History,
 		NextID:     cch.NextID,
 		PolicyName: cch.PolicyName,
 		PolicyJSON: cch.PolicyJSON,
@@ -1497,6 +1668,7 @@ func (cch *cache) Restore(data []byte) error {
 	cch.Pods = s.Pods
 	cch.Containers = s.Containers
 	cch.Cfg = s.Cfg
+	cch.History = s.History
 	cch.NextID = s.NextID
 	cch.PolicyJSON = s.PolicyJSON
 	cch.PolicyName = s.PolicyName
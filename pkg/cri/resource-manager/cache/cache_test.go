@@ -20,12 +20,18 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	resapi "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	cri "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 	kubecm "k8s.io/kubernetes/pkg/kubelet/cm"
 	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
 
+	"github.com/intel/cri-resource-manager/pkg/apis/resmgr"
+	extapi "github.com/intel/cri-resource-manager/pkg/apis/resmgr/v1alpha1"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/kubernetes"
 )
 
@@ -107,7 +113,7 @@ func createFakePod(cch Cache, fp *fakePod) (Pod, error) {
 	fp.podCfg = req.Config
 
 	cch.(*cache).Debug("*** => creating Pod: %+v\n", *req)
-	p := cch.InsertPod(fp.id, req, nil)
+	p := cch.InsertPod(fp.id, req, nil, nil)
 	cch.(*cache).Debug("*** <= created Pod: %+v\n", *p.(*pod))
 	return p, nil
 }
@@ -354,6 +360,289 @@ func TestDefaultRDTAndBlockIOClasses(t *testing.T) {
 	}
 }
 
+func TestAdjustedAnnotations(t *testing.T) {
+	fp := &fakePod{
+		name: "pod1",
+		annotations: map[string]string{
+			MemoryTypeKey + "/pod":                        "dram",
+			PreferSharedCPUsKey + "/container.container2": "true",
+		},
+	}
+
+	cch, dir, err := createTmpCache()
+	if err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+	defer removeTmpCache(dir)
+
+	pod, err := createFakePod(cch, fp)
+	if err != nil {
+		t.Fatalf("failed to create fake pod: %v", err)
+	}
+	for _, name := range []string{"container1", "container2"} {
+		if _, err := createFakeContainer(cch, &fakeContainer{fakePod: fp, name: name}); err != nil {
+			t.Fatalf("failed to create fake container %s: %v", name, err)
+		}
+	}
+
+	isolated, memType := true, "pmem"
+	adjustment := &extapi.AdjustmentSpec{
+		Scope: []extapi.AdjustmentScope{
+			{
+				Containers: []*resmgr.Expression{
+					{Key: resmgr.KeyName, Op: resmgr.Equals, Values: []string{"container1"}},
+				},
+			},
+		},
+		CPU:        &extapi.CPUPreferences{PreferIsolated: &isolated},
+		MemoryType: &memType,
+		ColdStart:  &extapi.ColdStart{Duration: metav1.Duration{Duration: 5 * time.Minute}},
+		AntiAffinity: []extapi.ContainerAffinity{
+			{
+				Match:  &resmgr.Expression{Key: resmgr.KeyName, Op: resmgr.Equals, Values: []string{"container2"}},
+				Weight: 10,
+			},
+		},
+	}
+	if ok, errs := cch.SetAdjustment(&config.Adjustment{
+		Adjustments: map[string]*extapi.AdjustmentSpec{"test": adjustment},
+	}); !ok {
+		t.Fatalf("failed to set adjustment: %v", errs)
+	}
+
+	tcases := []struct {
+		container string
+		key       string
+		value     string
+		found     bool
+	}{
+		{container: "container1", key: PreferIsolatedCPUsKey, value: "true", found: true},
+		{container: "container1", key: MemoryTypeKey, value: "pmem", found: true},
+		{container: "container1", key: ColdStartKey, value: "duration: 5m0s", found: true},
+		{container: "container1", key: PreferSharedCPUsKey},
+		{container: "container2", key: PreferIsolatedCPUsKey},
+		{container: "container2", key: MemoryTypeKey, value: "dram", found: true},
+		{container: "container2", key: PreferSharedCPUsKey, value: "true", found: true},
+	}
+	for _, tc := range tcases {
+		value, found := pod.GetEffectiveAnnotation(tc.key, tc.container)
+		if value != tc.value || found != tc.found {
+			t.Errorf("%s: annotation %s: got %q (%v), expected %q (%v)",
+				tc.container, tc.key, value, found, tc.value, tc.found)
+		}
+	}
+
+	c, ok := pod.GetContainer("container1")
+	if !ok {
+		t.Fatalf("failed to find container1")
+	}
+	affinity := c.GetAffinity()
+	if len(affinity) != 1 {
+		t.Fatalf("container1: got %d affinities, expected 1", len(affinity))
+	}
+	if affinity[0].Weight != -10 {
+		t.Errorf("container1: got affinity weight %d, expected -10", affinity[0].Weight)
+	}
+	if affinity[0].Scope == nil || affinity[0].Scope.String() != pod.ScopeExpression().String() {
+		t.Errorf("container1: got affinity scope %v, expected pod scope", affinity[0].Scope)
+	}
+}
+
+func TestPodResourcesLookup(t *testing.T) {
+	lookups := map[string]int{}
+	dir, err := ioutil.TempDir("", "cache-test")
+	if err != nil {
+		t.Fatalf("failed to create cache directory: %v", err)
+	}
+	defer removeTmpCache(dir)
+
+	cch, err := NewCache(Options{
+		CacheDir: dir,
+		PodResources: func(namespace, name, uid string) (*PodResourceRequirements, error) {
+			lookups[name]++
+			if name != "pod1" {
+				return nil, fmt.Errorf("pod %s/%s not found", namespace, name)
+			}
+			return &PodResourceRequirements{
+				Containers: map[string]v1.ResourceRequirements{
+					"container1": {
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resapi.MustParse("250m"),
+							v1.ResourceMemory: resapi.MustParse("100M"),
+						},
+						Limits: v1.ResourceList{
+							v1.ResourceMemory: resapi.MustParse("200M"),
+						},
+					},
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+
+	annotated := `{"containers": {"container1": {"requests": {"memory": "50M"}}}}`
+	tcases := []struct {
+		name        string
+		annotations map[string]string
+		lookups     int
+		memory      string
+	}{
+		{name: "pod1", lookups: 1, memory: "100M"},
+		{name: "pod2", annotations: map[string]string{KeyResourceAnnotation: annotated}, memory: "50M"},
+		{name: "pod3", lookups: 1},
+	}
+	for _, tc := range tcases {
+		fp := &fakePod{name: tc.name, annotations: tc.annotations}
+		if _, err := createFakePod(cch, fp); err != nil {
+			t.Fatalf("%s: failed to create fake pod: %v", tc.name, err)
+		}
+		c, err := createFakeContainer(cch, &fakeContainer{fakePod: fp, name: "container1"})
+		if err != nil {
+			t.Fatalf("%s: failed to create fake container: %v", tc.name, err)
+		}
+
+		if lookups[tc.name] != tc.lookups {
+			t.Errorf("%s: got %d lookups, expected %d", tc.name, lookups[tc.name], tc.lookups)
+		}
+		memory := ""
+		if qty, ok := c.GetResourceRequirements().Requests[v1.ResourceMemory]; ok {
+			memory = qty.String()
+		}
+		if memory != tc.memory {
+			t.Errorf("%s: got memory request %q, expected %q", tc.name, memory, tc.memory)
+		}
+	}
+}
+
+func TestUpdatePodMetadata(t *testing.T) {
+	fp := &fakePod{
+		name:        "pod1",
+		labels:      map[string]string{"app": "test"},
+		annotations: map[string]string{RDTClassKey + "/pod": "gold"},
+	}
+
+	cch, dir, err := createTmpCache()
+	if err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+	defer removeTmpCache(dir)
+
+	pod, err := createFakePod(cch, fp)
+	if err != nil {
+		t.Fatalf("failed to create fake pod: %v", err)
+	}
+	c, err := createFakeContainer(cch, &fakeContainer{fakePod: fp, name: "container1"})
+	if err != nil {
+		t.Fatalf("failed to create fake container: %v", err)
+	}
+	c.ClearPending(RDT)
+
+	if _, err := cch.UpdatePodMetadata("no-such-uid", nil, nil); err == nil {
+		t.Errorf("update of unknown pod should have failed")
+	}
+
+	containers, err := cch.UpdatePodMetadata(fp.uid,
+		map[string]string{"app": "test"},
+		map[string]string{RDTClassKey + "/pod": "gold"})
+	if err != nil || len(containers) != 0 {
+		t.Errorf("unchanged metadata: got %d containers (error %v), expected none",
+			len(containers), err)
+	}
+
+	containers, err = cch.UpdatePodMetadata(fp.uid,
+		map[string]string{"app": "test", "tier": "backend"},
+		map[string]string{RDTClassKey + "/pod": "silver"})
+	if err != nil {
+		t.Fatalf("failed to update pod metadata: %v", err)
+	}
+	if len(containers) != 1 || containers[0].GetCacheID() != c.GetCacheID() {
+		t.Fatalf("changed metadata: got %d containers, expected container1", len(containers))
+	}
+	if class := c.GetRDTClass(); class != "silver" {
+		t.Errorf("got RDT class %q, expected %q", class, "silver")
+	}
+	if !c.HasPending(RDT) {
+		t.Errorf("container should have pending RDT changes")
+	}
+	if value, ok := pod.GetLabel("tier"); !ok || value != "backend" {
+		t.Errorf("got label tier=%q, expected %q", value, "backend")
+	}
+	if _, ok := pod.GetLabel(kubetypes.KubernetesPodUIDLabel); !ok {
+		t.Errorf("label %s should have been preserved", kubetypes.KubernetesPodUIDLabel)
+	}
+	affinity := `
+container1:
+  - match:
+      key: labels/tier
+      operator: Equals
+      values: [ frontend ]
+`
+	for _, tc := range []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		expected    int
+	}{
+		{
+			name:        "unrelated label",
+			labels:      map[string]string{"app": "test", "tier": "backend", "owner": "someone"},
+			annotations: map[string]string{RDTClassKey + "/pod": "silver"},
+		},
+		{
+			name:        "unrelated annotation",
+			labels:      map[string]string{"app": "test", "tier": "backend", "owner": "someone"},
+			annotations: map[string]string{RDTClassKey + "/pod": "silver", "example.com/revision": "2"},
+		},
+		{
+			name:   "look-alike annotation",
+			labels: map[string]string{"app": "test", "tier": "backend", "owner": "someone"},
+			annotations: map[string]string{RDTClassKey + "/pod": "silver", "example.com/revision": "2",
+				"evil" + kubernetes.ResmgrKeyNamespace + "/pod": "true"},
+		},
+		{
+			name:   "affinity annotation",
+			labels: map[string]string{"app": "test", "tier": "backend", "owner": "someone"},
+			annotations: map[string]string{RDTClassKey + "/pod": "silver", "example.com/revision": "2",
+				kubernetes.ResmgrKey(keyAffinity): affinity},
+			expected: 1,
+		},
+		{
+			name:   "label used by affinity",
+			labels: map[string]string{"app": "test", "tier": "frontend", "owner": "someone"},
+			annotations: map[string]string{RDTClassKey + "/pod": "silver", "example.com/revision": "2",
+				kubernetes.ResmgrKey(keyAffinity): affinity},
+			expected: 1,
+		},
+		{
+			name:   "label not used by affinity",
+			labels: map[string]string{"app": "other", "tier": "frontend", "owner": "someone"},
+			annotations: map[string]string{RDTClassKey + "/pod": "silver", "example.com/revision": "2",
+				kubernetes.ResmgrKey(keyAffinity): affinity},
+		},
+	} {
+		for _, ctrl := range c.GetPending() {
+			c.ClearPending(ctrl)
+		}
+		containers, err := cch.UpdatePodMetadata(fp.uid, tc.labels, tc.annotations)
+		if err != nil {
+			t.Fatalf("%s: failed to update pod metadata: %v", tc.name, err)
+		}
+		if len(containers) != tc.expected {
+			t.Errorf("%s: got %d containers to update, expected %d", tc.name, len(containers), tc.expected)
+		}
+		if tc.expected == 0 && len(c.GetPending()) != 0 {
+			t.Errorf("%s: container should have no pending changes, got %v", tc.name, c.GetPending())
+		}
+		for key, value := range tc.labels {
+			if v, _ := pod.GetLabel(key); v != value {
+				t.Errorf("%s: got label %s=%q, expected %q", tc.name, key, v, value)
+			}
+		}
+	}
+}
+
 const (
 	// anything below 2 millicpus will yield 0 as an estimate
 	minNonZeroRequest = 2
@@ -363,6 +652,41 @@ const (
 	expectedAccuracy = 1
 )
 
+func TestContainerTags(t *testing.T) {
+	fp := &fakePod{name: "pod1"}
+
+	cch, dir, err := createTmpCache()
+	if err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+	defer removeTmpCache(dir)
+
+	if _, err := createFakePod(cch, fp); err != nil {
+		t.Fatalf("failed to create fake pod: %v", err)
+	}
+	c, err := createFakeContainer(cch, &fakeContainer{fakePod: fp, name: "container1"})
+	if err != nil {
+		t.Fatalf("failed to create fake container: %v", err)
+	}
+
+	c.SetTag("a", "1")
+	c.SetTag("b", "2")
+	tags := c.GetTags()
+	if len(tags) != 2 || tags["a"] != "1" || tags["b"] != "2" {
+		t.Errorf("unexpected tags %v", tags)
+	}
+
+	tags["c"] = "3"
+	if _, ok := c.GetTag("c"); ok {
+		t.Errorf("modifying returned tags should not change container tags")
+	}
+
+	c.DeleteTag("a")
+	if tags := c.GetTags(); len(tags) != 1 || tags["b"] != "2" {
+		t.Errorf("unexpected tags %v after deletion", tags)
+	}
+}
+
 func TestCPURequestCalculationAccuracy(t *testing.T) {
 	for request := 0; request < maxCPU; request++ {
 		shares := MilliCPUToShares(request)
@@ -414,3 +738,53 @@ func TestCPULimitCalculationAccuracy(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigHistory(t *testing.T) {
+	cch, dir, err := createTmpCache()
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	defer removeTmpCache(dir)
+
+	cnt := MaxConfigHistory + 3
+	for i := 1; i <= cnt; i++ {
+		cfg := &config.RawConfig{
+			NodeName: "node",
+			Data:     map[string]string{"policy": fmt.Sprintf("Active: test-%d", i)},
+		}
+		cv := cch.RecordConfig(cfg, "test")
+		if cv.Version != uint64(i) {
+			t.Errorf("expected config version %d, got %d", i, cv.Version)
+		}
+		if cv.Checksum != cfg.Checksum() {
+			t.Errorf("expected checksum %s, got %s", cfg.Checksum(), cv.Checksum)
+		}
+	}
+
+	history := cch.GetConfigHistory()
+	if len(history) != MaxConfigHistory {
+		t.Fatalf("expected %d versions in history, got %d", MaxConfigHistory, len(history))
+	}
+	if oldest := history[0].Version; oldest != uint64(cnt-MaxConfigHistory+1) {
+		t.Errorf("expected oldest version %d, got %d", cnt-MaxConfigHistory+1, oldest)
+	}
+	if _, ok := cch.LookupConfigVersion(1); ok {
+		t.Errorf("expired version 1 unexpectedly found in history")
+	}
+	if cv, ok := cch.LookupConfigVersion(uint64(cnt)); !ok {
+		t.Errorf("latest version %d not found in history", cnt)
+	} else if cv.Config.Data["policy"] != fmt.Sprintf("Active: test-%d", cnt) {
+		t.Errorf("unexpected data for version %d: %v", cnt, cv.Config.Data)
+	}
+
+	restored, err := NewCache(Options{CacheDir: dir})
+	if err != nil {
+		t.Fatalf("failed to reload cache: %v", err)
+	}
+	if len(restored.GetConfigHistory()) != MaxConfigHistory {
+		t.Errorf("configuration history not restored from saved cache")
+	}
+	if cv := restored.RecordConfig(&config.RawConfig{}, "test"); cv.Version != uint64(cnt+1) {
+		t.Errorf("expected config version %d after restore, got %d", cnt+1, cv.Version)
+	}
+}
@@ -502,6 +502,75 @@ func (c *container) getEffectiveAdjustment() (*extapi.AdjustmentSpec, string) {
 	return nil, c.Adjustment
 }
 
+// getAdjustedAnnotation returns the value an external adjustment sets for an annotation.
+func (c *container) getAdjustedAnnotation(key string) (string, bool) {
+	adjust, _ := c.getEffectiveAdjustment()
+	if adjust == nil {
+		return "", false
+	}
+
+	switch key {
+	case PreferIsolatedCPUsKey:
+		if pref, ok := adjust.GetIsolatedCPUPreference(); ok {
+			return strconv.FormatBool(pref), true
+		}
+	case PreferSharedCPUsKey:
+		if pref, ok := adjust.GetSharedCPUPreference(); ok {
+			return strconv.FormatBool(pref), true
+		}
+	case MemoryTypeKey:
+		return adjust.GetMemoryType()
+	case ColdStartKey:
+		if duration, ok := adjust.GetColdStartDuration(); ok {
+			return "duration: " + duration.String(), true
+		}
+	case PageMigrationKey:
+		if pref, ok := adjust.GetDemotionPreference(); ok {
+			return strconv.FormatBool(pref), true
+		}
+	}
+
+	return "", false
+}
+
+// getAdjustedAffinity returns the affinities an external adjustment sets for the container.
+func (c *container) getAdjustedAffinity(pod Pod) ([]*Affinity, bool) {
+	adjust, _ := c.getEffectiveAdjustment()
+	if adjust == nil || !adjust.HasAffinity() {
+		return nil, false
+	}
+
+	affinity := make([]*Affinity, 0, len(adjust.Affinity)+len(adjust.AntiAffinity))
+	for _, a := range adjust.Affinity {
+		affinity = append(affinity, adjustedAffinity(pod, a, DefaultWeight))
+	}
+	for _, a := range adjust.AntiAffinity {
+		affinity = append(affinity, adjustedAffinity(pod, a, -DefaultWeight))
+	}
+
+	return affinity, true
+}
+
+// adjustedAffinity converts an adjustment affinity to one with the given default weight.
+func adjustedAffinity(pod Pod, a extapi.ContainerAffinity, weight int32) *Affinity {
+	affinity := &Affinity{
+		Match:  a.Match.DeepCopy(),
+		Weight: weight,
+	}
+	if a.Scope != nil {
+		affinity.Scope = a.Scope.DeepCopy()
+	} else if pod != nil {
+		affinity.Scope = pod.ScopeExpression()
+	}
+	if a.Weight != 0 {
+		affinity.Weight = a.Weight
+		if weight < 0 {
+			affinity.Weight *= -1
+		}
+	}
+	return affinity
+}
+
 func (c *container) SetCommand(value []string) {
 	c.Command = value
 	c.markPending(CRI)
@@ -757,7 +826,11 @@ func (c *container) GetAffinity() []*Affinity {
 		c.cache.Error("internal error: can't find Pod for container %s", c.PrettyName())
 	}
 
-	affinity := append(pod.GetContainerAffinity(c.GetName()), c.implicitAffinities()...)
+	affinity, ok := c.getAdjustedAffinity(pod)
+	if !ok {
+		affinity = pod.GetContainerAffinity(c.GetName())
+	}
+	affinity = append(affinity, c.implicitAffinities()...)
 	c.cache.Debug("affinity for container %s:", c.PrettyName())
 	for _, a := range affinity {
 		c.cache.Debug("  - %s", a.String())
@@ -970,6 +1043,14 @@ func (c *container) DeleteTag(key string) (string, bool) {
 	return value, ok
 }
 
+func (c *container) GetTags() map[string]string {
+	tags := make(map[string]string, len(c.Tags))
+	for key, value := range c.Tags {
+		tags[key] = value
+	}
+	return tags
+}
+
 func (c *container) implicitAffinities() []*Affinity {
 	implicit := []*Affinity{}
 	for name, ia := range c.cache.implicit {
@@ -16,6 +16,7 @@ package cache
 
 import (
 	"encoding/json"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -32,8 +33,11 @@ const (
 	KeyResourceAnnotation = "intel.com/resources"
 )
 
+// runtimeMetadataPrefixes are the prefixes of labels and annotations set by the kubelet or the runtime.
+var runtimeMetadataPrefixes = []string{"io.kubernetes.", "kubernetes.io/config."}
+
 // Create a pod from a run request.
-func (p *pod) fromRunRequest(req *cri.RunPodSandboxRequest) error {
+func (p *pod) fromRunRequest(req *cri.RunPodSandboxRequest, resources *PodResourceRequirements) error {
 	cfg := req.Config
 	if cfg == nil {
 		return cacheError("pod %s has no config", p.ID)
@@ -56,13 +60,13 @@ func (p *pod) fromRunRequest(req *cri.RunPodSandboxRequest) error {
 		p.cache.Error("%v", err)
 	}
 
-	p.parseResourceAnnotations()
+	p.parseResourceAnnotations(resources)
 
 	return nil
 }
 
 // Create a pod from a list response.
-func (p *pod) fromListResponse(pod *cri.PodSandbox, status *PodStatus) error {
+func (p *pod) fromListResponse(pod *cri.PodSandbox, status *PodStatus, resources *PodResourceRequirements) error {
 	meta := pod.Metadata
 	if meta == nil {
 		return cacheError("pod %s has no reply metadata", p.ID)
@@ -81,11 +85,79 @@ func (p *pod) fromListResponse(pod *cri.PodSandbox, status *PodStatus) error {
 		p.cache.Error("%v", err)
 	}
 
-	p.parseResourceAnnotations()
+	p.parseResourceAnnotations(resources)
 
 	return nil
 }
 
+// Update the labels and annotations of a pod, returning true if they changed.
+// Labels and annotations set by the kubelet or the runtime are preserved.
+func (p *pod) updateMetadata(labels, annotations map[string]string) bool {
+	labels = mergeRuntimeMetadata(p.Labels, labels)
+	annotations = mergeRuntimeMetadata(p.Annotations, annotations)
+
+	if sameMetadata(labels, p.Labels) && sameMetadata(annotations, p.Annotations) {
+		return false
+	}
+
+	p.Labels = labels
+	p.Annotations = annotations
+	p.Affinity = nil
+
+	return true
+}
+
+// Merge updated metadata with any runtime-specific entries of the current one.
+func mergeRuntimeMetadata(current, updated map[string]string) map[string]string {
+	merged := make(map[string]string, len(updated))
+	for key, value := range current {
+		for _, prefix := range runtimeMetadataPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				merged[key] = value
+				break
+			}
+		}
+	}
+	for key, value := range updated {
+		merged[key] = value
+	}
+	return merged
+}
+
+// Get the keys which differ between two sets of labels or annotations.
+func changedKeys(a, b map[string]string) []string {
+	changed := []string{}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			changed = append(changed, key)
+		}
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
+
+// Check if an annotation is one interpreted by us.
+func isResmgrAnnotation(key string) bool {
+	if key == KeyResourceAnnotation {
+		return true
+	}
+	domain := strings.SplitN(key, "/", 2)[0]
+	return domain == kubernetes.ResmgrKeyNamespace ||
+		strings.HasSuffix(domain, "."+kubernetes.ResmgrKeyNamespace)
+}
+
+// Check if two sets of labels or annotations are the same.
+func sameMetadata(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
 // Get the init containers of a pod.
 func (p *pod) GetInitContainers() []Container {
 	if p.Resources == nil {
@@ -288,6 +360,11 @@ func (p *pod) GetResmgrAnnotationObject(key string, objPtr interface{},
 
 // Get the effective annotation for the container.
 func (p *pod) GetEffectiveAnnotation(key, container string) (string, bool) {
+	if c := p.getContainer(container); c != nil {
+		if v, ok := c.getAdjustedAnnotation(key); ok {
+			return v, true
+		}
+	}
 	if v, ok := p.Annotations[key+"/container."+container]; ok {
 		return v, true
 	}
@@ -361,9 +438,31 @@ func (p *pod) GetPodResourceRequirements() PodResourceRequirements {
 }
 
 // Parse per container resource requirements from webhook annotations.
-func (p *pod) parseResourceAnnotations() {
+// Without annotations, use the given resources, or if there are none,
+// try looking them up from the node agent instead.
+func (p *pod) parseResourceAnnotations(resources *PodResourceRequirements) {
 	p.Resources = &PodResourceRequirements{}
-	p.GetAnnotationObject(KeyResourceAnnotation, p.Resources, nil)
+	ok, _ := p.GetAnnotationObject(KeyResourceAnnotation, p.Resources, nil)
+	if ok {
+		return
+	}
+	if resources != nil {
+		p.Resources = resources
+		return
+	}
+	if p.cache.podResources == nil {
+		return
+	}
+
+	resources, err := p.cache.podResources(p.Namespace, p.Name, p.UID)
+	if err != nil {
+		p.cache.Warn("failed to look up resource requirements of pod %s/%s: %v",
+			p.Namespace, p.Name, err)
+		return
+	}
+	if resources != nil {
+		p.Resources = resources
+	}
 }
 
 // Determine the QoS class of the pod.
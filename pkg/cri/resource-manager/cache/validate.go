@@ -0,0 +1,166 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	resapi "k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/kubernetes"
+)
+
+// AnnotationValidator checks the value of an annotation for errors.
+type AnnotationValidator func(value string) error
+
+// ValidatePodAnnotations checks the annotations of a pod interpreted by the cache.
+// The pod is given by its name, annotations and the names of all its containers.
+// This allows detecting errors in annotations before the pod reaches any node.
+func ValidatePodAnnotations(name string, annotations map[string]string, containers []string) error {
+	validators := map[string]AnnotationValidator{
+		RDTClassKey:      validateClass,
+		BlockIOClassKey:  validateClass,
+		ToptierLimitKey:  validateQuantity,
+		TopologyHintsKey: validateBool,
+	}
+
+	if err := ValidateEffectiveAnnotations(annotations, containers, validators); err != nil {
+		return err
+	}
+
+	return validateAffinityAnnotations(name, annotations, containers)
+}
+
+// ValidateEffectiveAnnotations checks effective annotations with the given
+// validators. Annotations are checked in the order of their keys, so that the
+// same pod always fails with the same error.
+func ValidateEffectiveAnnotations(annotations map[string]string, containers []string,
+	validators map[string]AnnotationValidator) error {
+	keys := make([]string, 0, len(validators))
+	for key := range validators {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := ValidateEffectiveAnnotation(annotations, containers, key, validators[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateEffectiveAnnotation checks all pod- and container-specific forms of an
+// effective annotation, including that any container it refers to exists.
+func ValidateEffectiveAnnotation(annotations map[string]string, containers []string,
+	key string, validate AnnotationValidator) error {
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if k != key && !strings.HasPrefix(k, key+"/") {
+			continue
+		}
+
+		switch suffix := strings.TrimPrefix(k, key); {
+		case suffix == "", suffix == "/pod":
+		case strings.HasPrefix(suffix, "/container."):
+			name := strings.TrimPrefix(suffix, "/container.")
+			if !hasContainer(containers, name) {
+				return cacheError("annotation %q: pod has no container %q", k, name)
+			}
+		default:
+			return cacheError("annotation %q: invalid suffix %q, expecting %q or %q",
+				k, suffix, "/pod", "/container.<name>")
+		}
+
+		if err := validate(annotations[k]); err != nil {
+			return cacheError("annotation %q: invalid value %q: %v", k, annotations[k], err)
+		}
+	}
+
+	return nil
+}
+
+// validateAffinityAnnotations checks the affinity and anti-affinity annotations of a pod.
+func validateAffinityAnnotations(name string, annotations map[string]string, containers []string) error {
+	p := &pod{Name: name, Annotations: annotations}
+
+	for _, a := range []struct {
+		key    string
+		weight int32
+	}{
+		{keyAffinity, DefaultWeight},
+		{keyAntiAffinity, -DefaultWeight},
+	} {
+		key, weight := a.key, a.weight
+		value, ok := p.GetResmgrAnnotation(key)
+		if !ok {
+			continue
+		}
+
+		pca := podContainerAffinity{}
+		if !pca.parseSimple(p, value, weight) {
+			if err := pca.parseFull(p, value, weight); err != nil {
+				return cacheError("annotation %q: %v", kubernetes.ResmgrKey(key), err)
+			}
+		}
+
+		for container := range pca {
+			if !hasContainer(containers, container) {
+				return cacheError("annotation %q: pod has no container %q",
+					kubernetes.ResmgrKey(key), container)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateClass checks an RDT or Block I/O class name.
+func validateClass(value string) error {
+	if value == "" {
+		return cacheError("empty class name")
+	}
+	return nil
+}
+
+// validateQuantity checks a resource quantity.
+func validateQuantity(value string) error {
+	_, err := resapi.ParseQuantity(value)
+	return err
+}
+
+// validateBool checks a boolean.
+func validateBool(value string) error {
+	_, err := strconv.ParseBool(value)
+	return err
+}
+
+// hasContainer checks if a container is among the given ones.
+func hasContainer(containers []string, name string) bool {
+	for _, c := range containers {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
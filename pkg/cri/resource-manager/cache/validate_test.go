@@ -0,0 +1,145 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/kubernetes"
+)
+
+func TestValidatePodAnnotations(t *testing.T) {
+	containers := []string{"c0", "c1", "c2"}
+	tcases := []struct {
+		name        string
+		annotations map[string]string
+		expectError bool
+	}{
+		{
+			name: "valid annotations",
+			annotations: map[string]string{
+				RDTClassKey + "/pod":                  "gold",
+				BlockIOClassKey + "/container.c0":     "slowreader",
+				ToptierLimitKey + "/container.c1":     "2Gi",
+				TopologyHintsKey + "/container.c2":    "false",
+				kubernetes.ResmgrKey(keyAffinity):     "c0: [ c1 ]",
+				kubernetes.ResmgrKey(keyAntiAffinity): "c2: [ c0, c1 ]",
+			},
+		},
+		{
+			name: "valid full affinity",
+			annotations: map[string]string{
+				kubernetes.ResmgrKey(keyAffinity): `
+c0:
+  - match:
+      key: name
+      operator: In
+      values:
+        - c1
+        - c2
+    weight: 10
+`,
+			},
+		},
+		{
+			name: "empty RDT class",
+			annotations: map[string]string{
+				RDTClassKey + "/container.c0": "",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid top tier limit",
+			annotations: map[string]string{
+				ToptierLimitKey: "lots",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid annotation suffix",
+			annotations: map[string]string{
+				BlockIOClassKey + "/containers.c0": "slowreader",
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown container",
+			annotations: map[string]string{
+				RDTClassKey + "/container.c3": "gold",
+			},
+			expectError: true,
+		},
+		{
+			name: "malformed affinity",
+			annotations: map[string]string{
+				kubernetes.ResmgrKey(keyAffinity): "c0: { match: [ c1 ] }",
+			},
+			expectError: true,
+		},
+		{
+			name: "affinity for unknown container",
+			annotations: map[string]string{
+				kubernetes.ResmgrKey(keyAntiAffinity): "c3: [ c0 ]",
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePodAnnotations("pod", tc.annotations, containers)
+			if tc.expectError && err == nil {
+				t.Errorf("expected error, got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidatePodAnnotationsOrder(t *testing.T) {
+	containers := []string{"c0"}
+	annotations := map[string]string{
+		BlockIOClassKey:                       "",
+		RDTClassKey:                           "",
+		ToptierLimitKey:                       "lots",
+		TopologyHintsKey:                      "maybe",
+		kubernetes.ResmgrKey(keyAffinity):     "c1: [ c0 ]",
+		kubernetes.ResmgrKey(keyAntiAffinity): "c2: [ c0 ]",
+	}
+
+	expected := ValidatePodAnnotations("pod", annotations, containers)
+	if expected == nil {
+		t.Fatalf("expected error, got none")
+	}
+	if !strings.Contains(expected.Error(), BlockIOClassKey) {
+		t.Errorf("expected error for the first annotation %q, got %v", BlockIOClassKey, expected)
+	}
+	for i := 0; i < 32; i++ {
+		if err := ValidatePodAnnotations("pod", annotations, containers); err.Error() != expected.Error() {
+			t.Fatalf("expected the same error %q, got %q", expected, err)
+		}
+	}
+
+	delete(annotations, BlockIOClassKey)
+	delete(annotations, RDTClassKey)
+	delete(annotations, ToptierLimitKey)
+	delete(annotations, TopologyHintsKey)
+	err := ValidatePodAnnotations("pod", annotations, containers)
+	if err == nil || !strings.Contains(err.Error(), kubernetes.ResmgrKey(keyAffinity)+`"`) {
+		t.Errorf("expected error for affinity annotation, got %v", err)
+	}
+}
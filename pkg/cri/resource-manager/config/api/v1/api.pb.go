@@ -202,6 +202,812 @@ func (m *SetAdjustmentReply) GetErrors() map[string]string {
 	return nil
 }
 
+type DryRunRequest struct {
+	// node_name is node name used to acquire this configuration.
+	NodeName string `protobuf:"bytes,1,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// config is the ConfigMap data to check.
+	Config               map[string]string `protobuf:"bytes,2,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DryRunRequest) Reset()         { *m = DryRunRequest{} }
+func (m *DryRunRequest) String() string { return proto.CompactTextString(m) }
+func (*DryRunRequest) ProtoMessage()    {}
+func (*DryRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{4}
+}
+
+func (m *DryRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DryRunRequest.Unmarshal(m, b)
+}
+func (m *DryRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DryRunRequest.Marshal(b, m, deterministic)
+}
+func (m *DryRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DryRunRequest.Merge(m, src)
+}
+func (m *DryRunRequest) XXX_Size() int {
+	return xxx_messageInfo_DryRunRequest.Size(m)
+}
+func (m *DryRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DryRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DryRunRequest proto.InternalMessageInfo
+
+func (m *DryRunRequest) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+func (m *DryRunRequest) GetConfig() map[string]string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+type DryRunReply struct {
+	// diff is the per-module diff of effective values, module path as key.
+	Diff map[string]*ConfigModuleDiff `protobuf:"bytes,1,rep,name=diff,proto3" json:"diff,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// errors are the validation errors found in the configuration, if any.
+	Errors               []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DryRunReply) Reset()         { *m = DryRunReply{} }
+func (m *DryRunReply) String() string { return proto.CompactTextString(m) }
+func (*DryRunReply) ProtoMessage()    {}
+func (*DryRunReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{5}
+}
+
+func (m *DryRunReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DryRunReply.Unmarshal(m, b)
+}
+func (m *DryRunReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DryRunReply.Marshal(b, m, deterministic)
+}
+func (m *DryRunReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DryRunReply.Merge(m, src)
+}
+func (m *DryRunReply) XXX_Size() int {
+	return xxx_messageInfo_DryRunReply.Size(m)
+}
+func (m *DryRunReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DryRunReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DryRunReply proto.InternalMessageInfo
+
+func (m *DryRunReply) GetDiff() map[string]*ConfigModuleDiff {
+	if m != nil {
+		return m.Diff
+	}
+	return nil
+}
+
+func (m *DryRunReply) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type ConfigModuleDiff struct {
+	// changes are the changed effective values of the module.
+	Changes              []*ConfigFieldChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConfigModuleDiff) Reset()         { *m = ConfigModuleDiff{} }
+func (m *ConfigModuleDiff) String() string { return proto.CompactTextString(m) }
+func (*ConfigModuleDiff) ProtoMessage()    {}
+func (*ConfigModuleDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{6}
+}
+
+func (m *ConfigModuleDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigModuleDiff.Unmarshal(m, b)
+}
+func (m *ConfigModuleDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigModuleDiff.Marshal(b, m, deterministic)
+}
+func (m *ConfigModuleDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigModuleDiff.Merge(m, src)
+}
+func (m *ConfigModuleDiff) XXX_Size() int {
+	return xxx_messageInfo_ConfigModuleDiff.Size(m)
+}
+func (m *ConfigModuleDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigModuleDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigModuleDiff proto.InternalMessageInfo
+
+func (m *ConfigModuleDiff) GetChanges() []*ConfigFieldChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type ConfigFieldChange struct {
+	// field is the path of the field within its module, in dotted notation.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// old is the current effective value, JSON-encoded.
+	Old string `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	// new is the effective value with the new configuration, JSON-encoded.
+	New                  string   `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigFieldChange) Reset()         { *m = ConfigFieldChange{} }
+func (m *ConfigFieldChange) String() string { return proto.CompactTextString(m) }
+func (*ConfigFieldChange) ProtoMessage()    {}
+func (*ConfigFieldChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{7}
+}
+
+func (m *ConfigFieldChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigFieldChange.Unmarshal(m, b)
+}
+func (m *ConfigFieldChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigFieldChange.Marshal(b, m, deterministic)
+}
+func (m *ConfigFieldChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigFieldChange.Merge(m, src)
+}
+func (m *ConfigFieldChange) XXX_Size() int {
+	return xxx_messageInfo_ConfigFieldChange.Size(m)
+}
+func (m *ConfigFieldChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigFieldChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigFieldChange proto.InternalMessageInfo
+
+func (m *ConfigFieldChange) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *ConfigFieldChange) GetOld() string {
+	if m != nil {
+		return m.Old
+	}
+	return ""
+}
+
+func (m *ConfigFieldChange) GetNew() string {
+	if m != nil {
+		return m.New
+	}
+	return ""
+}
+
+type ConfigVersion struct {
+	// version is the sequence number of this configuration in the history.
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// source describes where the configuration came from.
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// timestamp is the time the configuration was applied, in Unix nanoseconds.
+	Timestamp int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// checksum is the SHA256 checksum of the configuration data.
+	Checksum string `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// node_name is node name used to acquire this configuration.
+	NodeName string `protobuf:"bytes,5,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// config is the ConfigMap data, omitted in history listings unless requested.
+	Config               map[string]string `protobuf:"bytes,6,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConfigVersion) Reset()         { *m = ConfigVersion{} }
+func (m *ConfigVersion) String() string { return proto.CompactTextString(m) }
+func (*ConfigVersion) ProtoMessage()    {}
+func (*ConfigVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{8}
+}
+
+func (m *ConfigVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigVersion.Unmarshal(m, b)
+}
+func (m *ConfigVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigVersion.Marshal(b, m, deterministic)
+}
+func (m *ConfigVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigVersion.Merge(m, src)
+}
+func (m *ConfigVersion) XXX_Size() int {
+	return xxx_messageInfo_ConfigVersion.Size(m)
+}
+func (m *ConfigVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigVersion proto.InternalMessageInfo
+
+func (m *ConfigVersion) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ConfigVersion) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ConfigVersion) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ConfigVersion) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+func (m *ConfigVersion) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+func (m *ConfigVersion) GetConfig() map[string]string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+type GetCurrentConfigRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetCurrentConfigRequest) Reset()         { *m = GetCurrentConfigRequest{} }
+func (m *GetCurrentConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCurrentConfigRequest) ProtoMessage()    {}
+func (*GetCurrentConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{9}
+}
+
+func (m *GetCurrentConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCurrentConfigRequest.Unmarshal(m, b)
+}
+func (m *GetCurrentConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCurrentConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *GetCurrentConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCurrentConfigRequest.Merge(m, src)
+}
+func (m *GetCurrentConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_GetCurrentConfigRequest.Size(m)
+}
+func (m *GetCurrentConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCurrentConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCurrentConfigRequest proto.InternalMessageInfo
+
+type GetCurrentConfigReply struct {
+	// current is the active configuration, unset if none has been recorded.
+	Current              *ConfigVersion `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetCurrentConfigReply) Reset()         { *m = GetCurrentConfigReply{} }
+func (m *GetCurrentConfigReply) String() string { return proto.CompactTextString(m) }
+func (*GetCurrentConfigReply) ProtoMessage()    {}
+func (*GetCurrentConfigReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{10}
+}
+
+func (m *GetCurrentConfigReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetCurrentConfigReply.Unmarshal(m, b)
+}
+func (m *GetCurrentConfigReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetCurrentConfigReply.Marshal(b, m, deterministic)
+}
+func (m *GetCurrentConfigReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCurrentConfigReply.Merge(m, src)
+}
+func (m *GetCurrentConfigReply) XXX_Size() int {
+	return xxx_messageInfo_GetCurrentConfigReply.Size(m)
+}
+func (m *GetCurrentConfigReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCurrentConfigReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCurrentConfigReply proto.InternalMessageInfo
+
+func (m *GetCurrentConfigReply) GetCurrent() *ConfigVersion {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+type GetEffectiveConfigRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEffectiveConfigRequest) Reset()         { *m = GetEffectiveConfigRequest{} }
+func (m *GetEffectiveConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEffectiveConfigRequest) ProtoMessage()    {}
+func (*GetEffectiveConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{11}
+}
+
+func (m *GetEffectiveConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEffectiveConfigRequest.Unmarshal(m, b)
+}
+func (m *GetEffectiveConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEffectiveConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEffectiveConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEffectiveConfigRequest.Merge(m, src)
+}
+func (m *GetEffectiveConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEffectiveConfigRequest.Size(m)
+}
+func (m *GetEffectiveConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEffectiveConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEffectiveConfigRequest proto.InternalMessageInfo
+
+type GetEffectiveConfigReply struct {
+	// config is the effective configuration, including defaults, per module.
+	Config map[string]string `protobuf:"bytes,1,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If not empty, indicates an error that happened while collecting the configuration.
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEffectiveConfigReply) Reset()         { *m = GetEffectiveConfigReply{} }
+func (m *GetEffectiveConfigReply) String() string { return proto.CompactTextString(m) }
+func (*GetEffectiveConfigReply) ProtoMessage()    {}
+func (*GetEffectiveConfigReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{12}
+}
+
+func (m *GetEffectiveConfigReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEffectiveConfigReply.Unmarshal(m, b)
+}
+func (m *GetEffectiveConfigReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEffectiveConfigReply.Marshal(b, m, deterministic)
+}
+func (m *GetEffectiveConfigReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEffectiveConfigReply.Merge(m, src)
+}
+func (m *GetEffectiveConfigReply) XXX_Size() int {
+	return xxx_messageInfo_GetEffectiveConfigReply.Size(m)
+}
+func (m *GetEffectiveConfigReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEffectiveConfigReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEffectiveConfigReply proto.InternalMessageInfo
+
+func (m *GetEffectiveConfigReply) GetConfig() map[string]string {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *GetEffectiveConfigReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ListConfigHistoryRequest struct {
+	// with_data requests the ConfigMap data to be included for each version.
+	WithData             bool     `protobuf:"varint,1,opt,name=with_data,json=withData,proto3" json:"with_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListConfigHistoryRequest) Reset()         { *m = ListConfigHistoryRequest{} }
+func (m *ListConfigHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ListConfigHistoryRequest) ProtoMessage()    {}
+func (*ListConfigHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{13}
+}
+
+func (m *ListConfigHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListConfigHistoryRequest.Unmarshal(m, b)
+}
+func (m *ListConfigHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListConfigHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *ListConfigHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConfigHistoryRequest.Merge(m, src)
+}
+func (m *ListConfigHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_ListConfigHistoryRequest.Size(m)
+}
+func (m *ListConfigHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConfigHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConfigHistoryRequest proto.InternalMessageInfo
+
+func (m *ListConfigHistoryRequest) GetWithData() bool {
+	if m != nil {
+		return m.WithData
+	}
+	return false
+}
+
+type ListConfigHistoryReply struct {
+	// history is the configuration history, oldest version first.
+	History              []*ConfigVersion `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListConfigHistoryReply) Reset()         { *m = ListConfigHistoryReply{} }
+func (m *ListConfigHistoryReply) String() string { return proto.CompactTextString(m) }
+func (*ListConfigHistoryReply) ProtoMessage()    {}
+func (*ListConfigHistoryReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{14}
+}
+
+func (m *ListConfigHistoryReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListConfigHistoryReply.Unmarshal(m, b)
+}
+func (m *ListConfigHistoryReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListConfigHistoryReply.Marshal(b, m, deterministic)
+}
+func (m *ListConfigHistoryReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListConfigHistoryReply.Merge(m, src)
+}
+func (m *ListConfigHistoryReply) XXX_Size() int {
+	return xxx_messageInfo_ListConfigHistoryReply.Size(m)
+}
+func (m *ListConfigHistoryReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListConfigHistoryReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListConfigHistoryReply proto.InternalMessageInfo
+
+func (m *ListConfigHistoryReply) GetHistory() []*ConfigVersion {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+type RollbackConfigRequest struct {
+	// version is the version in the history to roll back to.
+	Version              uint64   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackConfigRequest) Reset()         { *m = RollbackConfigRequest{} }
+func (m *RollbackConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackConfigRequest) ProtoMessage()    {}
+func (*RollbackConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{15}
+}
+
+func (m *RollbackConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackConfigRequest.Unmarshal(m, b)
+}
+func (m *RollbackConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *RollbackConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackConfigRequest.Merge(m, src)
+}
+func (m *RollbackConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_RollbackConfigRequest.Size(m)
+}
+func (m *RollbackConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackConfigRequest proto.InternalMessageInfo
+
+func (m *RollbackConfigRequest) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type RollbackConfigReply struct {
+	// current is the configuration version created by the rollback.
+	Current *ConfigVersion `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	// If not empty, indicates an error that happened while trying to roll back.
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollbackConfigReply) Reset()         { *m = RollbackConfigReply{} }
+func (m *RollbackConfigReply) String() string { return proto.CompactTextString(m) }
+func (*RollbackConfigReply) ProtoMessage()    {}
+func (*RollbackConfigReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{16}
+}
+
+func (m *RollbackConfigReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackConfigReply.Unmarshal(m, b)
+}
+func (m *RollbackConfigReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackConfigReply.Marshal(b, m, deterministic)
+}
+func (m *RollbackConfigReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackConfigReply.Merge(m, src)
+}
+func (m *RollbackConfigReply) XXX_Size() int {
+	return xxx_messageInfo_RollbackConfigReply.Size(m)
+}
+func (m *RollbackConfigReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackConfigReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollbackConfigReply proto.InternalMessageInfo
+
+func (m *RollbackConfigReply) GetCurrent() *ConfigVersion {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+func (m *RollbackConfigReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type UpdatePodRequest struct {
+	// namespace is the namespace of the pod.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// name is the name of the pod.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// uid is the UID of the pod.
+	Uid string `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	// labels are the current labels of the pod.
+	Labels map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// annotations are the current annotations of the pod.
+	Annotations          map[string]string `protobuf:"bytes,5,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *UpdatePodRequest) Reset()         { *m = UpdatePodRequest{} }
+func (m *UpdatePodRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdatePodRequest) ProtoMessage()    {}
+func (*UpdatePodRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{17}
+}
+
+func (m *UpdatePodRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePodRequest.Unmarshal(m, b)
+}
+func (m *UpdatePodRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePodRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdatePodRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePodRequest.Merge(m, src)
+}
+func (m *UpdatePodRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdatePodRequest.Size(m)
+}
+func (m *UpdatePodRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePodRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePodRequest proto.InternalMessageInfo
+
+func (m *UpdatePodRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *UpdatePodRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdatePodRequest) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *UpdatePodRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *UpdatePodRequest) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+type UpdatePodReply struct {
+	// If not empty, indicates an error that happened while updating the pod.
+	Error                string   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdatePodReply) Reset()         { *m = UpdatePodReply{} }
+func (m *UpdatePodReply) String() string { return proto.CompactTextString(m) }
+func (*UpdatePodReply) ProtoMessage()    {}
+func (*UpdatePodReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{18}
+}
+
+func (m *UpdatePodReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePodReply.Unmarshal(m, b)
+}
+func (m *UpdatePodReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePodReply.Marshal(b, m, deterministic)
+}
+func (m *UpdatePodReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePodReply.Merge(m, src)
+}
+func (m *UpdatePodReply) XXX_Size() int {
+	return xxx_messageInfo_UpdatePodReply.Size(m)
+}
+func (m *UpdatePodReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePodReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePodReply proto.InternalMessageInfo
+
+func (m *UpdatePodReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type GetDiagnosticsRequest struct {
+	// redact requests potentially sensitive data to be left out of the diagnostics.
+	Redact               bool     `protobuf:"varint,1,opt,name=redact,proto3" json:"redact,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDiagnosticsRequest) Reset()         { *m = GetDiagnosticsRequest{} }
+func (m *GetDiagnosticsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDiagnosticsRequest) ProtoMessage()    {}
+func (*GetDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{19}
+}
+
+func (m *GetDiagnosticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDiagnosticsRequest.Unmarshal(m, b)
+}
+func (m *GetDiagnosticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDiagnosticsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDiagnosticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDiagnosticsRequest.Merge(m, src)
+}
+func (m *GetDiagnosticsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDiagnosticsRequest.Size(m)
+}
+func (m *GetDiagnosticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDiagnosticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDiagnosticsRequest proto.InternalMessageInfo
+
+func (m *GetDiagnosticsRequest) GetRedact() bool {
+	if m != nil {
+		return m.Redact
+	}
+	return false
+}
+
+type GetDiagnosticsReply struct {
+	// bundle is the collected diagnostics, as a gzipped tarball.
+	Bundle []byte `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// If not empty, indicates an error that happened while collecting diagnostics.
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDiagnosticsReply) Reset()         { *m = GetDiagnosticsReply{} }
+func (m *GetDiagnosticsReply) String() string { return proto.CompactTextString(m) }
+func (*GetDiagnosticsReply) ProtoMessage()    {}
+func (*GetDiagnosticsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d9bc9cf5b527561, []int{20}
+}
+
+func (m *GetDiagnosticsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDiagnosticsReply.Unmarshal(m, b)
+}
+func (m *GetDiagnosticsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDiagnosticsReply.Marshal(b, m, deterministic)
+}
+func (m *GetDiagnosticsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDiagnosticsReply.Merge(m, src)
+}
+func (m *GetDiagnosticsReply) XXX_Size() int {
+	return xxx_messageInfo_GetDiagnosticsReply.Size(m)
+}
+func (m *GetDiagnosticsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDiagnosticsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDiagnosticsReply proto.InternalMessageInfo
+
+func (m *GetDiagnosticsReply) GetBundle() []byte {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+func (m *GetDiagnosticsReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*SetConfigRequest)(nil), "v1.SetConfigRequest")
 	proto.RegisterMapType((map[string]string)(nil), "v1.SetConfigRequest.ConfigEntry")
@@ -209,6 +1015,29 @@ func init() {
 	proto.RegisterType((*SetAdjustmentRequest)(nil), "v1.SetAdjustmentRequest")
 	proto.RegisterType((*SetAdjustmentReply)(nil), "v1.SetAdjustmentReply")
 	proto.RegisterMapType((map[string]string)(nil), "v1.SetAdjustmentReply.ErrorsEntry")
+	proto.RegisterType((*DryRunRequest)(nil), "v1.DryRunRequest")
+	proto.RegisterMapType((map[string]string)(nil), "v1.DryRunRequest.ConfigEntry")
+	proto.RegisterType((*DryRunReply)(nil), "v1.DryRunReply")
+	proto.RegisterMapType((map[string]*ConfigModuleDiff)(nil), "v1.DryRunReply.DiffEntry")
+	proto.RegisterType((*ConfigModuleDiff)(nil), "v1.ConfigModuleDiff")
+	proto.RegisterType((*ConfigFieldChange)(nil), "v1.ConfigFieldChange")
+	proto.RegisterType((*ConfigVersion)(nil), "v1.ConfigVersion")
+	proto.RegisterMapType((map[string]string)(nil), "v1.ConfigVersion.ConfigEntry")
+	proto.RegisterType((*GetCurrentConfigRequest)(nil), "v1.GetCurrentConfigRequest")
+	proto.RegisterType((*GetCurrentConfigReply)(nil), "v1.GetCurrentConfigReply")
+	proto.RegisterType((*GetEffectiveConfigRequest)(nil), "v1.GetEffectiveConfigRequest")
+	proto.RegisterType((*GetEffectiveConfigReply)(nil), "v1.GetEffectiveConfigReply")
+	proto.RegisterMapType((map[string]string)(nil), "v1.GetEffectiveConfigReply.ConfigEntry")
+	proto.RegisterType((*ListConfigHistoryRequest)(nil), "v1.ListConfigHistoryRequest")
+	proto.RegisterType((*ListConfigHistoryReply)(nil), "v1.ListConfigHistoryReply")
+	proto.RegisterType((*RollbackConfigRequest)(nil), "v1.RollbackConfigRequest")
+	proto.RegisterType((*RollbackConfigReply)(nil), "v1.RollbackConfigReply")
+	proto.RegisterType((*UpdatePodRequest)(nil), "v1.UpdatePodRequest")
+	proto.RegisterMapType((map[string]string)(nil), "v1.UpdatePodRequest.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "v1.UpdatePodRequest.LabelsEntry")
+	proto.RegisterType((*UpdatePodReply)(nil), "v1.UpdatePodReply")
+	proto.RegisterType((*GetDiagnosticsRequest)(nil), "v1.GetDiagnosticsRequest")
+	proto.RegisterType((*GetDiagnosticsReply)(nil), "v1.GetDiagnosticsReply")
 }
 
 func init() {
@@ -216,28 +1045,68 @@ func init() {
 }
 
 var fileDescriptor_2d9bc9cf5b527561 = []byte{
-	// 326 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xc1, 0x4e, 0xc2, 0x40,
-	0x10, 0x86, 0xd9, 0xa2, 0x8d, 0x0c, 0xd1, 0x90, 0x0d, 0x31, 0x0d, 0x26, 0x86, 0xec, 0xc1, 0x70,
-	0xb1, 0xb5, 0x78, 0x10, 0xb9, 0x29, 0xe1, 0xea, 0x01, 0x6e, 0x5e, 0xcc, 0x0a, 0x23, 0x41, 0xe8,
-	0x6e, 0xdd, 0x6e, 0x9b, 0xf0, 0x02, 0x5e, 0x7c, 0x0d, 0x1f, 0xd4, 0x74, 0x77, 0x43, 0x6a, 0xc3,
-	0x41, 0x4f, 0xed, 0xfc, 0xf9, 0xe7, 0x9f, 0x99, 0xaf, 0x85, 0x9b, 0x74, 0xb3, 0x8a, 0x16, 0x6a,
-	0x1d, 0x29, 0xcc, 0x64, 0xae, 0x16, 0x78, 0x9d, 0x70, 0xc1, 0x57, 0xa8, 0xa2, 0x85, 0x14, 0x6f,
-	0xeb, 0x55, 0xc4, 0xd3, 0x75, 0x54, 0xc4, 0xe5, 0x23, 0x4c, 0x95, 0xd4, 0x92, 0x7a, 0x45, 0xcc,
-	0xbe, 0x09, 0x74, 0xe6, 0xa8, 0x27, 0xc6, 0x32, 0xc3, 0x8f, 0x1c, 0x33, 0x4d, 0x2f, 0xa0, 0x25,
-	0xe4, 0x12, 0x5f, 0x04, 0x4f, 0x30, 0x20, 0x7d, 0x32, 0x68, 0xcd, 0x4e, 0x4a, 0xe1, 0x89, 0x27,
-	0x48, 0x47, 0xe0, 0xdb, 0xc0, 0xc0, 0xeb, 0x37, 0x07, 0xed, 0x61, 0x3f, 0x2c, 0xe2, 0xb0, 0x1e,
-	0x11, 0xda, 0x6a, 0x2a, 0xb4, 0xda, 0xcd, 0x9c, 0xbf, 0x77, 0x0f, 0xed, 0x8a, 0x4c, 0x3b, 0xd0,
-	0xdc, 0xe0, 0xce, 0xe5, 0x97, 0xaf, 0xb4, 0x0b, 0xc7, 0x05, 0xdf, 0xe6, 0x18, 0x78, 0x46, 0xb3,
-	0xc5, 0xd8, 0x1b, 0x11, 0x76, 0x05, 0x67, 0x95, 0x11, 0xe9, 0xd6, 0x78, 0x51, 0x29, 0xa9, 0x5c,
-	0xbf, 0x2d, 0xd8, 0x1c, 0xba, 0x73, 0xd4, 0x0f, 0xcb, 0xf7, 0x3c, 0xd3, 0x09, 0x0a, 0xfd, 0xa7,
-	0x8b, 0x2e, 0x01, 0xf8, 0xbe, 0xc3, 0xcd, 0xae, 0x28, 0xec, 0x8b, 0x00, 0xad, 0xa5, 0x96, 0x1b,
-	0x8c, 0xc1, 0x37, 0x43, 0xb3, 0x80, 0x18, 0x10, 0xcc, 0x81, 0xa8, 0xf9, 0xc2, 0xa9, 0x31, 0x39,
-	0x14, 0xb6, 0xa3, 0x44, 0x51, 0x91, 0xff, 0x83, 0x62, 0xf8, 0x49, 0xc0, 0xb7, 0x20, 0xe8, 0x1d,
-	0xb4, 0xf6, 0x54, 0x68, 0xf7, 0xd0, 0x77, 0xe8, 0xd1, 0x9a, 0x9a, 0x6e, 0x77, 0xac, 0x41, 0x27,
-	0x70, 0xfa, 0x6b, 0x51, 0x1a, 0x1c, 0xd8, 0xdd, 0x06, 0x9c, 0x1f, 0xbe, 0x8a, 0x35, 0x1e, 0x8f,
-	0x9e, 0xbd, 0x22, 0x7e, 0xf5, 0xcd, 0xbf, 0x74, 0xfb, 0x13, 0x00, 0x00, 0xff, 0xff, 0xc5, 0x0c,
-	0xf3, 0xeb, 0x7f, 0x02, 0x00, 0x00,
+	// 973 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5f, 0x6f, 0xe3, 0x44,
+	0x10, 0xaf, 0x93, 0x34, 0xad, 0x27, 0xf4, 0x48, 0xf7, 0xda, 0xd4, 0x75, 0x7b, 0xa8, 0x5a, 0x09,
+	0x38, 0x81, 0x2e, 0x21, 0x45, 0xa8, 0xc7, 0x3d, 0x80, 0x8e, 0x24, 0x57, 0x1e, 0xae, 0x80, 0x5c,
+	0x81, 0x10, 0x2f, 0xa7, 0xad, 0xbd, 0x49, 0x4d, 0x9d, 0x75, 0xb0, 0xd7, 0xa9, 0xf2, 0x15, 0xe0,
+	0x3b, 0xf0, 0x02, 0x6f, 0x7c, 0x1d, 0xbe, 0x05, 0x5f, 0x02, 0xed, 0x1f, 0x3b, 0xb6, 0xe3, 0xa0,
+	0x8b, 0xe8, 0x53, 0x32, 0xb3, 0x33, 0xbf, 0x99, 0xfd, 0xcd, 0xec, 0x8c, 0xe1, 0x93, 0xd9, 0xdd,
+	0xa4, 0xe7, 0x46, 0x7e, 0x2f, 0xa2, 0x71, 0x98, 0x44, 0x2e, 0x7d, 0x36, 0x25, 0x8c, 0x4c, 0x68,
+	0xd4, 0x73, 0x43, 0x36, 0xf6, 0x27, 0x3d, 0x32, 0xf3, 0x7b, 0xf3, 0xbe, 0xf8, 0xe9, 0xce, 0xa2,
+	0x90, 0x87, 0xa8, 0x36, 0xef, 0xe3, 0x3f, 0x0d, 0x68, 0x5f, 0x53, 0x3e, 0x90, 0x26, 0x0e, 0xfd,
+	0x25, 0xa1, 0x31, 0x47, 0x27, 0x60, 0xb2, 0xd0, 0xa3, 0x6f, 0x18, 0x99, 0x52, 0xcb, 0x38, 0x33,
+	0x9e, 0x9a, 0xce, 0xae, 0x50, 0x7c, 0x43, 0xa6, 0x14, 0x3d, 0x87, 0xa6, 0x02, 0xb4, 0x6a, 0x67,
+	0xf5, 0xa7, 0xad, 0xf3, 0xb3, 0xee, 0xbc, 0xdf, 0x2d, 0x43, 0x74, 0x95, 0x34, 0x62, 0x3c, 0x5a,
+	0x38, 0xda, 0xde, 0xfe, 0x1c, 0x5a, 0x39, 0x35, 0x6a, 0x43, 0xfd, 0x8e, 0x2e, 0x34, 0xbe, 0xf8,
+	0x8b, 0x0e, 0x60, 0x7b, 0x4e, 0x82, 0x84, 0x5a, 0x35, 0xa9, 0x53, 0xc2, 0x8b, 0xda, 0x73, 0x03,
+	0x7f, 0x00, 0x8f, 0x72, 0x21, 0x66, 0x81, 0xb4, 0xa5, 0x51, 0x14, 0x46, 0xda, 0x5f, 0x09, 0xf8,
+	0x1a, 0x0e, 0xae, 0x29, 0x7f, 0xe9, 0xfd, 0x9c, 0xc4, 0x7c, 0x4a, 0x19, 0x7f, 0xab, 0x1b, 0xbd,
+	0x07, 0x40, 0x32, 0x0f, 0x1d, 0x3b, 0xa7, 0xc1, 0xbf, 0x19, 0x80, 0x4a, 0xa8, 0x22, 0x83, 0x17,
+	0xd0, 0x94, 0x41, 0x63, 0xcb, 0x90, 0x44, 0x60, 0x4d, 0x44, 0xc9, 0xae, 0x3b, 0x92, 0x46, 0x9a,
+	0x0a, 0xe5, 0x21, 0xa8, 0xc8, 0xa9, 0x37, 0xa2, 0xe2, 0x77, 0x03, 0xf6, 0x86, 0xd1, 0xc2, 0x49,
+	0xd8, 0x5b, 0x5d, 0xee, 0xb3, 0x52, 0xb9, 0x9e, 0x88, 0x2c, 0x0b, 0xfe, 0x0f, 0x5d, 0xab, 0x3f,
+	0x0c, 0x68, 0xa5, 0x01, 0x04, 0x4f, 0xcf, 0xa0, 0xe1, 0xf9, 0xe3, 0xb1, 0x66, 0xe9, 0x38, 0x1f,
+	0x5f, 0xd0, 0x33, 0xf4, 0xc7, 0x63, 0x15, 0x5b, 0x9a, 0xa1, 0x4e, 0x46, 0xab, 0x48, 0xd8, 0xcc,
+	0x28, 0xbb, 0x02, 0x33, 0x33, 0xad, 0xc8, 0xe7, 0xa3, 0x7c, 0x3e, 0xad, 0xf3, 0x03, 0x11, 0x46,
+	0xdd, 0xe0, 0x2a, 0xf4, 0x92, 0x80, 0x0a, 0xdf, 0x7c, 0x96, 0x03, 0x68, 0x97, 0x8f, 0x51, 0x0f,
+	0x76, 0xdc, 0x5b, 0xc2, 0x26, 0x34, 0x2d, 0xe9, 0xe1, 0x12, 0xe5, 0x95, 0x4f, 0x03, 0x6f, 0x20,
+	0x4f, 0x9d, 0xd4, 0x0a, 0x5f, 0xc1, 0xfe, 0xca, 0xa9, 0x60, 0x66, 0x2c, 0xc4, 0xb4, 0x33, 0xa5,
+	0x20, 0x32, 0x0e, 0x03, 0x4f, 0xb3, 0x55, 0x0f, 0x95, 0x86, 0xd1, 0x7b, 0xab, 0xae, 0x34, 0x8c,
+	0xde, 0xe3, 0x5f, 0x6b, 0xb0, 0xa7, 0xf0, 0x7e, 0xa0, 0x51, 0xec, 0x87, 0x0c, 0x59, 0xb0, 0x33,
+	0x57, 0x7f, 0x25, 0x5a, 0xc3, 0x49, 0x45, 0x41, 0x93, 0x7a, 0xe6, 0x1a, 0x52, 0x4b, 0xe8, 0x14,
+	0x4c, 0xee, 0x4f, 0x69, 0xcc, 0xc9, 0x74, 0x26, 0xb1, 0xeb, 0xce, 0x52, 0x81, 0x6c, 0xd8, 0x75,
+	0x6f, 0xa9, 0x7b, 0x17, 0x27, 0x53, 0xab, 0xa1, 0x3a, 0x25, 0x95, 0x8b, 0x6d, 0xb4, 0xbd, 0xb6,
+	0x8d, 0x9a, 0xcb, 0x36, 0x2a, 0xe4, 0xfa, 0xd0, 0x6d, 0x74, 0x0c, 0x47, 0x97, 0x94, 0x0f, 0x92,
+	0x28, 0xa2, 0xac, 0x38, 0x5c, 0xf0, 0x10, 0x0e, 0x57, 0x8f, 0x44, 0xab, 0x7d, 0x0c, 0x3b, 0xae,
+	0xd2, 0xca, 0x18, 0xad, 0xf3, 0xfd, 0x95, 0x34, 0x9d, 0xd4, 0x02, 0x9f, 0xc0, 0xf1, 0x25, 0xe5,
+	0xa3, 0xf1, 0x98, 0xba, 0xdc, 0x9f, 0xd3, 0x62, 0x88, 0xbf, 0x0c, 0x38, 0xaa, 0x3a, 0x15, 0x51,
+	0xbe, 0xcc, 0xb8, 0x50, 0x5d, 0xf2, 0xa1, 0x08, 0xb2, 0xc6, 0xb8, 0x8a, 0x95, 0xe5, 0xec, 0xaa,
+	0xe5, 0x66, 0xd7, 0xff, 0xe1, 0xea, 0x02, 0xac, 0xd7, 0x7e, 0xac, 0xa9, 0xf8, 0xda, 0x8f, 0x79,
+	0x18, 0x2d, 0x72, 0xd3, 0xe1, 0xde, 0xe7, 0xb7, 0x6f, 0x3c, 0xc2, 0x89, 0x44, 0xdb, 0x75, 0x76,
+	0x85, 0x62, 0x48, 0x38, 0xc1, 0x23, 0xe8, 0x54, 0x38, 0x6a, 0x2a, 0x6f, 0x95, 0xac, 0x6f, 0x59,
+	0x45, 0xa5, 0xb6, 0xc0, 0x7d, 0x38, 0x74, 0xc2, 0x20, 0xb8, 0x21, 0xee, 0x5d, 0x71, 0x93, 0xac,
+	0xed, 0x5f, 0xfc, 0x23, 0x3c, 0x2e, 0xbb, 0x6c, 0x5a, 0xc1, 0x6a, 0x1e, 0xf1, 0xdf, 0x35, 0x68,
+	0x7f, 0x3f, 0xf3, 0x08, 0xa7, 0xdf, 0x85, 0x5e, 0x9a, 0xc8, 0x29, 0x98, 0xa2, 0xaf, 0xe3, 0x19,
+	0x71, 0xd3, 0x19, 0xb9, 0x54, 0x20, 0x04, 0x0d, 0x21, 0x68, 0x1c, 0xf9, 0x5f, 0xf0, 0x9f, 0xf8,
+	0x5e, 0xfa, 0x3c, 0x13, 0xdf, 0x13, 0x9b, 0x2f, 0x20, 0x37, 0x34, 0x88, 0xad, 0xc6, 0x72, 0xf3,
+	0x95, 0x23, 0x75, 0x5f, 0x4b, 0x13, 0x5d, 0x70, 0x65, 0x8f, 0x2e, 0xa1, 0x45, 0x18, 0x0b, 0x39,
+	0xe1, 0x7e, 0xc8, 0x62, 0x6b, 0x5b, 0xba, 0xbf, 0x5f, 0xe9, 0xfe, 0x72, 0x69, 0xa7, 0x30, 0xf2,
+	0x9e, 0xa2, 0x47, 0x72, 0xf8, 0x9b, 0xf4, 0x88, 0xfd, 0x05, 0xb4, 0xcb, 0xd8, 0x9b, 0xae, 0xe0,
+	0x5c, 0xb2, 0xeb, 0x57, 0x70, 0x4f, 0x3e, 0xce, 0xa1, 0x4f, 0x26, 0x2c, 0x8c, 0xb9, 0xef, 0xc6,
+	0x69, 0x09, 0x3a, 0xd0, 0x8c, 0xa8, 0x47, 0x5c, 0xae, 0xbb, 0x50, 0x4b, 0x78, 0x00, 0x8f, 0xcb,
+	0x0e, 0x02, 0xbd, 0x03, 0xcd, 0x9b, 0x84, 0x79, 0x81, 0x2a, 0xd7, 0x3b, 0x8e, 0x96, 0xaa, 0x8b,
+	0x7e, 0xfe, 0x4f, 0x03, 0x9a, 0xaa, 0x4b, 0xd0, 0x05, 0x98, 0xd9, 0xb7, 0x02, 0x3a, 0xa8, 0xfa,
+	0x3a, 0xb1, 0x51, 0x49, 0x3b, 0x0b, 0x16, 0x78, 0x0b, 0x0d, 0x60, 0xaf, 0xb0, 0xbe, 0x91, 0x55,
+	0xb1, 0xd1, 0x15, 0x40, 0xa7, 0x7a, 0xd7, 0xe3, 0x2d, 0xd4, 0x85, 0xa6, 0xda, 0x6e, 0x68, 0x7f,
+	0x65, 0xd3, 0xda, 0xef, 0x96, 0x96, 0x1f, 0xde, 0x42, 0x23, 0x30, 0x2f, 0xb3, 0x6c, 0x4f, 0xf4,
+	0x24, 0xa9, 0x9a, 0x7a, 0xf6, 0x71, 0xf5, 0xa1, 0x82, 0x71, 0x00, 0xad, 0x4e, 0x20, 0xf4, 0x64,
+	0xdd, 0x64, 0x52, 0x88, 0x27, 0xff, 0x31, 0xb8, 0xf0, 0x16, 0xfa, 0x16, 0xf6, 0x57, 0x86, 0x03,
+	0x3a, 0x15, 0x3e, 0xeb, 0x86, 0x8d, 0x6d, 0xaf, 0x39, 0x55, 0x80, 0xaf, 0xe0, 0x51, 0xf1, 0xcd,
+	0x23, 0x79, 0xa7, 0xca, 0xd1, 0x61, 0x1f, 0x55, 0x1d, 0x29, 0x9c, 0x0b, 0x30, 0xb3, 0x56, 0x54,
+	0x15, 0x2e, 0x3f, 0x23, 0x1b, 0x95, 0xb4, 0x59, 0x02, 0xc5, 0x56, 0x43, 0x29, 0xa9, 0xab, 0xfd,
+	0x6a, 0x1f, 0x55, 0x1d, 0x49, 0x9c, 0xaf, 0x1a, 0x3f, 0xd5, 0xe6, 0xfd, 0x9b, 0xa6, 0xfc, 0x8c,
+	0xfe, 0xf4, 0xdf, 0x01, 0x00, 0xcf, 0xec, 0xa1, 0x3a, 0x7a, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -254,6 +1123,13 @@ const _ = grpc.SupportPackageIsVersion4
 type ConfigClient interface {
 	SetConfig(ctx context.Context, in *SetConfigRequest, opts ...grpc.CallOption) (*SetConfigReply, error)
 	SetAdjustment(ctx context.Context, in *SetAdjustmentRequest, opts ...grpc.CallOption) (*SetAdjustmentReply, error)
+	DryRun(ctx context.Context, in *DryRunRequest, opts ...grpc.CallOption) (*DryRunReply, error)
+	GetConfig(ctx context.Context, in *GetCurrentConfigRequest, opts ...grpc.CallOption) (*GetCurrentConfigReply, error)
+	GetEffectiveConfig(ctx context.Context, in *GetEffectiveConfigRequest, opts ...grpc.CallOption) (*GetEffectiveConfigReply, error)
+	ListConfigHistory(ctx context.Context, in *ListConfigHistoryRequest, opts ...grpc.CallOption) (*ListConfigHistoryReply, error)
+	RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*RollbackConfigReply, error)
+	UpdatePod(ctx context.Context, in *UpdatePodRequest, opts ...grpc.CallOption) (*UpdatePodReply, error)
+	GetDiagnostics(ctx context.Context, in *GetDiagnosticsRequest, opts ...grpc.CallOption) (*GetDiagnosticsReply, error)
 }
 
 type configClient struct {
@@ -282,10 +1158,80 @@ func (c *configClient) SetAdjustment(ctx context.Context, in *SetAdjustmentReque
 	return out, nil
 }
 
+func (c *configClient) DryRun(ctx context.Context, in *DryRunRequest, opts ...grpc.CallOption) (*DryRunReply, error) {
+	out := new(DryRunReply)
+	err := c.cc.Invoke(ctx, "/v1.Config/DryRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) GetConfig(ctx context.Context, in *GetCurrentConfigRequest, opts ...grpc.CallOption) (*GetCurrentConfigReply, error) {
+	out := new(GetCurrentConfigReply)
+	err := c.cc.Invoke(ctx, "/v1.Config/GetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) GetEffectiveConfig(ctx context.Context, in *GetEffectiveConfigRequest, opts ...grpc.CallOption) (*GetEffectiveConfigReply, error) {
+	out := new(GetEffectiveConfigReply)
+	err := c.cc.Invoke(ctx, "/v1.Config/GetEffectiveConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) ListConfigHistory(ctx context.Context, in *ListConfigHistoryRequest, opts ...grpc.CallOption) (*ListConfigHistoryReply, error) {
+	out := new(ListConfigHistoryReply)
+	err := c.cc.Invoke(ctx, "/v1.Config/ListConfigHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*RollbackConfigReply, error) {
+	out := new(RollbackConfigReply)
+	err := c.cc.Invoke(ctx, "/v1.Config/RollbackConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) UpdatePod(ctx context.Context, in *UpdatePodRequest, opts ...grpc.CallOption) (*UpdatePodReply, error) {
+	out := new(UpdatePodReply)
+	err := c.cc.Invoke(ctx, "/v1.Config/UpdatePod", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configClient) GetDiagnostics(ctx context.Context, in *GetDiagnosticsRequest, opts ...grpc.CallOption) (*GetDiagnosticsReply, error) {
+	out := new(GetDiagnosticsReply)
+	err := c.cc.Invoke(ctx, "/v1.Config/GetDiagnostics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConfigServer is the server API for Config service.
 type ConfigServer interface {
 	SetConfig(context.Context, *SetConfigRequest) (*SetConfigReply, error)
 	SetAdjustment(context.Context, *SetAdjustmentRequest) (*SetAdjustmentReply, error)
+	DryRun(context.Context, *DryRunRequest) (*DryRunReply, error)
+	GetConfig(context.Context, *GetCurrentConfigRequest) (*GetCurrentConfigReply, error)
+	GetEffectiveConfig(context.Context, *GetEffectiveConfigRequest) (*GetEffectiveConfigReply, error)
+	ListConfigHistory(context.Context, *ListConfigHistoryRequest) (*ListConfigHistoryReply, error)
+	RollbackConfig(context.Context, *RollbackConfigRequest) (*RollbackConfigReply, error)
+	UpdatePod(context.Context, *UpdatePodRequest) (*UpdatePodReply, error)
+	GetDiagnostics(context.Context, *GetDiagnosticsRequest) (*GetDiagnosticsReply, error)
 }
 
 // UnimplementedConfigServer can be embedded to have forward compatible implementations.
@@ -298,6 +1244,27 @@ func (*UnimplementedConfigServer) SetConfig(ctx context.Context, req *SetConfigR
 func (*UnimplementedConfigServer) SetAdjustment(ctx context.Context, req *SetAdjustmentRequest) (*SetAdjustmentReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetAdjustment not implemented")
 }
+func (*UnimplementedConfigServer) DryRun(ctx context.Context, req *DryRunRequest) (*DryRunReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRun not implemented")
+}
+func (*UnimplementedConfigServer) GetConfig(ctx context.Context, req *GetCurrentConfigRequest) (*GetCurrentConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (*UnimplementedConfigServer) GetEffectiveConfig(ctx context.Context, req *GetEffectiveConfigRequest) (*GetEffectiveConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectiveConfig not implemented")
+}
+func (*UnimplementedConfigServer) ListConfigHistory(ctx context.Context, req *ListConfigHistoryRequest) (*ListConfigHistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConfigHistory not implemented")
+}
+func (*UnimplementedConfigServer) RollbackConfig(ctx context.Context, req *RollbackConfigRequest) (*RollbackConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackConfig not implemented")
+}
+func (*UnimplementedConfigServer) UpdatePod(ctx context.Context, req *UpdatePodRequest) (*UpdatePodReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePod not implemented")
+}
+func (*UnimplementedConfigServer) GetDiagnostics(ctx context.Context, req *GetDiagnosticsRequest) (*GetDiagnosticsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiagnostics not implemented")
+}
 
 func RegisterConfigServer(s *grpc.Server, srv ConfigServer) {
 	s.RegisterService(&_Config_serviceDesc, srv)
@@ -339,6 +1306,132 @@ func _Config_SetAdjustment_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Config_DryRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).DryRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Config/DryRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).DryRun(ctx, req.(*DryRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Config/GetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetConfig(ctx, req.(*GetCurrentConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_GetEffectiveConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectiveConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetEffectiveConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Config/GetEffectiveConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetEffectiveConfig(ctx, req.(*GetEffectiveConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_ListConfigHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).ListConfigHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Config/ListConfigHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).ListConfigHistory(ctx, req.(*ListConfigHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_RollbackConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).RollbackConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Config/RollbackConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).RollbackConfig(ctx, req.(*RollbackConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_UpdatePod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).UpdatePod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Config/UpdatePod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).UpdatePod(ctx, req.(*UpdatePodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Config_GetDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServer).GetDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Config/GetDiagnostics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServer).GetDiagnostics(ctx, req.(*GetDiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Config_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.Config",
 	HandlerType: (*ConfigServer)(nil),
@@ -351,6 +1444,34 @@ var _Config_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetAdjustment",
 			Handler:    _Config_SetAdjustment_Handler,
 		},
+		{
+			MethodName: "DryRun",
+			Handler:    _Config_DryRun_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _Config_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetEffectiveConfig",
+			Handler:    _Config_GetEffectiveConfig_Handler,
+		},
+		{
+			MethodName: "ListConfigHistory",
+			Handler:    _Config_ListConfigHistory_Handler,
+		},
+		{
+			MethodName: "RollbackConfig",
+			Handler:    _Config_RollbackConfig_Handler,
+		},
+		{
+			MethodName: "UpdatePod",
+			Handler:    _Config_UpdatePod_Handler,
+		},
+		{
+			MethodName: "GetDiagnostics",
+			Handler:    _Config_GetDiagnostics_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/cri/resource-manager/config/api/v1/api.proto",
@@ -17,6 +17,11 @@ limitations under the License.
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+
 	extapi "github.com/intel/cri-resource-manager/pkg/apis/resmgr/v1alpha1"
 )
 
@@ -29,12 +34,40 @@ type RawConfig struct {
 	Data map[string]string
 }
 
+// ConfigVersion is an applied configuration recorded in the configuration history.
+type ConfigVersion struct {
+	// Version is the sequence number of this configuration.
+	Version uint64
+	// Source describes where the configuration came from (agent, file, rollback).
+	Source string
+	// Time is the time the configuration was applied.
+	Time time.Time
+	// Checksum is the checksum of the configuration data.
+	Checksum string
+	// Config is the applied configuration.
+	Config *RawConfig
+}
+
 // Adjustment represents external adjustments for this node.
 type Adjustment struct {
 	// Adjustments contains all adjustment CRDs for this node.
 	Adjustments map[string]*extapi.AdjustmentSpec
 }
 
+// PodMetadata represents updated metadata of a pod, as received from the agent.
+type PodMetadata struct {
+	// Namespace is the namespace of the pod.
+	Namespace string
+	// Name is the name of the pod.
+	Name string
+	// UID is the UID of the pod.
+	UID string
+	// Labels are the current labels of the pod.
+	Labels map[string]string
+	// Annotations are the current annotations of the pod.
+	Annotations map[string]string
+}
+
 // HasIdenticalData returns true if RawConfig has identical data to the supplied one.
 func (c *RawConfig) HasIdenticalData(data map[string]string) bool {
 	if c == nil && data == nil {
@@ -62,3 +95,26 @@ func (c *RawConfig) HasIdenticalData(data map[string]string) bool {
 
 	return true
 }
+
+// Checksum returns a SHA256 checksum of the RawConfig data.
+func (c *RawConfig) Checksum() string {
+	if c == nil {
+		return ""
+	}
+
+	keys := make([]string, 0, len(c.Data))
+	for key := range c.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(c.Data[key]))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -26,11 +26,13 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/intel/cri-resource-manager/pkg/auth"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config/api/v1"
 	"github.com/intel/cri-resource-manager/pkg/log"
 
 	"encoding/json"
 	extapi "github.com/intel/cri-resource-manager/pkg/apis/resmgr/v1alpha1"
+	pkgcfg "github.com/intel/cri-resource-manager/pkg/config"
 )
 
 // SetConfigCb is a callback function for a SetConfig request.
@@ -39,6 +41,25 @@ type SetConfigCb func(*RawConfig) error
 // SetAdjustmentCb is a callback function for a SetAdjustment request.
 type SetAdjustmentCb func(*Adjustment) map[string]error
 
+// DryRunCb is a callback function for a DryRun request.
+type DryRunCb func(*RawConfig) (pkgcfg.Diff, []error)
+
+// UpdatePodCb is a callback function for an UpdatePod request.
+type UpdatePodCb func(*PodMetadata) error
+
+// DiagnosticsCb is a callback function for a GetDiagnostics request.
+type DiagnosticsCb func(redact bool) ([]byte, error)
+
+// ConfigHistory is the interface for querying and rolling back configuration.
+type ConfigHistory interface {
+	// GetConfigHistory returns the configuration history, oldest version first.
+	GetConfigHistory() []*ConfigVersion
+	// GetEffectiveConfig returns the effective configuration of all modules.
+	GetEffectiveConfig() (map[string]string, error)
+	// RollbackConfig re-applies the given version from the configuration history.
+	RollbackConfig(uint64) (*ConfigVersion, error)
+}
+
 // Server is the interface for our gRPC server.
 type Server interface {
 	Start(string) error
@@ -52,14 +73,23 @@ type server struct {
 	server          *grpc.Server    // gRPC server instance
 	setConfigCb     SetConfigCb     // configuration update notification callback
 	setAdjustmentCb SetAdjustmentCb // extneral adjustment update notification callback
+	dryRunCb        DryRunCb        // configuration dry-run callback
+	updatePodCb     UpdatePodCb     // pod metadata update notification callback
+	diagnosticsCb   DiagnosticsCb   // diagnostics collection callback
+	history         ConfigHistory   // configuration history and rollback
 }
 
 // NewConfigServer creates new Server instance.
-func NewConfigServer(configCb SetConfigCb, adjustmentCb SetAdjustmentCb) (Server, error) {
+func NewConfigServer(configCb SetConfigCb, adjustmentCb SetAdjustmentCb, dryRunCb DryRunCb,
+	updatePodCb UpdatePodCb, diagnosticsCb DiagnosticsCb, history ConfigHistory) (Server, error) {
 	s := &server{
 		Logger:          log.NewLogger("config-server"),
 		setConfigCb:     configCb,
 		setAdjustmentCb: adjustmentCb,
+		dryRunCb:        dryRunCb,
+		updatePodCb:     updatePodCb,
+		diagnosticsCb:   diagnosticsCb,
+		history:         history,
 	}
 	return s, nil
 }
@@ -83,7 +113,11 @@ func (s *server) Start(socket string) error {
 		return serverError("failed to listen to socket: %v", err)
 	}
 
-	serverOpts := []grpc.ServerOption{}
+	serverOpts, err := auth.ServerOptions(auth.ConfigSocket)
+	if err != nil {
+		lis.Close()
+		return serverError("failed to set up socket access control: %v", err)
+	}
 	s.server = grpc.NewServer(serverOpts...)
 	v1.RegisterConfigServer(s.server, s)
 
@@ -154,6 +188,152 @@ func (s *server) SetAdjustment(ctx context.Context, req *v1.SetAdjustmentRequest
 	return reply, nil
 }
 
+// UpdatePod pushes updated pod metadata to the server.
+func (s *server) UpdatePod(ctx context.Context, req *v1.UpdatePodRequest) (*v1.UpdatePodReply, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.Debug("UpdatePod request: %+v", req)
+
+	reply := &v1.UpdatePodReply{}
+	err := s.updatePodCb(&PodMetadata{
+		Namespace:   req.Namespace,
+		Name:        req.Name,
+		UID:         req.Uid,
+		Labels:      req.Labels,
+		Annotations: req.Annotations,
+	})
+	if err != nil {
+		reply.Error = fmt.Sprintf("failed to update pod %s/%s: %v", req.Namespace, req.Name, err)
+	}
+
+	return reply, nil
+}
+
+// GetDiagnostics collects diagnostics for debugging the node.
+func (s *server) GetDiagnostics(ctx context.Context, req *v1.GetDiagnosticsRequest) (*v1.GetDiagnosticsReply, error) {
+	s.Debug("GetDiagnostics request: %+v", req)
+
+	reply := &v1.GetDiagnosticsReply{}
+	bundle, err := s.diagnosticsCb(req.Redact)
+	if err != nil {
+		reply.Error = fmt.Sprintf("failed to collect diagnostics: %v", err)
+	}
+	reply.Bundle = bundle
+
+	return reply, nil
+}
+
+// DryRun checks what a configuration would change without applying it.
+func (s *server) DryRun(ctx context.Context, req *v1.DryRunRequest) (*v1.DryRunReply, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.Debug("DryRun request: %+v", req)
+
+	diff, errs := s.dryRunCb(&RawConfig{NodeName: req.NodeName, Data: req.Config})
+
+	reply := &v1.DryRunReply{Diff: make(map[string]*v1.ConfigModuleDiff)}
+	for module, changes := range diff {
+		md := &v1.ConfigModuleDiff{}
+		for _, c := range changes {
+			md.Changes = append(md.Changes, &v1.ConfigFieldChange{
+				Field: c.Field,
+				Old:   encodeValue(c.Old),
+				New:   encodeValue(c.New),
+			})
+		}
+		reply.Diff[module] = md
+	}
+	for _, err := range errs {
+		reply.Errors = append(reply.Errors, err.Error())
+	}
+
+	return reply, nil
+}
+
+// GetConfig returns the active configuration.
+func (s *server) GetConfig(ctx context.Context, req *v1.GetCurrentConfigRequest) (*v1.GetCurrentConfigReply, error) {
+	s.Debug("GetConfig request: %+v", req)
+
+	reply := &v1.GetCurrentConfigReply{}
+	if history := s.history.GetConfigHistory(); len(history) > 0 {
+		reply.Current = encodeVersion(history[len(history)-1], true)
+	}
+
+	return reply, nil
+}
+
+// GetEffectiveConfig returns the effective configuration, including defaults.
+func (s *server) GetEffectiveConfig(ctx context.Context, req *v1.GetEffectiveConfigRequest) (*v1.GetEffectiveConfigReply, error) {
+	s.Debug("GetEffectiveConfig request: %+v", req)
+
+	reply := &v1.GetEffectiveConfigReply{}
+	cfg, err := s.history.GetEffectiveConfig()
+	if err != nil {
+		reply.Error = fmt.Sprintf("failed to get effective configuration: %v", err)
+	}
+	reply.Config = cfg
+
+	return reply, nil
+}
+
+// ListConfigHistory lists the configuration history.
+func (s *server) ListConfigHistory(ctx context.Context, req *v1.ListConfigHistoryRequest) (*v1.ListConfigHistoryReply, error) {
+	s.Debug("ListConfigHistory request: %+v", req)
+
+	reply := &v1.ListConfigHistoryReply{}
+	for _, cv := range s.history.GetConfigHistory() {
+		reply.History = append(reply.History, encodeVersion(cv, req.WithData))
+	}
+
+	return reply, nil
+}
+
+// RollbackConfig re-applies a configuration from the history.
+func (s *server) RollbackConfig(ctx context.Context, req *v1.RollbackConfigRequest) (*v1.RollbackConfigReply, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.Debug("RollbackConfig request: %+v", req)
+
+	reply := &v1.RollbackConfigReply{}
+	cv, err := s.history.RollbackConfig(req.Version)
+	if err != nil {
+		reply.Error = fmt.Sprintf("failed to roll back to version %d: %v", req.Version, err)
+	} else {
+		reply.Current = encodeVersion(cv, false)
+	}
+
+	return reply, nil
+}
+
+// encodeVersion encodes a configuration version for a reply.
+func encodeVersion(cv *ConfigVersion, withData bool) *v1.ConfigVersion {
+	v := &v1.ConfigVersion{
+		Version:   cv.Version,
+		Source:    cv.Source,
+		Timestamp: cv.Time.UnixNano(),
+		Checksum:  cv.Checksum,
+	}
+	if cv.Config != nil {
+		v.NodeName = cv.Config.NodeName
+		if withData {
+			v.Config = cv.Config.Data
+		}
+	}
+	return v
+}
+
+// encodeValue encodes a configuration value as JSON.
+func encodeValue(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("<failed to encode value: %v>", err)
+	}
+	return string(raw)
+}
+
 func serverError(format string, args ...interface{}) error {
 	return fmt.Errorf(format, args...)
 }
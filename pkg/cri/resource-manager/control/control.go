@@ -15,10 +15,13 @@
 package control
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
 
+	"go.opencensus.io/trace"
+
 	"github.com/intel/cri-resource-manager/pkg/cri/client"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
 	logger "github.com/intel/cri-resource-manager/pkg/log"
@@ -29,15 +32,29 @@ type Control interface {
 	// StartStopControllers starts/stops all controllers according to configuration.
 	StartStopControllers(cache.Cache, client.Client) error
 	// PreCreateHooks runs the pre-create hooks of all registered controllers.
-	RunPreCreateHooks(cache.Container) error
+	RunPreCreateHooks(context.Context, cache.Container) error
 	// RunPreStartHooks runs the pre-start hooks of all registered controllers.
-	RunPreStartHooks(cache.Container) error
+	RunPreStartHooks(context.Context, cache.Container) error
 	// RunPostStartHooks runs the post-start hooks of all registered controllers.
-	RunPostStartHooks(cache.Container) error
+	RunPostStartHooks(context.Context, cache.Container) error
 	// RunPostUpdateHooks runs the post-update hooks of all registered controllers.
-	RunPostUpdateHooks(cache.Container) error
+	RunPostUpdateHooks(context.Context, cache.Container) error
 	// RunPostStopHooks runs the post-stop hooks of all registered controllers.
-	RunPostStopHooks(cache.Container) error
+	RunPostStopHooks(context.Context, cache.Container) error
+	// GetControllerStates returns the state of all registered controllers.
+	GetControllerStates() []ControllerState
+}
+
+// ControllerState describes the state of a single registered controller.
+type ControllerState struct {
+	// Name is the name of the controller.
+	Name string
+	// Description is the description of the controller.
+	Description string
+	// Mode is the current mode of the controller.
+	Mode string
+	// Running tells whether the controller is running.
+	Running bool
 }
 
 // Controller is the interface all resource controllers must implement.
@@ -155,10 +172,24 @@ func (c *control) StartStopControllers(cache cache.Cache, client client.Client)
 	return nil
 }
 
+// GetControllerStates returns the state of all registered controllers.
+func (c *control) GetControllerStates() []ControllerState {
+	states := make([]ControllerState, 0, len(c.controllers))
+	for _, controller := range c.controllers {
+		states = append(states, ControllerState{
+			Name:        controller.name,
+			Description: controller.description,
+			Mode:        controller.mode.String(),
+			Running:     controller.running,
+		})
+	}
+	return states
+}
+
 // RunPreCreateHooks runs all registered controllers' PreCreate hooks.
-func (c *control) RunPreCreateHooks(container cache.Container) error {
+func (c *control) RunPreCreateHooks(ctx context.Context, container cache.Container) error {
 	for _, controller := range c.controllers {
-		if err := c.runhook(controller, precreate, container); err != nil {
+		if err := c.runhook(ctx, controller, precreate, container); err != nil {
 			return err
 		}
 	}
@@ -166,9 +197,9 @@ func (c *control) RunPreCreateHooks(container cache.Container) error {
 }
 
 // RunPreStartHooks runs all registered controllers' PreStart hooks.
-func (c *control) RunPreStartHooks(container cache.Container) error {
+func (c *control) RunPreStartHooks(ctx context.Context, container cache.Container) error {
 	for _, controller := range c.controllers {
-		if err := c.runhook(controller, prestart, container); err != nil {
+		if err := c.runhook(ctx, controller, prestart, container); err != nil {
 			return err
 		}
 	}
@@ -176,9 +207,9 @@ func (c *control) RunPreStartHooks(container cache.Container) error {
 }
 
 // RunPostStartHooks runs all registered controllers' PostStart hooks.
-func (c *control) RunPostStartHooks(container cache.Container) error {
+func (c *control) RunPostStartHooks(ctx context.Context, container cache.Container) error {
 	for _, controller := range c.controllers {
-		if err := c.runhook(controller, poststart, container); err != nil {
+		if err := c.runhook(ctx, controller, poststart, container); err != nil {
 			return err
 		}
 	}
@@ -186,9 +217,9 @@ func (c *control) RunPostStartHooks(container cache.Container) error {
 }
 
 // RunPostUpdateHooks runs all registered controllers' PostUpdate hooks.
-func (c *control) RunPostUpdateHooks(container cache.Container) error {
+func (c *control) RunPostUpdateHooks(ctx context.Context, container cache.Container) error {
 	for _, controller := range c.controllers {
-		if err := c.runhook(controller, postupdate, container); err != nil {
+		if err := c.runhook(ctx, controller, postupdate, container); err != nil {
 			return err
 		}
 	}
@@ -196,9 +227,9 @@ func (c *control) RunPostUpdateHooks(container cache.Container) error {
 }
 
 // RunPostStopHooks runs all registered controllers' PostStop hooks.
-func (c *control) RunPostStopHooks(container cache.Container) error {
+func (c *control) RunPostStopHooks(ctx context.Context, container cache.Container) error {
 	for _, controller := range c.controllers {
-		if err := c.runhook(controller, poststop, container); err != nil {
+		if err := c.runhook(ctx, controller, poststop, container); err != nil {
 			return err
 		}
 	}
@@ -206,7 +237,7 @@ func (c *control) RunPostStopHooks(container cache.Container) error {
 }
 
 // runhook executes the given container hook according to the controller settings
-func (c *control) runhook(controller *controller, hook string, container cache.Container) error {
+func (c *control) runhook(ctx context.Context, controller *controller, hook string, container cache.Container) error {
 	if controller.mode == Disabled || !controller.running {
 		return nil
 	}
@@ -228,7 +259,16 @@ func (c *control) runhook(controller *controller, hook string, container cache.C
 
 	log.Debug("running %s %s hook for container %s", controller.name, hook, container.PrettyName())
 
+	_, span := trace.StartSpan(ctx, "control."+hook)
+	defer span.End()
+	span.AddAttributes(
+		trace.StringAttribute("controller", controller.name),
+		trace.StringAttribute("container", container.PrettyName()),
+	)
+
 	if err := fn(container); err != nil {
+		span.AddAttributes(trace.StringAttribute("error", err.Error()))
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
 		if controller.mode == Required {
 			return controlError("%s %s hook failed: %v", controller.name, hook, err)
 		}
@@ -112,8 +112,18 @@ func defaultOptions() interface{} {
 	return &options{Controllers: make(map[string]mode)}
 }
 
+// validateOptions checks candidate options without applying them.
+func validateOptions(cfg interface{}) error {
+	for name := range cfg.(*options).Controllers {
+		if _, ok := controllers[name]; !ok {
+			return controlError("unknown controller %q", name)
+		}
+	}
+	return nil
+}
+
 // Register us for configuration handling.
 func init() {
 	config.Register("resource-manager.control", "Resource control.", opt, defaultOptions,
-		config.WithNotify(opt.configNotify))
+		config.WithNotify(opt.configNotify), config.WithValidate(validateOptions))
 }
@@ -36,7 +36,17 @@ func defaultOptions() interface{} {
 	return &options{}
 }
 
+// validateOptions checks candidate options without applying them.
+func validateOptions(cfg interface{}) error {
+	o := cfg.(*options)
+	if o.PageScanInterval < 0 || o.PageMoveInterval < 0 {
+		return migrationError("invalid negative page scan or move interval")
+	}
+	return nil
+}
+
 // Register us for configuration handling.
 func init() {
-	config.Register(PageMigrationConfigPath, PageMigrationDescription, opt, defaultOptions)
+	config.Register(PageMigrationConfigPath, PageMigrationDescription, opt, defaultOptions,
+		config.WithValidate(validateOptions))
 }
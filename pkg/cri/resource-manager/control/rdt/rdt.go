@@ -366,6 +366,16 @@ func (ctl *rdtctl) defaultOptions() interface{} {
 	return c
 }
 
+// validateOptions checks candidate options without applying them.
+func validateOptions(cfg interface{}) error {
+	switch mode := cfg.(*config).Options.Mode; mode {
+	case OperatingModeDisabled, OperatingModeDiscovery, OperatingModeFull:
+	default:
+		return rdtError("invalid mode %q", mode)
+	}
+	return nil
+}
+
 // GetClasses returns all available RDT classes
 func GetClasses() []rdt.CtrlGroup {
 	return rdt.GetClasses()
@@ -379,5 +389,6 @@ func rdtError(format string, args ...interface{}) error {
 // Register us as a controller.
 func init() {
 	control.Register(RDTController, "RDT controller", getRDTController())
-	pkgcfg.Register(ConfigModuleName, "RDT control", getRDTController().opt, getRDTController().defaultOptions)
+	pkgcfg.Register(ConfigModuleName, "RDT control", getRDTController().opt, getRDTController().defaultOptions,
+		pkgcfg.WithValidate(validateOptions))
 }
@@ -0,0 +1,384 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	cri "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/ctl"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/introspect"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/kubernetes"
+)
+
+// setupControlServer sets up our server for cri-resmgr-ctl requests.
+func (m *resmgr) setupControlServer() error {
+	var err error
+
+	if m.ctlServer, err = ctl.NewControlServer(m); err != nil {
+		return resmgrError("failed to create control server: %v", err)
+	}
+
+	return nil
+}
+
+// ListContainerInfo returns all containers with their resource assignments.
+func (m *resmgr) ListContainerInfo() []*ctl.ContainerInfo {
+	m.RLock()
+	defer m.RUnlock()
+
+	state := m.introspectState()
+	containers := []*ctl.ContainerInfo{}
+	for _, c := range m.cache.GetContainers() {
+		containers = append(containers, containerInfo(c, state))
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		ci, cj := containers[i], containers[j]
+		if ci.Namespace != cj.Namespace {
+			return ci.Namespace < cj.Namespace
+		}
+		if ci.Pod != cj.Pod {
+			return ci.Pod < cj.Pod
+		}
+		return ci.Name < cj.Name
+	})
+
+	return containers
+}
+
+// GetPoolUsage returns the active policy and the usage of its pools.
+func (m *resmgr) GetPoolUsage() (string, []*ctl.PoolUsage, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	state := m.introspectState()
+	if state == nil {
+		return "", nil, resmgrError("no active policy")
+	}
+
+	containers := map[string]*introspect.Container{}
+	for _, pod := range state.Pods {
+		for id, c := range pod.Containers {
+			containers[id] = c
+		}
+	}
+
+	usage := map[string]*ctl.PoolUsage{}
+	exclusive := map[string]cpuset.CPUSet{}
+	for name, p := range state.Pools {
+		u := &ctl.PoolUsage{
+			Name:   name,
+			Parent: p.Parent,
+			CPUs:   p.CPUs,
+			Memory: p.Memory,
+		}
+		if cpus, err := cpuset.Parse(p.CPUs); err == nil {
+			u.CPUCapacity = int64(1000 * cpus.Size())
+		}
+		usage[name] = u
+		exclusive[name] = cpuset.NewCPUSet()
+	}
+
+	for id, a := range state.Assignments {
+		u, ok := usage[a.Pool]
+		if !ok {
+			continue
+		}
+		u.Containers++
+		if c, ok := containers[id]; ok {
+			u.CPURequest += c.CPURequest
+			u.MemoryRequest += c.MemoryRequest
+		}
+		if cpus, err := cpuset.Parse(a.ExclusiveCPUs); err == nil {
+			exclusive[a.Pool] = exclusive[a.Pool].Union(cpus)
+		}
+	}
+
+	pools := make([]*ctl.PoolUsage, 0, len(usage))
+	for name, u := range usage {
+		u.ExclusiveCPUs = exclusive[name].String()
+		pools = append(pools, u)
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+
+	policy := ""
+	if state.System != nil {
+		policy = state.System.Policy
+	}
+
+	return policy, pools, nil
+}
+
+// ExplainPlacement explains the placement of the given container.
+func (m *resmgr) ExplainPlacement(id string) (*ctl.Placement, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	c, err := m.lookupContainer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	state := m.introspectState()
+	info := containerInfo(c, state)
+	lines := []string{}
+	explain := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	explain("container %s/%s/%s is %s, QoS class %s", info.Namespace, info.Pod, info.Name,
+		info.State, info.QOSClass)
+
+	resources := c.GetResourceRequirements()
+	explain("requests: %s, limits: %s", resourceList(resources.Requests), resourceList(resources.Limits))
+
+	if state != nil && state.System != nil {
+		explain("active policy: %s", state.System.Policy)
+	}
+
+	var assignment *introspect.Assignment
+	if state != nil {
+		assignment = state.Assignments[c.GetID()]
+	}
+	if assignment == nil {
+		explain("not assigned to any pool by the active policy")
+	} else {
+		pool := state.Pools[assignment.Pool]
+		if pool != nil {
+			explain("assigned to pool %s (CPUs %s, memory nodes %s)", pool.Name, pool.CPUs, pool.Memory)
+		} else {
+			explain("assigned to pool %s", assignment.Pool)
+		}
+		if assignment.ExclusiveCPUs != "" {
+			explain("exclusive CPUs: %s", assignment.ExclusiveCPUs)
+		}
+		if assignment.SharedCPUs != "" {
+			explain("shared CPUs: %s, with a %d milli-CPU share", assignment.SharedCPUs, assignment.CPUShare)
+		}
+	}
+
+	explain("cpuset: %s, memset: %s", orNone(info.Cpuset), orNone(info.Memset))
+
+	hints := c.GetTopologyHints()
+	providers := make([]string, 0, len(hints))
+	for provider := range hints {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		hint := hints[provider]
+		explain("topology hint from %s: %s", provider, hint.String())
+	}
+
+	for _, a := range c.GetAffinity() {
+		explain("affinity: %s", a.String())
+	}
+
+	if pod, ok := c.GetPod(); ok {
+		keys := pod.GetResmgrAnnotationKeys()
+		sort.Strings(keys)
+		for _, key := range keys {
+			value, _ := pod.GetResmgrAnnotation(key)
+			explain("annotation %s: %s", kubernetes.ResmgrKey(key), value)
+		}
+	}
+
+	explain("RDT class: %s, block I/O class: %s", orNone(info.RDTClass), orNone(info.BlockIOClass))
+
+	tags := c.GetTags()
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		explain("tag %s: %s", key, tags[key])
+	}
+
+	return &ctl.Placement{Container: info, Explanation: lines}, nil
+}
+
+// GetContainerTags returns the tags of the given container.
+func (m *resmgr) GetContainerTags(id string) (map[string]string, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	c, err := m.lookupContainer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetTags(), nil
+}
+
+// SetContainerTags sets and deletes tags of the given container. The resources
+// of the container are then updated, since tags can be used in affinities and
+// adjustment scopes. Other containers referring to the tags are updated only
+// when they are next allocated or rebalanced.
+func (m *resmgr) SetContainerTags(id string, set map[string]string, del []string) (map[string]string, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	c, err := m.lookupContainer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	old := c.GetTags()
+	for _, key := range del {
+		c.DeleteTag(key)
+	}
+	for key, value := range set {
+		c.SetTag(key, value)
+	}
+	tags := c.GetTags()
+
+	if reflect.DeepEqual(old, tags) {
+		return tags, nil
+	}
+
+	m.Info("updated tags of container %s", c.PrettyName())
+
+	ctx := context.Background()
+	method := "SetContainerTags"
+
+	switch c.GetState() {
+	case cache.ContainerStateRunning, cache.ContainerStateCreated:
+		if m.policy == nil {
+			break
+		}
+		grant := getContainerGrant(c)
+		if err := m.policy.UpdateResources(c); err != nil {
+			m.Error("%s: failed to update resources of %s: %v", method, c.PrettyName(), err)
+		}
+		m.skipUnchangedGrant(method, c, grant)
+		if err := m.runPostUpdateHooks(ctx, method); err != nil {
+			m.Error("%s: failed to run post-update hooks: %v", method, err)
+			return nil, resmgrError("%s: failed to run post-update hooks: %v", method, err)
+		}
+		m.updateIntrospection()
+	}
+
+	if err := m.saveCache(ctx); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// lookupContainer looks up a container by ID, unique ID prefix, pod/name, or namespace/pod/name.
+func (m *resmgr) lookupContainer(id string) (cache.Container, error) {
+	if c, ok := m.cache.LookupContainer(id); ok {
+		return c, nil
+	}
+
+	var matches []cache.Container
+	parts := strings.Split(id, "/")
+	for _, c := range m.cache.GetContainers() {
+		podName := ""
+		if pod, ok := c.GetPod(); ok {
+			podName = pod.GetName()
+		}
+		switch len(parts) {
+		case 1:
+			if strings.HasPrefix(c.GetID(), id) || strings.HasPrefix(c.GetCacheID(), id) {
+				matches = append(matches, c)
+			}
+		case 2:
+			if podName == parts[0] && c.GetName() == parts[1] {
+				matches = append(matches, c)
+			}
+		case 3:
+			if c.GetNamespace() == parts[0] && podName == parts[1] && c.GetName() == parts[2] {
+				matches = append(matches, c)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, resmgrError("container %q not found", id)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, resmgrError("container %q is ambiguous, matches %d containers", id, len(matches))
+}
+
+// introspectState returns the introspected state of the active policy, if any.
+func (m *resmgr) introspectState() *introspect.State {
+	if m.policy == nil {
+		return nil
+	}
+	return m.policy.Introspect()
+}
+
+// containerInfo collects information about a container and its resource assignment.
+func containerInfo(c cache.Container, state *introspect.State) *ctl.ContainerInfo {
+	info := &ctl.ContainerInfo{
+		Namespace:    c.GetNamespace(),
+		Name:         c.GetName(),
+		ID:           c.GetID(),
+		CacheID:      c.GetCacheID(),
+		State:        cri.ContainerState(c.GetState()).String(),
+		Cpuset:       c.GetCpusetCpus(),
+		Memset:       c.GetCpusetMems(),
+		RDTClass:     c.GetRDTClass(),
+		BlockIOClass: c.GetBlockIOClass(),
+	}
+	if pod, ok := c.GetPod(); ok {
+		info.Pod = pod.GetName()
+		info.QOSClass = string(pod.GetQOSClass())
+	}
+	if state != nil {
+		if a, ok := state.Assignments[c.GetID()]; ok {
+			info.Pool = a.Pool
+		}
+	}
+	return info
+}
+
+// resourceList formats a resource list for an explanation.
+func resourceList(resources corev1.ResourceList) string {
+	if len(resources) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		qty := resources[corev1.ResourceName(name)]
+		values = append(values, name+"="+qty.String())
+	}
+	return strings.Join(values, ",")
+}
+
+// orNone returns the given value, or "none" if it is empty.
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
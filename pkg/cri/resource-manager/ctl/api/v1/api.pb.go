@@ -0,0 +1,1274 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/cri/resource-manager/ctl/api/v1/api.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ContainerInfo struct {
+	// namespace is the namespace of the pod of the container.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// pod is the name of the pod of the container.
+	Pod string `protobuf:"bytes,2,opt,name=pod,proto3" json:"pod,omitempty"`
+	// name is the name of the container.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// id is the runtime ID of the container.
+	Id string `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	// cache_id is the ID of the container in the cri-resmgr cache.
+	CacheId string `protobuf:"bytes,5,opt,name=cache_id,json=cacheId,proto3" json:"cache_id,omitempty"`
+	// state is the runtime state of the container.
+	State string `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	// qos_class is the QoS class of the pod of the container.
+	QosClass string `protobuf:"bytes,7,opt,name=qos_class,json=qosClass,proto3" json:"qos_class,omitempty"`
+	// cpuset is the CPUs the container is allowed to run on.
+	Cpuset string `protobuf:"bytes,8,opt,name=cpuset,proto3" json:"cpuset,omitempty"`
+	// memset is the memory nodes the container is allowed to use.
+	Memset string `protobuf:"bytes,9,opt,name=memset,proto3" json:"memset,omitempty"`
+	// pool is the pool the container is assigned to by the active policy.
+	Pool string `protobuf:"bytes,10,opt,name=pool,proto3" json:"pool,omitempty"`
+	// rdt_class is the RDT class of the container.
+	RdtClass string `protobuf:"bytes,11,opt,name=rdt_class,json=rdtClass,proto3" json:"rdt_class,omitempty"`
+	// blockio_class is the block I/O class of the container.
+	BlockioClass         string   `protobuf:"bytes,12,opt,name=blockio_class,json=blockioClass,proto3" json:"blockio_class,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerInfo) Reset()         { *m = ContainerInfo{} }
+func (m *ContainerInfo) String() string { return proto.CompactTextString(m) }
+func (*ContainerInfo) ProtoMessage()    {}
+func (*ContainerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{0}
+}
+
+func (m *ContainerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerInfo.Unmarshal(m, b)
+}
+func (m *ContainerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerInfo.Marshal(b, m, deterministic)
+}
+func (m *ContainerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerInfo.Merge(m, src)
+}
+func (m *ContainerInfo) XXX_Size() int {
+	return xxx_messageInfo_ContainerInfo.Size(m)
+}
+func (m *ContainerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerInfo proto.InternalMessageInfo
+
+func (m *ContainerInfo) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetPod() string {
+	if m != nil {
+		return m.Pod
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetCacheId() string {
+	if m != nil {
+		return m.CacheId
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetQosClass() string {
+	if m != nil {
+		return m.QosClass
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetCpuset() string {
+	if m != nil {
+		return m.Cpuset
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetMemset() string {
+	if m != nil {
+		return m.Memset
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetPool() string {
+	if m != nil {
+		return m.Pool
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetRdtClass() string {
+	if m != nil {
+		return m.RdtClass
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetBlockioClass() string {
+	if m != nil {
+		return m.BlockioClass
+	}
+	return ""
+}
+
+type ListContainersRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListContainersRequest) Reset()         { *m = ListContainersRequest{} }
+func (m *ListContainersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListContainersRequest) ProtoMessage()    {}
+func (*ListContainersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{1}
+}
+
+func (m *ListContainersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListContainersRequest.Unmarshal(m, b)
+}
+func (m *ListContainersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListContainersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListContainersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListContainersRequest.Merge(m, src)
+}
+func (m *ListContainersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListContainersRequest.Size(m)
+}
+func (m *ListContainersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListContainersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListContainersRequest proto.InternalMessageInfo
+
+type ListContainersReply struct {
+	// containers are all containers known to cri-resmgr.
+	Containers           []*ContainerInfo `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListContainersReply) Reset()         { *m = ListContainersReply{} }
+func (m *ListContainersReply) String() string { return proto.CompactTextString(m) }
+func (*ListContainersReply) ProtoMessage()    {}
+func (*ListContainersReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{2}
+}
+
+func (m *ListContainersReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListContainersReply.Unmarshal(m, b)
+}
+func (m *ListContainersReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListContainersReply.Marshal(b, m, deterministic)
+}
+func (m *ListContainersReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListContainersReply.Merge(m, src)
+}
+func (m *ListContainersReply) XXX_Size() int {
+	return xxx_messageInfo_ListContainersReply.Size(m)
+}
+func (m *ListContainersReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListContainersReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListContainersReply proto.InternalMessageInfo
+
+func (m *ListContainersReply) GetContainers() []*ContainerInfo {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
+type PoolUsage struct {
+	// name is the name of the pool.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// parent is the name of the parent pool, if any.
+	Parent string `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"`
+	// cpus is the CPUs of the pool.
+	Cpus string `protobuf:"bytes,3,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	// memory is the memory nodes of the pool.
+	Memory string `protobuf:"bytes,4,opt,name=memory,proto3" json:"memory,omitempty"`
+	// cpu_capacity is the CPU capacity of the pool in milli-CPU.
+	CpuCapacity int64 `protobuf:"varint,5,opt,name=cpu_capacity,json=cpuCapacity,proto3" json:"cpu_capacity,omitempty"`
+	// cpu_request is the total CPU request of containers in the pool in milli-CPU.
+	CpuRequest int64 `protobuf:"varint,6,opt,name=cpu_request,json=cpuRequest,proto3" json:"cpu_request,omitempty"`
+	// exclusive_cpus are the CPUs exclusively allocated to containers in the pool.
+	ExclusiveCpus string `protobuf:"bytes,7,opt,name=exclusive_cpus,json=exclusiveCpus,proto3" json:"exclusive_cpus,omitempty"`
+	// memory_request is the total memory request of containers in the pool in bytes.
+	MemoryRequest int64 `protobuf:"varint,8,opt,name=memory_request,json=memoryRequest,proto3" json:"memory_request,omitempty"`
+	// containers is the number of containers assigned to the pool.
+	Containers           int32    `protobuf:"varint,9,opt,name=containers,proto3" json:"containers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PoolUsage) Reset()         { *m = PoolUsage{} }
+func (m *PoolUsage) String() string { return proto.CompactTextString(m) }
+func (*PoolUsage) ProtoMessage()    {}
+func (*PoolUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{3}
+}
+
+func (m *PoolUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PoolUsage.Unmarshal(m, b)
+}
+func (m *PoolUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PoolUsage.Marshal(b, m, deterministic)
+}
+func (m *PoolUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolUsage.Merge(m, src)
+}
+func (m *PoolUsage) XXX_Size() int {
+	return xxx_messageInfo_PoolUsage.Size(m)
+}
+func (m *PoolUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolUsage proto.InternalMessageInfo
+
+func (m *PoolUsage) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PoolUsage) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *PoolUsage) GetCpus() string {
+	if m != nil {
+		return m.Cpus
+	}
+	return ""
+}
+
+func (m *PoolUsage) GetMemory() string {
+	if m != nil {
+		return m.Memory
+	}
+	return ""
+}
+
+func (m *PoolUsage) GetCpuCapacity() int64 {
+	if m != nil {
+		return m.CpuCapacity
+	}
+	return 0
+}
+
+func (m *PoolUsage) GetCpuRequest() int64 {
+	if m != nil {
+		return m.CpuRequest
+	}
+	return 0
+}
+
+func (m *PoolUsage) GetExclusiveCpus() string {
+	if m != nil {
+		return m.ExclusiveCpus
+	}
+	return ""
+}
+
+func (m *PoolUsage) GetMemoryRequest() int64 {
+	if m != nil {
+		return m.MemoryRequest
+	}
+	return 0
+}
+
+func (m *PoolUsage) GetContainers() int32 {
+	if m != nil {
+		return m.Containers
+	}
+	return 0
+}
+
+type GetPoolUsageRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPoolUsageRequest) Reset()         { *m = GetPoolUsageRequest{} }
+func (m *GetPoolUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPoolUsageRequest) ProtoMessage()    {}
+func (*GetPoolUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{4}
+}
+
+func (m *GetPoolUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPoolUsageRequest.Unmarshal(m, b)
+}
+func (m *GetPoolUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPoolUsageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPoolUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPoolUsageRequest.Merge(m, src)
+}
+func (m *GetPoolUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPoolUsageRequest.Size(m)
+}
+func (m *GetPoolUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPoolUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPoolUsageRequest proto.InternalMessageInfo
+
+type GetPoolUsageReply struct {
+	// policy is the name of the active policy.
+	Policy string `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	// pools is the usage of all pools of the active policy.
+	Pools []*PoolUsage `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"`
+	// If not empty, indicates an error that happened while collecting pool usage.
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPoolUsageReply) Reset()         { *m = GetPoolUsageReply{} }
+func (m *GetPoolUsageReply) String() string { return proto.CompactTextString(m) }
+func (*GetPoolUsageReply) ProtoMessage()    {}
+func (*GetPoolUsageReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{5}
+}
+
+func (m *GetPoolUsageReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPoolUsageReply.Unmarshal(m, b)
+}
+func (m *GetPoolUsageReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPoolUsageReply.Marshal(b, m, deterministic)
+}
+func (m *GetPoolUsageReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPoolUsageReply.Merge(m, src)
+}
+func (m *GetPoolUsageReply) XXX_Size() int {
+	return xxx_messageInfo_GetPoolUsageReply.Size(m)
+}
+func (m *GetPoolUsageReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPoolUsageReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPoolUsageReply proto.InternalMessageInfo
+
+func (m *GetPoolUsageReply) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+func (m *GetPoolUsageReply) GetPools() []*PoolUsage {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+func (m *GetPoolUsageReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ExplainPlacementRequest struct {
+	// container is the ID of the container, or pod/container or namespace/pod/container.
+	Container            string   `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExplainPlacementRequest) Reset()         { *m = ExplainPlacementRequest{} }
+func (m *ExplainPlacementRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainPlacementRequest) ProtoMessage()    {}
+func (*ExplainPlacementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{6}
+}
+
+func (m *ExplainPlacementRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainPlacementRequest.Unmarshal(m, b)
+}
+func (m *ExplainPlacementRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainPlacementRequest.Marshal(b, m, deterministic)
+}
+func (m *ExplainPlacementRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainPlacementRequest.Merge(m, src)
+}
+func (m *ExplainPlacementRequest) XXX_Size() int {
+	return xxx_messageInfo_ExplainPlacementRequest.Size(m)
+}
+func (m *ExplainPlacementRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainPlacementRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainPlacementRequest proto.InternalMessageInfo
+
+func (m *ExplainPlacementRequest) GetContainer() string {
+	if m != nil {
+		return m.Container
+	}
+	return ""
+}
+
+type ExplainPlacementReply struct {
+	// container is the container being explained.
+	Container *ContainerInfo `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	// explanation is a human-readable explanation of the placement, one fact per line.
+	Explanation []string `protobuf:"bytes,2,rep,name=explanation,proto3" json:"explanation,omitempty"`
+	// If not empty, indicates an error that happened while explaining the placement.
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExplainPlacementReply) Reset()         { *m = ExplainPlacementReply{} }
+func (m *ExplainPlacementReply) String() string { return proto.CompactTextString(m) }
+func (*ExplainPlacementReply) ProtoMessage()    {}
+func (*ExplainPlacementReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{7}
+}
+
+func (m *ExplainPlacementReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainPlacementReply.Unmarshal(m, b)
+}
+func (m *ExplainPlacementReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainPlacementReply.Marshal(b, m, deterministic)
+}
+func (m *ExplainPlacementReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainPlacementReply.Merge(m, src)
+}
+func (m *ExplainPlacementReply) XXX_Size() int {
+	return xxx_messageInfo_ExplainPlacementReply.Size(m)
+}
+func (m *ExplainPlacementReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainPlacementReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainPlacementReply proto.InternalMessageInfo
+
+func (m *ExplainPlacementReply) GetContainer() *ContainerInfo {
+	if m != nil {
+		return m.Container
+	}
+	return nil
+}
+
+func (m *ExplainPlacementReply) GetExplanation() []string {
+	if m != nil {
+		return m.Explanation
+	}
+	return nil
+}
+
+func (m *ExplainPlacementReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type RebalanceRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebalanceRequest) Reset()         { *m = RebalanceRequest{} }
+func (m *RebalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*RebalanceRequest) ProtoMessage()    {}
+func (*RebalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{8}
+}
+
+func (m *RebalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebalanceRequest.Unmarshal(m, b)
+}
+func (m *RebalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *RebalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebalanceRequest.Merge(m, src)
+}
+func (m *RebalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_RebalanceRequest.Size(m)
+}
+func (m *RebalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebalanceRequest proto.InternalMessageInfo
+
+type RebalanceReply struct {
+	// If not empty, indicates an error that happened while rebalancing containers.
+	Error                string   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebalanceReply) Reset()         { *m = RebalanceReply{} }
+func (m *RebalanceReply) String() string { return proto.CompactTextString(m) }
+func (*RebalanceReply) ProtoMessage()    {}
+func (*RebalanceReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{9}
+}
+
+func (m *RebalanceReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebalanceReply.Unmarshal(m, b)
+}
+func (m *RebalanceReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebalanceReply.Marshal(b, m, deterministic)
+}
+func (m *RebalanceReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebalanceReply.Merge(m, src)
+}
+func (m *RebalanceReply) XXX_Size() int {
+	return xxx_messageInfo_RebalanceReply.Size(m)
+}
+func (m *RebalanceReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebalanceReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebalanceReply proto.InternalMessageInfo
+
+func (m *RebalanceReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type SetDebugRequest struct {
+	// sources are the logger sources to change debugging for.
+	Sources []string `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	// enable enables debugging if true and disables it otherwise.
+	Enable               bool     `protobuf:"varint,2,opt,name=enable,proto3" json:"enable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDebugRequest) Reset()         { *m = SetDebugRequest{} }
+func (m *SetDebugRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDebugRequest) ProtoMessage()    {}
+func (*SetDebugRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{10}
+}
+
+func (m *SetDebugRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDebugRequest.Unmarshal(m, b)
+}
+func (m *SetDebugRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDebugRequest.Marshal(b, m, deterministic)
+}
+func (m *SetDebugRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDebugRequest.Merge(m, src)
+}
+func (m *SetDebugRequest) XXX_Size() int {
+	return xxx_messageInfo_SetDebugRequest.Size(m)
+}
+func (m *SetDebugRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDebugRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDebugRequest proto.InternalMessageInfo
+
+func (m *SetDebugRequest) GetSources() []string {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+func (m *SetDebugRequest) GetEnable() bool {
+	if m != nil {
+		return m.Enable
+	}
+	return false
+}
+
+type SetDebugReply struct {
+	// previous is the previous debug state of the sources, by source name.
+	Previous             map[string]bool `protobuf:"bytes,1,rep,name=previous,proto3" json:"previous,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SetDebugReply) Reset()         { *m = SetDebugReply{} }
+func (m *SetDebugReply) String() string { return proto.CompactTextString(m) }
+func (*SetDebugReply) ProtoMessage()    {}
+func (*SetDebugReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{11}
+}
+
+func (m *SetDebugReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDebugReply.Unmarshal(m, b)
+}
+func (m *SetDebugReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDebugReply.Marshal(b, m, deterministic)
+}
+func (m *SetDebugReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDebugReply.Merge(m, src)
+}
+func (m *SetDebugReply) XXX_Size() int {
+	return xxx_messageInfo_SetDebugReply.Size(m)
+}
+func (m *SetDebugReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDebugReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDebugReply proto.InternalMessageInfo
+
+func (m *SetDebugReply) GetPrevious() map[string]bool {
+	if m != nil {
+		return m.Previous
+	}
+	return nil
+}
+
+type GetContainerTagsRequest struct {
+	// container is the ID of the container, or pod/container or namespace/pod/container.
+	Container            string   `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetContainerTagsRequest) Reset()         { *m = GetContainerTagsRequest{} }
+func (m *GetContainerTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetContainerTagsRequest) ProtoMessage()    {}
+func (*GetContainerTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{12}
+}
+
+func (m *GetContainerTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetContainerTagsRequest.Unmarshal(m, b)
+}
+func (m *GetContainerTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetContainerTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetContainerTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetContainerTagsRequest.Merge(m, src)
+}
+func (m *GetContainerTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetContainerTagsRequest.Size(m)
+}
+func (m *GetContainerTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetContainerTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetContainerTagsRequest proto.InternalMessageInfo
+
+func (m *GetContainerTagsRequest) GetContainer() string {
+	if m != nil {
+		return m.Container
+	}
+	return ""
+}
+
+type GetContainerTagsReply struct {
+	// tags are the tags of the container.
+	Tags map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If not empty, indicates an error that happened while looking up the tags.
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetContainerTagsReply) Reset()         { *m = GetContainerTagsReply{} }
+func (m *GetContainerTagsReply) String() string { return proto.CompactTextString(m) }
+func (*GetContainerTagsReply) ProtoMessage()    {}
+func (*GetContainerTagsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{13}
+}
+
+func (m *GetContainerTagsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetContainerTagsReply.Unmarshal(m, b)
+}
+func (m *GetContainerTagsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetContainerTagsReply.Marshal(b, m, deterministic)
+}
+func (m *GetContainerTagsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetContainerTagsReply.Merge(m, src)
+}
+func (m *GetContainerTagsReply) XXX_Size() int {
+	return xxx_messageInfo_GetContainerTagsReply.Size(m)
+}
+func (m *GetContainerTagsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetContainerTagsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetContainerTagsReply proto.InternalMessageInfo
+
+func (m *GetContainerTagsReply) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *GetContainerTagsReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type SetContainerTagsRequest struct {
+	// container is the ID of the container, or pod/container or namespace/pod/container.
+	Container string `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	// set are the tags to set.
+	Set map[string]string `protobuf:"bytes,2,rep,name=set,proto3" json:"set,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// delete are the tags to delete.
+	Delete               []string `protobuf:"bytes,3,rep,name=delete,proto3" json:"delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetContainerTagsRequest) Reset()         { *m = SetContainerTagsRequest{} }
+func (m *SetContainerTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetContainerTagsRequest) ProtoMessage()    {}
+func (*SetContainerTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{14}
+}
+
+func (m *SetContainerTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetContainerTagsRequest.Unmarshal(m, b)
+}
+func (m *SetContainerTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetContainerTagsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetContainerTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetContainerTagsRequest.Merge(m, src)
+}
+func (m *SetContainerTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetContainerTagsRequest.Size(m)
+}
+func (m *SetContainerTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetContainerTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetContainerTagsRequest proto.InternalMessageInfo
+
+func (m *SetContainerTagsRequest) GetContainer() string {
+	if m != nil {
+		return m.Container
+	}
+	return ""
+}
+
+func (m *SetContainerTagsRequest) GetSet() map[string]string {
+	if m != nil {
+		return m.Set
+	}
+	return nil
+}
+
+func (m *SetContainerTagsRequest) GetDelete() []string {
+	if m != nil {
+		return m.Delete
+	}
+	return nil
+}
+
+type SetContainerTagsReply struct {
+	// tags are the tags of the container after the update.
+	Tags map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If not empty, indicates an error that happened while updating the tags.
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetContainerTagsReply) Reset()         { *m = SetContainerTagsReply{} }
+func (m *SetContainerTagsReply) String() string { return proto.CompactTextString(m) }
+func (*SetContainerTagsReply) ProtoMessage()    {}
+func (*SetContainerTagsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fc37263829dca50e, []int{15}
+}
+
+func (m *SetContainerTagsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetContainerTagsReply.Unmarshal(m, b)
+}
+func (m *SetContainerTagsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetContainerTagsReply.Marshal(b, m, deterministic)
+}
+func (m *SetContainerTagsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetContainerTagsReply.Merge(m, src)
+}
+func (m *SetContainerTagsReply) XXX_Size() int {
+	return xxx_messageInfo_SetContainerTagsReply.Size(m)
+}
+func (m *SetContainerTagsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetContainerTagsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetContainerTagsReply proto.InternalMessageInfo
+
+func (m *SetContainerTagsReply) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *SetContainerTagsReply) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ContainerInfo)(nil), "v1.ContainerInfo")
+	proto.RegisterType((*ListContainersRequest)(nil), "v1.ListContainersRequest")
+	proto.RegisterType((*ListContainersReply)(nil), "v1.ListContainersReply")
+	proto.RegisterType((*PoolUsage)(nil), "v1.PoolUsage")
+	proto.RegisterType((*GetPoolUsageRequest)(nil), "v1.GetPoolUsageRequest")
+	proto.RegisterType((*GetPoolUsageReply)(nil), "v1.GetPoolUsageReply")
+	proto.RegisterType((*ExplainPlacementRequest)(nil), "v1.ExplainPlacementRequest")
+	proto.RegisterType((*ExplainPlacementReply)(nil), "v1.ExplainPlacementReply")
+	proto.RegisterType((*RebalanceRequest)(nil), "v1.RebalanceRequest")
+	proto.RegisterType((*RebalanceReply)(nil), "v1.RebalanceReply")
+	proto.RegisterType((*SetDebugRequest)(nil), "v1.SetDebugRequest")
+	proto.RegisterType((*SetDebugReply)(nil), "v1.SetDebugReply")
+	proto.RegisterMapType((map[string]bool)(nil), "v1.SetDebugReply.PreviousEntry")
+	proto.RegisterType((*GetContainerTagsRequest)(nil), "v1.GetContainerTagsRequest")
+	proto.RegisterType((*GetContainerTagsReply)(nil), "v1.GetContainerTagsReply")
+	proto.RegisterMapType((map[string]string)(nil), "v1.GetContainerTagsReply.TagsEntry")
+	proto.RegisterType((*SetContainerTagsRequest)(nil), "v1.SetContainerTagsRequest")
+	proto.RegisterMapType((map[string]string)(nil), "v1.SetContainerTagsRequest.SetEntry")
+	proto.RegisterType((*SetContainerTagsReply)(nil), "v1.SetContainerTagsReply")
+	proto.RegisterMapType((map[string]string)(nil), "v1.SetContainerTagsReply.TagsEntry")
+}
+
+func init() {
+	proto.RegisterFile("pkg/cri/resource-manager/ctl/api/v1/api.proto", fileDescriptor_fc37263829dca50e)
+}
+
+var fileDescriptor_fc37263829dca50e = []byte{
+	// 910 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xcd, 0x72, 0x1b, 0x45,
+	0x10, 0x66, 0x57, 0xfe, 0xd1, 0xb6, 0x2d, 0x61, 0x8f, 0xa3, 0x68, 0xad, 0xa4, 0x88, 0x91, 0x09,
+	0x95, 0x4b, 0xa4, 0x52, 0xa0, 0x62, 0x8a, 0x5c, 0x28, 0x44, 0x08, 0xa9, 0xca, 0xc1, 0xb5, 0x82,
+	0x0b, 0x17, 0xd5, 0x68, 0x34, 0x11, 0x5b, 0x5e, 0xed, 0x8c, 0x67, 0x67, 0x55, 0xd1, 0x8d, 0x2b,
+	0x0f, 0xc0, 0x99, 0xc7, 0xe0, 0xc8, 0x0b, 0xf1, 0x10, 0x54, 0xcf, 0xcc, 0xae, 0xfe, 0xd6, 0x10,
+	0xb8, 0x70, 0xd2, 0xf4, 0xd7, 0x3f, 0xd3, 0xfd, 0xf5, 0x74, 0xaf, 0xe0, 0xa9, 0xbc, 0x99, 0xf5,
+	0x99, 0x8a, 0xfb, 0x8a, 0x67, 0x22, 0x57, 0x8c, 0x3f, 0x9d, 0xd3, 0x94, 0xce, 0xb8, 0xea, 0x33,
+	0x9d, 0xf4, 0xa9, 0x8c, 0xfb, 0x8b, 0x01, 0xfe, 0xf4, 0xa4, 0x12, 0x5a, 0x10, 0x7f, 0x31, 0xe8,
+	0xfe, 0xee, 0x43, 0x63, 0x28, 0x52, 0x4d, 0xe3, 0x94, 0xab, 0xd7, 0xe9, 0x5b, 0x41, 0x1e, 0x42,
+	0x90, 0xd2, 0x39, 0xcf, 0x24, 0x65, 0x3c, 0xf4, 0x2e, 0xbc, 0x27, 0x41, 0xb4, 0x02, 0xc8, 0x09,
+	0xd4, 0xa4, 0x98, 0x86, 0xbe, 0xc1, 0xf1, 0x48, 0x08, 0xec, 0xa1, 0x3a, 0xac, 0x19, 0xc8, 0x9c,
+	0x49, 0x13, 0xfc, 0x78, 0x1a, 0xee, 0x19, 0xc4, 0x8f, 0xa7, 0xe4, 0x1c, 0xea, 0x8c, 0xb2, 0x9f,
+	0xf8, 0x38, 0x9e, 0x86, 0xfb, 0x06, 0x3d, 0x34, 0xf2, 0xeb, 0x29, 0xb9, 0x07, 0xfb, 0x99, 0xa6,
+	0x9a, 0x87, 0x07, 0x06, 0xb7, 0x02, 0x79, 0x00, 0xc1, 0xad, 0xc8, 0xc6, 0x2c, 0xa1, 0x59, 0x16,
+	0x1e, 0x1a, 0x4d, 0xfd, 0x56, 0x64, 0x43, 0x94, 0xc9, 0x7d, 0x38, 0x60, 0x32, 0xcf, 0xb8, 0x0e,
+	0xeb, 0x46, 0xe3, 0x24, 0xc4, 0xe7, 0x7c, 0x8e, 0x78, 0x60, 0x71, 0x2b, 0x61, 0x86, 0x52, 0x88,
+	0x24, 0x04, 0x9b, 0x21, 0x9e, 0xf1, 0x02, 0x35, 0xd5, 0xee, 0x82, 0x23, 0x7b, 0x81, 0x9a, 0x6a,
+	0x7b, 0xc1, 0x25, 0x34, 0x26, 0x89, 0x60, 0x37, 0xb1, 0x70, 0x06, 0xc7, 0xc6, 0xe0, 0xd8, 0x81,
+	0xc6, 0xa8, 0xdb, 0x86, 0xd6, 0x9b, 0x38, 0xd3, 0x25, 0x79, 0x59, 0xc4, 0x6f, 0x73, 0x9e, 0xe9,
+	0xee, 0x77, 0x70, 0xb6, 0xad, 0x90, 0xc9, 0x92, 0x0c, 0x00, 0x58, 0x09, 0x85, 0xde, 0x45, 0xed,
+	0xc9, 0xd1, 0xb3, 0xd3, 0xde, 0x62, 0xd0, 0xdb, 0xa0, 0x3f, 0x5a, 0x33, 0xea, 0xfe, 0xea, 0x43,
+	0x70, 0x2d, 0x44, 0xf2, 0x43, 0x46, 0x67, 0xbc, 0x24, 0xda, 0x5b, 0x23, 0xfa, 0x3e, 0x1c, 0x48,
+	0xaa, 0x78, 0xaa, 0x5d, 0x47, 0x9c, 0x84, 0xb6, 0x48, 0x4a, 0xd1, 0x14, 0x3c, 0x3b, 0x7a, 0x84,
+	0x5a, 0xba, 0xc6, 0x38, 0x89, 0x7c, 0x0c, 0xc7, 0x4c, 0xe6, 0x63, 0x46, 0x25, 0x65, 0xb1, 0x5e,
+	0x9a, 0x06, 0xd5, 0xa2, 0x23, 0x26, 0xf3, 0xa1, 0x83, 0xc8, 0x23, 0x40, 0x71, 0xac, 0x6c, 0x85,
+	0xa6, 0x55, 0xb5, 0x08, 0x98, 0xcc, 0x5d, 0xcd, 0xe4, 0x31, 0x34, 0xf9, 0x3b, 0x96, 0xe4, 0x59,
+	0xbc, 0xe0, 0x63, 0x73, 0xb3, 0x6d, 0x5a, 0xa3, 0x44, 0x87, 0x98, 0xc2, 0x63, 0x68, 0xda, 0x4b,
+	0xcb, 0x50, 0x75, 0x13, 0xaa, 0x61, 0xd1, 0x22, 0xda, 0x47, 0x1b, 0x54, 0x61, 0x33, 0xf7, 0x37,
+	0x78, 0x69, 0xc1, 0xd9, 0x2b, 0xae, 0x4b, 0x66, 0x0a, 0xe2, 0xdf, 0xc2, 0xe9, 0x26, 0x8c, 0xb4,
+	0x23, 0x43, 0x22, 0x89, 0xd9, 0xd2, 0xf1, 0xe6, 0x24, 0x72, 0x09, 0xfb, 0xf8, 0x10, 0xb2, 0xd0,
+	0x37, 0x9d, 0x68, 0x60, 0x27, 0x56, 0xae, 0x56, 0x87, 0x8f, 0x93, 0x2b, 0x25, 0x94, 0xe3, 0xd1,
+	0x0a, 0xdd, 0x2b, 0x68, 0xbf, 0x7c, 0x27, 0x13, 0x1a, 0xa7, 0xd7, 0x09, 0x65, 0x7c, 0xce, 0x53,
+	0x5d, 0x64, 0xfe, 0x10, 0x82, 0x32, 0xcf, 0x62, 0x78, 0x4a, 0xa0, 0xfb, 0xb3, 0x07, 0xad, 0x5d,
+	0x4f, 0xcc, 0xb2, 0xbf, 0xed, 0x57, 0xf9, 0x36, 0x56, 0x36, 0xe4, 0x02, 0x8e, 0x38, 0x46, 0x4a,
+	0xa9, 0x8e, 0x45, 0x6a, 0x8a, 0x08, 0xa2, 0x75, 0xe8, 0x8e, 0xdc, 0x09, 0x9c, 0x44, 0x7c, 0x42,
+	0x13, 0x9a, 0xb2, 0x92, 0xb7, 0x4f, 0xa1, 0xb9, 0x86, 0x61, 0x3a, 0xa5, 0xaf, 0xb7, 0xee, 0x3b,
+	0x84, 0x0f, 0x47, 0x5c, 0x7f, 0xc3, 0x27, 0xf9, 0xac, 0xa8, 0x37, 0x84, 0x43, 0xbb, 0x69, 0xec,
+	0x8b, 0x0e, 0xa2, 0x42, 0x44, 0xde, 0x79, 0x4a, 0x27, 0x09, 0x37, 0x2f, 0xb3, 0x1e, 0x39, 0xa9,
+	0xfb, 0x8b, 0x07, 0x8d, 0x55, 0x14, 0xbc, 0xec, 0x05, 0xd4, 0xa5, 0xe2, 0x8b, 0x58, 0xe4, 0xc5,
+	0x58, 0x3c, 0xc2, 0xd2, 0x37, 0x8c, 0x7a, 0xd7, 0xce, 0xe2, 0x65, 0xaa, 0xd5, 0x32, 0x2a, 0x1d,
+	0x3a, 0x2f, 0xa0, 0xb1, 0xa1, 0xc2, 0x05, 0x75, 0xc3, 0x8b, 0x66, 0xe3, 0x11, 0x8b, 0x59, 0xd0,
+	0x24, 0x2f, 0x12, 0xb1, 0xc2, 0x97, 0xfe, 0x17, 0x1e, 0x36, 0xf2, 0x15, 0x5f, 0x0d, 0xea, 0xf7,
+	0x74, 0x96, 0xbd, 0x5f, 0x23, 0x7f, 0xf3, 0xa0, 0xb5, 0xeb, 0x89, 0xc5, 0x5c, 0xc1, 0x9e, 0xa6,
+	0xb3, 0xa2, 0x90, 0x4b, 0x2c, 0xa4, 0xd2, 0xb0, 0x87, 0x27, 0x5b, 0x8c, 0x71, 0x58, 0x51, 0xee,
+	0xaf, 0x51, 0xde, 0xb9, 0x82, 0xa0, 0x34, 0xfc, 0xa7, 0xd2, 0x82, 0xf5, 0xd2, 0xfe, 0xf0, 0xa0,
+	0x3d, 0xfa, 0x2f, 0xb5, 0x91, 0xe7, 0x50, 0xc3, 0x15, 0x6a, 0xc7, 0xe2, 0x13, 0xd7, 0x89, 0xaa,
+	0x38, 0x88, 0xdb, 0x0a, 0x6a, 0x6e, 0xfb, 0x4e, 0x79, 0xc2, 0x35, 0x7e, 0x09, 0xf0, 0x25, 0x38,
+	0xa9, 0xf3, 0x1c, 0xea, 0x85, 0xe1, 0xbf, 0xaa, 0x00, 0x39, 0x1e, 0xbd, 0x2f, 0xc7, 0xa3, 0xff,
+	0x81, 0xe3, 0x67, 0x7f, 0xd6, 0xe0, 0x10, 0x6f, 0x55, 0x22, 0x21, 0xdf, 0x42, 0x73, 0x73, 0xe9,
+	0x93, 0x73, 0xcc, 0xab, 0xf2, 0x0b, 0xd1, 0x69, 0x57, 0xa9, 0x64, 0xb2, 0xec, 0x7e, 0x40, 0xbe,
+	0x82, 0xe3, 0xf5, 0x1d, 0x46, 0xda, 0xee, 0x05, 0x6d, 0x2f, 0xbb, 0x4e, 0x6b, 0x57, 0x61, 0x23,
+	0xbc, 0x81, 0x93, 0xed, 0x1d, 0x43, 0x1e, 0xa0, 0xf1, 0x1d, 0x3b, 0xab, 0x73, 0x5e, 0xad, 0xb4,
+	0xd1, 0xae, 0x20, 0x28, 0x77, 0x03, 0xb9, 0x87, 0x96, 0xdb, 0xeb, 0xa3, 0x43, 0xb6, 0x50, 0xeb,
+	0xf8, 0x39, 0xd4, 0x8b, 0x09, 0x26, 0x67, 0x9b, 0xf3, 0x6c, 0xdd, 0x4e, 0x77, 0x86, 0xdc, 0x26,
+	0xbf, 0x3d, 0x2e, 0x36, 0xf9, 0x3b, 0xe6, 0xb4, 0x73, 0x5e, 0xad, 0x2c, 0xa3, 0x8d, 0x2a, 0xa3,
+	0x8d, 0xfe, 0x2e, 0xda, 0xa8, 0x3a, 0xda, 0xd7, 0x7b, 0x3f, 0xfa, 0x8b, 0xc1, 0xe4, 0xc0, 0xfc,
+	0x77, 0xfa, 0xec, 0xaf, 0x01, 0x00, 0xe5, 0xee, 0xd1, 0x4d, 0x6c, 0x09, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ControlClient is the client API for Control service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ControlClient interface {
+	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersReply, error)
+	GetPoolUsage(ctx context.Context, in *GetPoolUsageRequest, opts ...grpc.CallOption) (*GetPoolUsageReply, error)
+	ExplainPlacement(ctx context.Context, in *ExplainPlacementRequest, opts ...grpc.CallOption) (*ExplainPlacementReply, error)
+	Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceReply, error)
+	SetDebug(ctx context.Context, in *SetDebugRequest, opts ...grpc.CallOption) (*SetDebugReply, error)
+	GetContainerTags(ctx context.Context, in *GetContainerTagsRequest, opts ...grpc.CallOption) (*GetContainerTagsReply, error)
+	SetContainerTags(ctx context.Context, in *SetContainerTagsRequest, opts ...grpc.CallOption) (*SetContainerTagsReply, error)
+}
+
+type controlClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewControlClient(cc *grpc.ClientConn) ControlClient {
+	return &controlClient{cc}
+}
+
+func (c *controlClient) ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersReply, error) {
+	out := new(ListContainersReply)
+	err := c.cc.Invoke(ctx, "/v1.Control/ListContainers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetPoolUsage(ctx context.Context, in *GetPoolUsageRequest, opts ...grpc.CallOption) (*GetPoolUsageReply, error) {
+	out := new(GetPoolUsageReply)
+	err := c.cc.Invoke(ctx, "/v1.Control/GetPoolUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ExplainPlacement(ctx context.Context, in *ExplainPlacementRequest, opts ...grpc.CallOption) (*ExplainPlacementReply, error) {
+	out := new(ExplainPlacementReply)
+	err := c.cc.Invoke(ctx, "/v1.Control/ExplainPlacement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceReply, error) {
+	out := new(RebalanceReply)
+	err := c.cc.Invoke(ctx, "/v1.Control/Rebalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SetDebug(ctx context.Context, in *SetDebugRequest, opts ...grpc.CallOption) (*SetDebugReply, error) {
+	out := new(SetDebugReply)
+	err := c.cc.Invoke(ctx, "/v1.Control/SetDebug", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) GetContainerTags(ctx context.Context, in *GetContainerTagsRequest, opts ...grpc.CallOption) (*GetContainerTagsReply, error) {
+	out := new(GetContainerTagsReply)
+	err := c.cc.Invoke(ctx, "/v1.Control/GetContainerTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) SetContainerTags(ctx context.Context, in *SetContainerTagsRequest, opts ...grpc.CallOption) (*SetContainerTagsReply, error) {
+	out := new(SetContainerTagsReply)
+	err := c.cc.Invoke(ctx, "/v1.Control/SetContainerTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ControlServer is the server API for Control service.
+type ControlServer interface {
+	ListContainers(context.Context, *ListContainersRequest) (*ListContainersReply, error)
+	GetPoolUsage(context.Context, *GetPoolUsageRequest) (*GetPoolUsageReply, error)
+	ExplainPlacement(context.Context, *ExplainPlacementRequest) (*ExplainPlacementReply, error)
+	Rebalance(context.Context, *RebalanceRequest) (*RebalanceReply, error)
+	SetDebug(context.Context, *SetDebugRequest) (*SetDebugReply, error)
+	GetContainerTags(context.Context, *GetContainerTagsRequest) (*GetContainerTagsReply, error)
+	SetContainerTags(context.Context, *SetContainerTagsRequest) (*SetContainerTagsReply, error)
+}
+
+// UnimplementedControlServer can be embedded to have forward compatible implementations.
+type UnimplementedControlServer struct {
+}
+
+func (*UnimplementedControlServer) ListContainers(ctx context.Context, req *ListContainersRequest) (*ListContainersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContainers not implemented")
+}
+func (*UnimplementedControlServer) GetPoolUsage(ctx context.Context, req *GetPoolUsageRequest) (*GetPoolUsageReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoolUsage not implemented")
+}
+func (*UnimplementedControlServer) ExplainPlacement(ctx context.Context, req *ExplainPlacementRequest) (*ExplainPlacementReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainPlacement not implemented")
+}
+func (*UnimplementedControlServer) Rebalance(ctx context.Context, req *RebalanceRequest) (*RebalanceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rebalance not implemented")
+}
+func (*UnimplementedControlServer) SetDebug(ctx context.Context, req *SetDebugRequest) (*SetDebugReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDebug not implemented")
+}
+func (*UnimplementedControlServer) GetContainerTags(ctx context.Context, req *GetContainerTagsRequest) (*GetContainerTagsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerTags not implemented")
+}
+func (*UnimplementedControlServer) SetContainerTags(ctx context.Context, req *SetContainerTagsRequest) (*SetContainerTagsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetContainerTags not implemented")
+}
+
+func RegisterControlServer(s *grpc.Server, srv ControlServer) {
+	s.RegisterService(&_Control_serviceDesc, srv)
+}
+
+func _Control_ListContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ListContainers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Control/ListContainers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ListContainers(ctx, req.(*ListContainersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_GetPoolUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPoolUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GetPoolUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Control/GetPoolUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GetPoolUsage(ctx, req.(*GetPoolUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_ExplainPlacement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainPlacementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ExplainPlacement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Control/ExplainPlacement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ExplainPlacement(ctx, req.(*ExplainPlacementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Rebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Rebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Control/Rebalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Rebalance(ctx, req.(*RebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SetDebug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDebugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).SetDebug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Control/SetDebug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).SetDebug(ctx, req.(*SetDebugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_GetContainerTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).GetContainerTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Control/GetContainerTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).GetContainerTags(ctx, req.(*GetContainerTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_SetContainerTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetContainerTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).SetContainerTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.Control/SetContainerTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).SetContainerTags(ctx, req.(*SetContainerTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Control_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.Control",
+	HandlerType: (*ControlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListContainers",
+			Handler:    _Control_ListContainers_Handler,
+		},
+		{
+			MethodName: "GetPoolUsage",
+			Handler:    _Control_GetPoolUsage_Handler,
+		},
+		{
+			MethodName: "ExplainPlacement",
+			Handler:    _Control_ExplainPlacement_Handler,
+		},
+		{
+			MethodName: "Rebalance",
+			Handler:    _Control_Rebalance_Handler,
+		},
+		{
+			MethodName: "SetDebug",
+			Handler:    _Control_SetDebug_Handler,
+		},
+		{
+			MethodName: "GetContainerTags",
+			Handler:    _Control_GetContainerTags_Handler,
+		},
+		{
+			MethodName: "SetContainerTags",
+			Handler:    _Control_SetContainerTags_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/cri/resource-manager/ctl/api/v1/api.proto",
+}
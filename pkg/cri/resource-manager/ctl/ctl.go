@@ -0,0 +1,68 @@
+/*
+Copyright 2020 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctl
+
+// ContainerInfo describes a container and its current resource assignment.
+type ContainerInfo struct {
+	Namespace    string // namespace of the pod of the container
+	Pod          string // name of the pod of the container
+	Name         string // name of the container
+	ID           string // container runtime ID
+	CacheID      string // container cache ID
+	State        string // container runtime state
+	QOSClass     string // QoS class of the pod
+	Cpuset       string // CPUs the container is allowed to run on
+	Memset       string // memory nodes the container is allowed to use
+	Pool         string // pool the container is assigned to
+	RDTClass     string // RDT class of the container
+	BlockIOClass string // block I/O class of the container
+}
+
+// PoolUsage describes the capacity and usage of a single pool.
+type PoolUsage struct {
+	Name          string // name of the pool
+	Parent        string // name of the parent pool, if any
+	CPUs          string // CPUs of the pool
+	Memory        string // memory nodes of the pool
+	CPUCapacity   int64  // CPU capacity in milli-CPU
+	CPURequest    int64  // total CPU request of containers in milli-CPU
+	ExclusiveCPUs string // CPUs exclusively allocated to containers
+	MemoryRequest int64  // total memory request of containers in bytes
+	Containers    int    // number of containers in the pool
+}
+
+// Placement explains the placement of a container.
+type Placement struct {
+	Container   *ContainerInfo // container being explained
+	Explanation []string       // facts affecting the placement, one per line
+}
+
+// Backend is the interface the resource manager implements for the control server.
+type Backend interface {
+	// ListContainerInfo returns all containers with their resource assignments.
+	ListContainerInfo() []*ContainerInfo
+	// GetPoolUsage returns the active policy and the usage of its pools.
+	GetPoolUsage() (string, []*PoolUsage, error)
+	// ExplainPlacement explains the placement of the given container.
+	ExplainPlacement(container string) (*Placement, error)
+	// RebalanceContainers triggers a rebalancing of containers.
+	RebalanceContainers() error
+	// GetContainerTags returns the tags of the given container.
+	GetContainerTags(container string) (map[string]string, error)
+	// SetContainerTags sets and deletes tags of the given container.
+	SetContainerTags(container string, set map[string]string, del []string) (map[string]string, error)
+}
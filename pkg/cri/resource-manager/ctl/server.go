@@ -0,0 +1,241 @@
+/*
+Copyright 2020 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc"
+
+	"github.com/intel/cri-resource-manager/pkg/auth"
+	v1 "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/ctl/api/v1"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/sockets"
+	"github.com/intel/cri-resource-manager/pkg/log"
+)
+
+// Server is the interface for our gRPC control server.
+type Server interface {
+	Start(string) error
+	Stop()
+}
+
+// server implements Server.
+type server struct {
+	log.Logger
+	server  *grpc.Server // gRPC server instance
+	backend Backend      // resource manager serving requests
+}
+
+// NewControlServer creates a new control Server instance.
+func NewControlServer(backend Backend) (Server, error) {
+	s := &server{
+		Logger:  log.NewLogger("control-server"),
+		backend: backend,
+	}
+	return s, nil
+}
+
+// Start runs server instance.
+func (s *server) Start(socket string) error {
+	if err := os.MkdirAll(filepath.Dir(socket), sockets.DirPermissions); err != nil {
+		return serverError("failed to create directory for socket %s: %v",
+			socket, err)
+	}
+
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		return serverError("failed to unlink socket file: %s", err)
+	}
+
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		return serverError("failed to listen to socket: %v", err)
+	}
+
+	serverOpts, err := auth.ServerOptions(auth.ControlSocket)
+	if err != nil {
+		lis.Close()
+		return serverError("failed to set up socket access control: %v", err)
+	}
+	s.server = grpc.NewServer(serverOpts...)
+	v1.RegisterControlServer(s.server, s)
+
+	s.Info("starting control-server at socket %s...", socket)
+	go func() {
+		defer lis.Close()
+		err := s.server.Serve(lis)
+		if err != nil {
+			s.Fatal("control-server died: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop Server instance.
+func (s *server) Stop() {
+	if s.server != nil {
+		s.server.Stop()
+		s.server = nil
+	}
+}
+
+// ListContainers lists all containers with their resource assignments.
+func (s *server) ListContainers(ctx context.Context, req *v1.ListContainersRequest) (*v1.ListContainersReply, error) {
+	s.Debug("ListContainers request: %+v", req)
+
+	reply := &v1.ListContainersReply{}
+	for _, c := range s.backend.ListContainerInfo() {
+		reply.Containers = append(reply.Containers, containerInfo(c))
+	}
+
+	return reply, nil
+}
+
+// GetPoolUsage returns the usage of the pools of the active policy.
+func (s *server) GetPoolUsage(ctx context.Context, req *v1.GetPoolUsageRequest) (*v1.GetPoolUsageReply, error) {
+	s.Debug("GetPoolUsage request: %+v", req)
+
+	reply := &v1.GetPoolUsageReply{}
+	policy, pools, err := s.backend.GetPoolUsage()
+	if err != nil {
+		reply.Error = fmt.Sprintf("failed to get pool usage: %v", err)
+		return reply, nil
+	}
+
+	reply.Policy = policy
+	for _, p := range pools {
+		reply.Pools = append(reply.Pools, &v1.PoolUsage{
+			Name:          p.Name,
+			Parent:        p.Parent,
+			Cpus:          p.CPUs,
+			Memory:        p.Memory,
+			CpuCapacity:   p.CPUCapacity,
+			CpuRequest:    p.CPURequest,
+			ExclusiveCpus: p.ExclusiveCPUs,
+			MemoryRequest: p.MemoryRequest,
+			Containers:    int32(p.Containers),
+		})
+	}
+
+	return reply, nil
+}
+
+// ExplainPlacement explains the placement of a container.
+func (s *server) ExplainPlacement(ctx context.Context, req *v1.ExplainPlacementRequest) (*v1.ExplainPlacementReply, error) {
+	s.Debug("ExplainPlacement request: %+v", req)
+
+	reply := &v1.ExplainPlacementReply{}
+	placement, err := s.backend.ExplainPlacement(req.Container)
+	if err != nil {
+		reply.Error = fmt.Sprintf("failed to explain placement of %q: %v", req.Container, err)
+		return reply, nil
+	}
+
+	reply.Container = containerInfo(placement.Container)
+	reply.Explanation = placement.Explanation
+
+	return reply, nil
+}
+
+// Rebalance triggers a rebalancing of containers.
+func (s *server) Rebalance(ctx context.Context, req *v1.RebalanceRequest) (*v1.RebalanceReply, error) {
+	s.Debug("Rebalance request: %+v", req)
+
+	reply := &v1.RebalanceReply{}
+	if err := s.backend.RebalanceContainers(); err != nil {
+		reply.Error = fmt.Sprintf("failed to rebalance containers: %v", err)
+	}
+
+	return reply, nil
+}
+
+// SetDebug enables or disables debug logging for logger sources.
+func (s *server) SetDebug(ctx context.Context, req *v1.SetDebugRequest) (*v1.SetDebugReply, error) {
+	s.Debug("SetDebug request: %+v", req)
+
+	reply := &v1.SetDebugReply{Previous: make(map[string]bool)}
+	for _, source := range req.Sources {
+		if req.Enable {
+			reply.Previous[source] = log.EnableDebug(source)
+			s.Info("enabled debug logging for %s", source)
+		} else {
+			reply.Previous[source] = log.DisableDebug(source)
+			s.Info("disabled debug logging for %s", source)
+		}
+	}
+
+	return reply, nil
+}
+
+// GetContainerTags returns the tags of a container.
+func (s *server) GetContainerTags(ctx context.Context, req *v1.GetContainerTagsRequest) (*v1.GetContainerTagsReply, error) {
+	s.Debug("GetContainerTags request: %+v", req)
+
+	reply := &v1.GetContainerTagsReply{}
+	tags, err := s.backend.GetContainerTags(req.Container)
+	if err != nil {
+		reply.Error = fmt.Sprintf("failed to get tags of %q: %v", req.Container, err)
+		return reply, nil
+	}
+	reply.Tags = tags
+
+	return reply, nil
+}
+
+// SetContainerTags sets and deletes tags of a container.
+func (s *server) SetContainerTags(ctx context.Context, req *v1.SetContainerTagsRequest) (*v1.SetContainerTagsReply, error) {
+	s.Debug("SetContainerTags request: %+v", req)
+
+	reply := &v1.SetContainerTagsReply{}
+	tags, err := s.backend.SetContainerTags(req.Container, req.Set, req.Delete)
+	if err != nil {
+		reply.Error = fmt.Sprintf("failed to set tags of %q: %v", req.Container, err)
+		return reply, nil
+	}
+	reply.Tags = tags
+
+	return reply, nil
+}
+
+// containerInfo converts ContainerInfo to its gRPC representation.
+func containerInfo(c *ContainerInfo) *v1.ContainerInfo {
+	if c == nil {
+		return nil
+	}
+	return &v1.ContainerInfo{
+		Namespace:    c.Namespace,
+		Pod:          c.Pod,
+		Name:         c.Name,
+		Id:           c.ID,
+		CacheId:      c.CacheID,
+		State:        c.State,
+		QosClass:     c.QOSClass,
+		Cpuset:       c.Cpuset,
+		Memset:       c.Memset,
+		Pool:         c.Pool,
+		RdtClass:     c.RDTClass,
+		BlockioClass: c.BlockIOClass,
+	}
+}
+
+// serverError returns a formatted error specific to the control server.
+func serverError(format string, args ...interface{}) error {
+	return fmt.Errorf("control-server: "+format, args...)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2021 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctl
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	v1 "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/ctl/api/v1"
+)
+
+// fakeBackend is a Backend serving a single container.
+type fakeBackend struct {
+	tags map[string]string
+}
+
+var testContainer = &ContainerInfo{
+	Namespace: "default",
+	Pod:       "pod0",
+	Name:      "ctr0",
+	ID:        "ctr-id-0",
+	Cpuset:    "0-1",
+	Pool:      "shared",
+}
+
+func (b *fakeBackend) ListContainerInfo() []*ContainerInfo {
+	return []*ContainerInfo{testContainer}
+}
+
+func (b *fakeBackend) GetPoolUsage() (string, []*PoolUsage, error) {
+	return "", nil, fmt.Errorf("no active policy")
+}
+
+func (b *fakeBackend) ExplainPlacement(container string) (*Placement, error) {
+	if container != testContainer.ID {
+		return nil, fmt.Errorf("container %q not found", container)
+	}
+	return &Placement{Container: testContainer, Explanation: []string{"assigned to pool shared"}}, nil
+}
+
+func (b *fakeBackend) RebalanceContainers() error {
+	return nil
+}
+
+func (b *fakeBackend) GetContainerTags(container string) (map[string]string, error) {
+	return b.tags, nil
+}
+
+func (b *fakeBackend) SetContainerTags(container string, set map[string]string, del []string) (map[string]string, error) {
+	for _, key := range del {
+		delete(b.tags, key)
+	}
+	for key, value := range set {
+		b.tags[key] = value
+	}
+	return b.tags, nil
+}
+
+func TestControlServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "control-server-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "sockets", "control.sock")
+	backend := &fakeBackend{tags: map[string]string{"team": "a"}}
+	srv, err := NewControlServer(backend)
+	if err != nil {
+		t.Fatalf("failed to create control server: %v", err)
+	}
+	if err := srv.Start(socket); err != nil {
+		t.Fatalf("failed to start control server: %v", err)
+	}
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(socket, grpc.WithInsecure(),
+		grpc.WithDialer(func(sock string, timeout time.Duration) (net.Conn, error) {
+			return net.Dial("unix", sock)
+		}))
+	if err != nil {
+		t.Fatalf("failed to connect to control server: %v", err)
+	}
+	defer conn.Close()
+	cli := v1.NewControlClient(conn)
+	ctx := context.Background()
+
+	list, err := cli.ListContainers(ctx, &v1.ListContainersRequest{})
+	if err != nil {
+		t.Fatalf("ListContainers failed: %v", err)
+	}
+	if len(list.Containers) != 1 || list.Containers[0].Id != testContainer.ID ||
+		list.Containers[0].Pool != testContainer.Pool {
+		t.Errorf("unexpected containers %v", list.Containers)
+	}
+
+	usage, err := cli.GetPoolUsage(ctx, &v1.GetPoolUsageRequest{})
+	if err != nil || !strings.Contains(usage.Error, "no active policy") {
+		t.Errorf("expected pool usage error in reply, got %v, %v", usage, err)
+	}
+
+	explain, err := cli.ExplainPlacement(ctx, &v1.ExplainPlacementRequest{Container: testContainer.ID})
+	if err != nil || explain.Error != "" || len(explain.Explanation) != 1 {
+		t.Errorf("unexpected placement explanation %v, %v", explain, err)
+	}
+	explain, err = cli.ExplainPlacement(ctx, &v1.ExplainPlacementRequest{Container: "missing"})
+	if err != nil || explain.Error == "" {
+		t.Errorf("expected explanation error in reply, got %v, %v", explain, err)
+	}
+
+	tags, err := cli.SetContainerTags(ctx, &v1.SetContainerTagsRequest{
+		Container: testContainer.ID,
+		Set:       map[string]string{"tier": "frontend"},
+		Delete:    []string{"team"},
+	})
+	if err != nil || !reflect.DeepEqual(tags.Tags, map[string]string{"tier": "frontend"}) {
+		t.Errorf("unexpected tags after update %v, %v", tags, err)
+	}
+
+	if err := srv.Start(filepath.Join(dir, "missing", "\x00")); err == nil ||
+		!strings.HasPrefix(err.Error(), "control-server: ") {
+		t.Errorf("expected a control-server error, got %v", err)
+	}
+
+	info, err := os.Stat(filepath.Dir(socket))
+	if err != nil {
+		t.Fatalf("failed to stat socket directory: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0711 {
+		t.Errorf("expected socket directory permissions 0711, got %#o", perm)
+	}
+}
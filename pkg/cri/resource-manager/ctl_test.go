@@ -0,0 +1,116 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+)
+
+func TestLookupContainer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resource-manager-ctl-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cch, err := cache.NewCache(cache.Options{CacheDir: dir})
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	m := &resmgr{Logger: logger.NewLogger("resource-manager-test"), cache: cch}
+
+	pods := []struct{ id, namespace, name, uid string }{
+		{"pod-id-0", "default", "pod0", "uid-0"},
+		{"pod-id-1", "kube-system", "pod0", "uid-1"},
+	}
+	for _, p := range pods {
+		cch.InsertPod(p.id, &criapi.RunPodSandboxRequest{
+			Config: &criapi.PodSandboxConfig{
+				Metadata: &criapi.PodSandboxMetadata{Namespace: p.namespace, Name: p.name, Uid: p.uid},
+			},
+		}, nil, nil)
+	}
+	containers := []struct{ id, pod, name string }{
+		{"abc123", "pod-id-0", "ctr0"},
+		{"abd456", "pod-id-0", "ctr1"},
+		{"xyz789", "pod-id-1", "ctr0"},
+	}
+	for _, c := range containers {
+		if _, err := cch.InsertContainer(&criapi.Container{
+			Id:           c.id,
+			PodSandboxId: c.pod,
+			Metadata:     &criapi.ContainerMetadata{Name: c.name},
+			State:        criapi.ContainerState_CONTAINER_RUNNING,
+		}); err != nil {
+			t.Fatalf("failed to insert container %s: %v", c.id, err)
+		}
+	}
+
+	tcases := []struct {
+		name        string
+		id          string
+		expected    string
+		expectError bool
+	}{
+		{name: "exact ID", id: "abc123", expected: "abc123"},
+		{name: "unique ID prefix", id: "xy", expected: "xyz789"},
+		{name: "ambiguous ID prefix", id: "ab", expectError: true},
+		{name: "pod and name", id: "pod0/ctr1", expected: "abd456"},
+		{name: "ambiguous pod and name", id: "pod0/ctr0", expectError: true},
+		{name: "namespace, pod and name", id: "kube-system/pod0/ctr0", expected: "xyz789"},
+		{name: "unknown container", id: "default/pod0/ctr2", expectError: true},
+		{name: "unknown ID", id: "nope", expectError: true},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := m.lookupContainer(tc.id)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error, got container %s", c.GetID())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.GetID() != tc.expected {
+				t.Errorf("expected container %s, got %s", tc.expected, c.GetID())
+			}
+		})
+	}
+
+	tags, err := m.SetContainerTags("pod0/ctr1", map[string]string{"tier": "frontend", "team": "a"}, nil)
+	if err != nil {
+		t.Fatalf("failed to set container tags: %v", err)
+	}
+	tags, err = m.SetContainerTags("abd", nil, []string{"team"})
+	if err != nil {
+		t.Fatalf("failed to delete container tag: %v", err)
+	}
+	if expected := map[string]string{"tier": "frontend"}; !reflect.DeepEqual(tags, expected) {
+		t.Errorf("expected tags %v, got %v", expected, tags)
+	}
+	if tags, err = m.GetContainerTags("abd456"); err != nil || tags["tier"] != "frontend" {
+		t.Errorf("expected tags to be stored in the cache, got %v, %v", tags, err)
+	}
+}
@@ -0,0 +1,426 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/intel/cri-resource-manager/pkg/cgroups"
+	pkgcfg "github.com/intel/cri-resource-manager/pkg/config"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/kubernetes"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+	"github.com/intel/cri-resource-manager/pkg/sysfs"
+	cri "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// redactedValue replaces redacted data in diagnostics.
+	redactedValue = "<redacted>"
+)
+
+// Fields of cached containers left out of redacted diagnostics altogether.
+var redactedFields = map[string]struct{}{
+	"Command": {},
+	"Args":    {},
+	"Env":     {},
+	"Mounts":  {},
+	"Devices": {},
+}
+
+// Fields of cached pods and containers with only our own values left in redacted diagnostics.
+var redactedValueFields = map[string]struct{}{
+	"Labels":      {},
+	"Annotations": {},
+}
+
+// systemDiagnostics describes the discovered hardware topology.
+type systemDiagnostics struct {
+	CPUs     string
+	Isolated string
+	Offlined string
+	Packages []packageDiagnostics
+	Nodes    []nodeDiagnostics
+}
+
+// packageDiagnostics describes a single CPU package.
+type packageDiagnostics struct {
+	ID    sysfs.ID
+	CPUs  string
+	Nodes []sysfs.ID
+	Dies  []sysfs.ID
+}
+
+// nodeDiagnostics describes a single NUMA node.
+type nodeDiagnostics struct {
+	ID         sysfs.ID
+	Package    sysfs.ID
+	Die        sysfs.ID
+	CPUs       string
+	MemoryType string
+	Normal     bool
+	Distance   []int
+	MemTotal   uint64 `json:",omitempty"`
+}
+
+// containerDiagnostics describes the actual resource assignment of a single container.
+type containerDiagnostics struct {
+	Pod          string
+	Namespace    string
+	Name         string
+	ID           string
+	CacheID      string
+	State        string
+	QOSClass     string
+	CgroupDir    string
+	CpusetCpus   string
+	CpusetMems   string
+	RDTClass     string
+	BlockIOClass string
+}
+
+// diagnostics is a diagnostics bundle being collected.
+type diagnostics struct {
+	buf    bytes.Buffer
+	gz     *gzip.Writer
+	tw     *tar.Writer
+	now    time.Time
+	errors []string
+}
+
+// CollectDiagnostics collects the state of the resource manager into a gzipped tarball.
+// If redact is true, data like container commands, environment variables, mounts, and
+// the values of labels and annotations not used by cri-resmgr are left out, both from
+// the cached state and from the current and past configuration, and logs are left out
+// altogether.
+func (m *resmgr) CollectDiagnostics(redact bool) ([]byte, error) {
+	m.Info("collecting diagnostics (redact: %v)...", redact)
+
+	d := newDiagnostics()
+
+	// Take a snapshot of our state under the lock, then do the rest without it.
+	var (
+		introspection, controllers       interface{}
+		introspectionErr, controllersErr error
+	)
+
+	m.RLock()
+	snapshot, snapshotErr := m.cache.Snapshot()
+	cfg, cfgErr := pkgcfg.GetConfig()
+	history := m.cache.GetConfigHistory()
+	if m.policy != nil {
+		introspection = m.policy.Introspect()
+	} else {
+		introspectionErr = resmgrError("no active policy")
+	}
+	if m.control != nil {
+		controllers = m.control.GetControllerStates()
+	} else {
+		controllersErr = resmgrError("no controllers")
+	}
+	containers := m.collectContainers()
+	m.RUnlock()
+
+	d.addJSON("system.json", collectSystem)
+
+	d.addJSON("cache.json", func() (interface{}, error) {
+		if snapshotErr != nil || !redact {
+			return json.RawMessage(snapshot), snapshotErr
+		}
+		return redactJSON(snapshot)
+	})
+
+	d.addJSON("config.json", func() (interface{}, error) {
+		if cfgErr != nil {
+			return nil, cfgErr
+		}
+		smap, err := cfg.StringMap()
+		if err != nil || !redact {
+			return smap, err
+		}
+		return redactConfig(smap)
+	})
+
+	d.addJSON("config-history.json", func() (interface{}, error) {
+		if !redact {
+			return history, nil
+		}
+		return redactConfigHistory(history)
+	})
+
+	d.addJSON("introspection.json", func() (interface{}, error) {
+		return introspection, introspectionErr
+	})
+
+	d.addJSON("controllers.json", func() (interface{}, error) {
+		return controllers, controllersErr
+	})
+
+	d.addJSON("containers.json", func() (interface{}, error) {
+		for i := range containers {
+			if dir := containers[i].CgroupDir; dir != "" {
+				group := string(cgroups.Cpuset.Group(dir))
+				containers[i].CpusetCpus = readCgroupEntry(group, "cpuset.cpus")
+				containers[i].CpusetMems = readCgroupEntry(group, "cpuset.mems")
+			}
+		}
+		return containers, nil
+	})
+
+	// Log messages can contain just about anything, so we can't redact them.
+	if !redact {
+		d.addFile("logs.txt", []byte(strings.Join(logger.RecentMessages(), "\n")+"\n"))
+	}
+
+	return d.finish()
+}
+
+// collectContainers collects the resource assignments of all containers, except
+// for the actual cgroup cpusets which are left for the caller to read.
+func (m *resmgr) collectContainers() []containerDiagnostics {
+	containers := []containerDiagnostics{}
+
+	for _, c := range m.cache.GetContainers() {
+		cd := containerDiagnostics{
+			Name:         c.GetName(),
+			ID:           c.GetID(),
+			CacheID:      c.GetCacheID(),
+			State:        cri.ContainerState(c.GetState()).String(),
+			QOSClass:     string(c.GetQOSClass()),
+			CgroupDir:    c.GetCgroupDir(),
+			RDTClass:     c.GetRDTClass(),
+			BlockIOClass: c.GetBlockIOClass(),
+		}
+		if pod, ok := c.GetPod(); ok {
+			cd.Pod = pod.GetName()
+			cd.Namespace = pod.GetNamespace()
+		}
+		containers = append(containers, cd)
+	}
+
+	return containers
+}
+
+// readCgroupEntry reads a single-line cgroup entry, returning any error as the value.
+func readCgroupEntry(group, entry string) string {
+	value, err := ioutil.ReadFile(filepath.Join(group, entry))
+	if err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return strings.TrimSpace(string(value))
+}
+
+// collectSystem collects the discovered hardware topology.
+func collectSystem() (interface{}, error) {
+	sys, err := sysfs.DiscoverSystem()
+	if err != nil {
+		return nil, err
+	}
+
+	sd := &systemDiagnostics{
+		CPUs:     sys.CPUSet().String(),
+		Isolated: sys.Isolated().String(),
+		Offlined: sys.Offlined().String(),
+	}
+	for _, id := range sys.PackageIDs() {
+		pkg := sys.Package(id)
+		sd.Packages = append(sd.Packages, packageDiagnostics{
+			ID:    id,
+			CPUs:  pkg.CPUSet().String(),
+			Nodes: pkg.NodeIDs(),
+			Dies:  pkg.DieIDs(),
+		})
+	}
+	for _, id := range sys.NodeIDs() {
+		node := sys.Node(id)
+		nd := nodeDiagnostics{
+			ID:         id,
+			Package:    node.PackageID(),
+			Die:        node.DieID(),
+			CPUs:       node.CPUSet().String(),
+			MemoryType: memoryTypeName(node.GetMemoryType()),
+			Normal:     node.HasNormalMemory(),
+			Distance:   node.Distance(),
+		}
+		if info, err := node.MemoryInfo(); err == nil {
+			nd.MemTotal = info.MemTotal
+		}
+		sd.Nodes = append(sd.Nodes, nd)
+	}
+
+	return sd, nil
+}
+
+// memoryTypeName returns the name of the given memory type.
+func memoryTypeName(memType sysfs.MemoryType) string {
+	switch memType {
+	case sysfs.MemoryTypeDRAM:
+		return "DRAM"
+	case sysfs.MemoryTypePMEM:
+		return "PMEM"
+	case sysfs.MemoryTypeHBM:
+		return "HBM"
+	}
+	return fmt.Sprintf("<unknown memory type %d>", memType)
+}
+
+// redactJSON removes potentially sensitive data from a JSON-encoded cache snapshot.
+func redactJSON(data []byte) (interface{}, error) {
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return redactValue(obj), nil
+}
+
+// redactConfig removes potentially sensitive data from raw configuration data.
+func redactConfig(data map[string]string) (map[string]string, error) {
+	redacted := make(map[string]string, len(data))
+	for key, value := range data {
+		var obj interface{}
+		if err := yaml.Unmarshal([]byte(value), &obj); err != nil {
+			return nil, resmgrError("failed to parse configuration %q: %v", key, err)
+		}
+		raw, err := yaml.Marshal(redactValue(obj))
+		if err != nil {
+			return nil, resmgrError("failed to marshal configuration %q: %v", key, err)
+		}
+		redacted[key] = string(raw)
+	}
+	return redacted, nil
+}
+
+// redactConfigHistory removes potentially sensitive data from a copy of the configuration history.
+func redactConfigHistory(history []*config.ConfigVersion) ([]*config.ConfigVersion, error) {
+	redacted := make([]*config.ConfigVersion, 0, len(history))
+	for _, entry := range history {
+		ver := *entry
+		if entry.Config != nil {
+			data, err := redactConfig(entry.Config.Data)
+			if err != nil {
+				return nil, resmgrError("configuration version %d: %v", entry.Version, err)
+			}
+			ver.Config = &config.RawConfig{NodeName: entry.Config.NodeName, Data: data}
+		}
+		redacted = append(redacted, &ver)
+	}
+	return redacted, nil
+}
+
+// redactValue recursively removes potentially sensitive data from a decoded JSON value.
+func redactValue(obj interface{}) interface{} {
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, ok := redactedFields[key]; ok {
+				v[key] = redactedValue
+				continue
+			}
+			if _, ok := redactedValueFields[key]; ok {
+				if m, ok := value.(map[string]interface{}); ok {
+					for k := range m {
+						if !isResmgrKey(k) {
+							m[k] = redactedValue
+						}
+					}
+				}
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+	return obj
+}
+
+// isResmgrKey checks if a label or annotation key is used by cri-resmgr or the kubelet.
+func isResmgrKey(key string) bool {
+	switch {
+	case key == cache.KeyResourceAnnotation:
+		return true
+	case strings.HasPrefix(key, "io.kubernetes."):
+		return true
+	}
+	domain := strings.SplitN(key, "/", 2)[0]
+	return domain == kubernetes.ResmgrKeyNamespace ||
+		strings.HasSuffix(domain, "."+kubernetes.ResmgrKeyNamespace)
+}
+
+// newDiagnostics creates a new diagnostics bundle.
+func newDiagnostics() *diagnostics {
+	d := &diagnostics{now: time.Now()}
+	d.gz = gzip.NewWriter(&d.buf)
+	d.tw = tar.NewWriter(d.gz)
+	return d
+}
+
+// addJSON adds the JSON-encoded result of a collector function to the bundle.
+func (d *diagnostics) addJSON(name string, collect func() (interface{}, error)) {
+	obj, err := collect()
+	if err != nil {
+		d.errors = append(d.errors, fmt.Sprintf("%s: %v", name, err))
+		return
+	}
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		d.errors = append(d.errors, fmt.Sprintf("%s: failed to encode: %v", name, err))
+		return
+	}
+	d.addFile(name, append(data, '\n'))
+}
+
+// addFile adds a file to the bundle.
+func (d *diagnostics) addFile(name string, data []byte) {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: d.now,
+	}
+	if err := d.tw.WriteHeader(hdr); err != nil {
+		d.errors = append(d.errors, fmt.Sprintf("%s: %v", name, err))
+		return
+	}
+	if _, err := d.tw.Write(data); err != nil {
+		d.errors = append(d.errors, fmt.Sprintf("%s: %v", name, err))
+	}
+}
+
+// finish adds any errors encountered to the bundle and returns the bundle.
+func (d *diagnostics) finish() ([]byte, error) {
+	if len(d.errors) > 0 {
+		d.addFile("errors.txt", []byte(strings.Join(d.errors, "\n")+"\n"))
+	}
+	if err := d.tw.Close(); err != nil {
+		return nil, resmgrError("failed to finish diagnostics tarball: %v", err)
+	}
+	if err := d.gz.Close(); err != nil {
+		return nil, resmgrError("failed to compress diagnostics: %v", err)
+	}
+	return d.buf.Bytes(), nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+)
+
+func TestRedactValue(t *testing.T) {
+	snapshot := `{
+  "Pods": {
+    "pod0": {
+      "Labels": {
+        "app": "secret-app",
+        "io.kubernetes.pod.name": "pod0"
+      },
+      "Annotations": {
+        "intel.com/resources": "{}",
+        "prefer-isolated-cpus.cri-resource-manager.intel.com/pod": "true",
+        "evilcri-resource-manager.intel.com/pod": "secret",
+        "example.com/token": "secret"
+      }
+    }
+  },
+  "Containers": [
+    {
+      "Name": "ctr0",
+      "Command": [ "/bin/secret" ],
+      "Args": [ "--password", "secret" ],
+      "Env": { "TOKEN": "secret" },
+      "Mounts": { "/secret": {} },
+      "Devices": { "/dev/secret": {} },
+      "CpusetCpus": "0-3"
+    }
+  ]
+}`
+	expected := `{
+  "Pods": {
+    "pod0": {
+      "Labels": {
+        "app": "<redacted>",
+        "io.kubernetes.pod.name": "pod0"
+      },
+      "Annotations": {
+        "intel.com/resources": "{}",
+        "prefer-isolated-cpus.cri-resource-manager.intel.com/pod": "true",
+        "evilcri-resource-manager.intel.com/pod": "<redacted>",
+        "example.com/token": "<redacted>"
+      }
+    }
+  },
+  "Containers": [
+    {
+      "Name": "ctr0",
+      "Command": "<redacted>",
+      "Args": "<redacted>",
+      "Env": "<redacted>",
+      "Mounts": "<redacted>",
+      "Devices": "<redacted>",
+      "CpusetCpus": "0-3"
+    }
+  ]
+}`
+
+	redacted, err := redactJSON([]byte(snapshot))
+	if err != nil {
+		t.Fatalf("failed to redact snapshot: %v", err)
+	}
+	var obj interface{}
+	if err := json.Unmarshal([]byte(expected), &obj); err != nil {
+		t.Fatalf("failed to parse expected result: %v", err)
+	}
+	if !reflect.DeepEqual(redacted, obj) {
+		t.Errorf("expected redacted snapshot %v, got %v", obj, redacted)
+	}
+}
+
+func TestRedactConfigHistory(t *testing.T) {
+	entry := &config.ConfigVersion{
+		Version: 1,
+		Source:  "agent",
+		Config: &config.RawConfig{
+			NodeName: "node0",
+			Data: map[string]string{
+				"policy": "Active: topology-aware\n",
+				"test":   "Labels:\n  owner: secret\n  " + cache.RDTClassKey + ": gold\nEnv:\n  TOKEN: secret\n",
+			},
+		},
+	}
+	original := entry.Config.Data["test"]
+
+	history, err := redactConfigHistory([]*config.ConfigVersion{entry, {Version: 2}})
+	if err != nil {
+		t.Fatalf("failed to redact configuration history: %v", err)
+	}
+	if len(history) != 2 || history[0].Version != 1 || history[0].Config.NodeName != "node0" ||
+		history[1].Version != 2 || history[1].Config != nil {
+		t.Fatalf("unexpected redacted history %v", history)
+	}
+
+	data := history[0].Config.Data
+	if data["policy"] != "Active: topology-aware\n" {
+		t.Errorf("expected policy configuration intact, got %q", data["policy"])
+	}
+	if strings.Contains(data["test"], "secret") || !strings.Contains(data["test"], "gold") {
+		t.Errorf("expected only secrets redacted, got %q", data["test"])
+	}
+	if entry.Config.Data["test"] != original {
+		t.Errorf("redaction modified the original history entry")
+	}
+
+	if _, err := redactConfig(map[string]string{"broken": "{"}); err == nil {
+		t.Errorf("expected an error for unparsable configuration")
+	}
+}
+
+func TestCollectDiagnostics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "diagnostics-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cch, err := cache.NewCache(cache.Options{CacheDir: dir})
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	m := &resmgr{Logger: logger.NewLogger("resource-manager-test"), cache: cch}
+
+	bundleFiles := func(redact bool) map[string]struct{} {
+		bundle, err := m.CollectDiagnostics(redact)
+		if err != nil {
+			t.Fatalf("failed to collect diagnostics: %v", err)
+		}
+		gz, err := gzip.NewReader(bytes.NewReader(bundle))
+		if err != nil {
+			t.Fatalf("failed to decompress diagnostics: %v", err)
+		}
+		files := map[string]struct{}{}
+		tr := tar.NewReader(gz)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("failed to read diagnostics: %v", err)
+			}
+			files[hdr.Name] = struct{}{}
+		}
+		return files
+	}
+
+	files := bundleFiles(false)
+	for _, name := range []string{"cache.json", "config-history.json", "containers.json", "logs.txt"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("expected %s in diagnostics, got %v", name, files)
+		}
+	}
+
+	files = bundleFiles(true)
+	if _, ok := files["logs.txt"]; ok {
+		t.Errorf("unexpected logs.txt in redacted diagnostics")
+	}
+	if _, ok := files["cache.json"]; !ok {
+		t.Errorf("expected cache.json in redacted diagnostics, got %v", files)
+	}
+}
@@ -39,6 +39,7 @@ func (m *resmgr) setupEventProcessing() error {
 	if m.metrics, err = metrics.NewMetrics(options); err != nil {
 		return resmgrError("failed to create metrics (pre)processor: %v", err)
 	}
+	m.podEvents = newPodEventPoster(m.agent)
 
 	return nil
 }
@@ -54,6 +55,7 @@ func (m *resmgr) startEventProcessing() error {
 	}
 
 	stop := m.stop
+	m.podEvents.start(stop)
 	go func() {
 		var rebalanceTimer *time.Ticker
 		var rebalanceChan <-chan time.Time
@@ -103,6 +105,10 @@ func (m *resmgr) SendEvent(event interface{}) error {
 	case m.events <- event:
 		return nil
 	default:
+		if e, ok := event.(*events.Pod); ok {
+			evtlog.Warn("dropped %s event %s on pod %s/%s, event channel full",
+				e.Type, e.Reason, e.Namespace, e.Name)
+		}
 		return resmgrError("can't send event of type %T, event channel full", event)
 	}
 }
@@ -118,6 +124,10 @@ func (m *resmgr) processEvent(e interface{}) {
 		m.processAvx(event.Avx)
 	case *events.Policy:
 		m.DeliverPolicyEvent(event)
+	case *events.Pod:
+		if m.podEvents != nil {
+			m.podEvents.post(event)
+		}
 	default:
 		evtlog.Warn("event of unexpected type %T...", e)
 	}
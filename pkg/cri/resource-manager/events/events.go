@@ -40,3 +40,33 @@ const (
 	// ContainerStarted is delivered to policies when a StartContainer request succeeds.
 	ContainerStarted = "container-started"
 )
+
+// Pod is an event to post on a pod object, for instance about its containers.
+type Pod struct {
+	// Namespace is the namespace of the pod.
+	Namespace string
+	// Name is the name of the pod.
+	Name string
+	// UID is the unique ID of the pod.
+	UID string
+	// Type is the type of the event, PodEventNormal or PodEventWarning.
+	Type string
+	// Reason is a short, machine understandable reason for the event.
+	Reason string
+	// Message is a human readable description of the event.
+	Message string
+}
+
+const (
+	// PodEventNormal is the type of pod events for normal operation.
+	PodEventNormal = "Normal"
+	// PodEventWarning is the type of pod events for problems.
+	PodEventWarning = "Warning"
+
+	// AllocationFailed is the reason for failed resource allocation.
+	AllocationFailed = "AllocationFailed"
+	// MemorySpilled is the reason for memory spilling over to further nodes.
+	MemorySpilled = "MemorySpilled"
+	// ReservedCPUFallback is the reason for using shared CPUs instead of reserved ones.
+	ReservedCPUFallback = "ReservedCPUFallback"
+)
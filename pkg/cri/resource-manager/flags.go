@@ -29,16 +29,22 @@ type options struct {
 	RelayDir            string
 	AgentSocket         string
 	ConfigSocket        string
+	ControlSocket       string
 	ResctrlPath         string
 	FallbackConfig      string
 	ForceConfig         string
 	ForceConfigSignal   string
+	ForceConfigDir      string
+	ConfigWatch         bool
+	ConfigWatchDelay    time.Duration
 	DisablePolicySwitch bool
 	ResetPolicy         bool
 	ResetConfig         bool
 	MetricsTimer        time.Duration
 	RebalanceTimer      time.Duration
 	DisableUI           bool
+	TimelineSize        int
+	TimelineFile        string
 }
 
 // Relay command line options.
@@ -58,6 +64,8 @@ func init() {
 		"local socket of the cri-resmgr agent to connect")
 	flag.StringVar(&opt.ConfigSocket, "config-socket", sockets.ResourceManagerConfig,
 		"Unix domain socket path where the resource manager listens for cri-resmgr-agent")
+	flag.StringVar(&opt.ControlSocket, "control-socket", sockets.ResourceManagerControl,
+		"Unix domain socket path where the resource manager listens for cri-resmgr-ctl requests.")
 
 	flag.StringVar(&opt.FallbackConfig, "fallback-config", "",
 		"Fallback configuration to use unless/until one is available from the cache or agent.")
@@ -65,6 +73,12 @@ func init() {
 		"Configuration used to override the one stored in the cache. Does not override the agent.")
 	flag.StringVar(&opt.ForceConfigSignal, "force-config-signal", "SIGHUP",
 		"Signal used to reload forced configuration.")
+	flag.StringVar(&opt.ForceConfigDir, "force-config-dir", "",
+		"Directory of forced configuration fragments (*.yaml), merged in lexical order on top of --force-config.")
+	flag.BoolVar(&opt.ConfigWatch, "config-watch", true,
+		"Watch forced and fallback configuration files for changes and reload them automatically.")
+	flag.DurationVar(&opt.ConfigWatchDelay, "config-watch-delay", 1*time.Second,
+		"Delay for letting configuration file changes settle before reloading them.")
 	flag.BoolVar(&opt.ResetConfig, "reset-config", false,
 		"Remove configuration (from the agent) stored in the cache, then exit.")
 
@@ -80,4 +94,9 @@ func init() {
 
 	flag.BoolVar(&opt.DisableUI, "disable-ui", false,
 		"Disable serving container placement visualization UIs.")
+
+	flag.IntVar(&opt.TimelineSize, "allocation-timeline-size", 4096,
+		"Number of container allocation events to keep in the allocation timeline. Use 0 for disabling.")
+	flag.StringVar(&opt.TimelineFile, "allocation-timeline-file", "",
+		"File to persist the allocation timeline in across restarts. Not persisted if empty.")
 }
@@ -0,0 +1,320 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EventsPath is the HTTP path for streaming introspection updates.
+	EventsPath = "/introspect/events"
+	// EventBacklog is the number of past events kept for resuming clients.
+	EventBacklog = 1024
+	// subscriberQueue is the number of events queued for a single client.
+	subscriberQueue = 256
+	// keepAliveInterval is the interval of keep-alive comments to idle clients.
+	keepAliveInterval = 15 * time.Second
+)
+
+// Types of introspection update events.
+const (
+	// EventSnapshot carries the full state, sent when a client can't resume.
+	EventSnapshot = "snapshot"
+	// EventPodAdded, EventPodUpdated, EventPodRemoved carry pod changes.
+	EventPodAdded   = "pod-added"
+	EventPodUpdated = "pod-updated"
+	EventPodRemoved = "pod-removed"
+	// EventAssignmentAdded, EventAssignmentUpdated, EventAssignmentRemoved carry assignment changes.
+	EventAssignmentAdded   = "assignment-added"
+	EventAssignmentUpdated = "assignment-updated"
+	EventAssignmentRemoved = "assignment-removed"
+	// EventPoolAdded, EventPoolUpdated, EventPoolRemoved carry pool changes.
+	EventPoolAdded   = "pool-added"
+	EventPoolUpdated = "pool-updated"
+	EventPoolRemoved = "pool-removed"
+	// EventSystemUpdated carries changes in system information.
+	EventSystemUpdated = "system-updated"
+	// EventError carries changes in the introspection error.
+	EventError = "error"
+)
+
+// Event describes a single incremental change in the introspected state.
+type Event struct {
+	Seq  uint64      // sequence number of this event
+	Type string      // type of the change
+	ID   string      // ID of the pod, container, or pool changed
+	Data interface{} `json:",omitempty"` // new value, omitted for removals
+}
+
+// eventStream keeps the recent events and the clients subscribed to them.
+type eventStream struct {
+	epoch       string                   // epoch of sequence numbers, unique per process
+	seq         uint64                   // sequence number of the last event
+	backlog     []*Event                 // recent events, oldest first
+	subscribers map[chan *Event]struct{} // subscribed clients
+}
+
+// newEventStream creates a new event stream.
+func newEventStream() *eventStream {
+	return &eventStream{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		subscribers: make(map[chan *Event]struct{}),
+	}
+}
+
+// eventID returns the ID of the event with the given sequence number.
+func (es *eventStream) eventID(seq uint64) string {
+	return es.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// parseEventID parses an event ID into an epoch and a sequence number.
+func parseEventID(id string) (string, uint64, error) {
+	epoch, seq := "", id
+	if i := strings.LastIndex(id, "-"); i >= 0 {
+		epoch, seq = id[:i], id[i+1:]
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return "", 0, err
+	}
+	return epoch, n, nil
+}
+
+// diffStates generates update events for the differences between two states.
+func diffStates(old, state *State) []*Event {
+	if old == nil {
+		old = &State{}
+	}
+	events := []*Event{}
+
+	diffMaps(&events, reflect.ValueOf(old.Pods), reflect.ValueOf(state.Pods),
+		EventPodAdded, EventPodUpdated, EventPodRemoved)
+	diffMaps(&events, reflect.ValueOf(old.Pools), reflect.ValueOf(state.Pools),
+		EventPoolAdded, EventPoolUpdated, EventPoolRemoved)
+	diffMaps(&events, reflect.ValueOf(old.Assignments), reflect.ValueOf(state.Assignments),
+		EventAssignmentAdded, EventAssignmentUpdated, EventAssignmentRemoved)
+
+	if !reflect.DeepEqual(old.System, state.System) {
+		events = append(events, &Event{Type: EventSystemUpdated, Data: state.System})
+	}
+	if old.Error != state.Error {
+		events = append(events, &Event{Type: EventError, Data: state.Error})
+	}
+
+	return events
+}
+
+// diffMaps generates update events for the differences between two maps with string keys.
+func diffMaps(events *[]*Event, old, new reflect.Value, added, updated, removed string) {
+	keys := map[string]struct{}{}
+	for _, k := range old.MapKeys() {
+		keys[k.String()] = struct{}{}
+	}
+	for _, k := range new.MapKeys() {
+		keys[k.String()] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		key := reflect.ValueOf(k)
+		o, n := mapIndex(old, key), mapIndex(new, key)
+		switch {
+		case o == nil && n != nil:
+			*events = append(*events, &Event{Type: added, ID: k, Data: n})
+		case o != nil && n == nil:
+			*events = append(*events, &Event{Type: removed, ID: k})
+		case o != nil && n != nil && !reflect.DeepEqual(o, n):
+			*events = append(*events, &Event{Type: updated, ID: k, Data: n})
+		}
+	}
+}
+
+// mapIndex returns the value for the key in a map, or nil if it is not found.
+func mapIndex(m, key reflect.Value) interface{} {
+	if !m.IsValid() || m.IsNil() {
+		return nil
+	}
+	v := m.MapIndex(key)
+	if !v.IsValid() || v.IsNil() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// publish assigns sequence numbers to events, records them, and sends them to subscribers.
+func (es *eventStream) publish(events []*Event) {
+	for _, e := range events {
+		es.seq++
+		e.Seq = es.seq
+		es.backlog = append(es.backlog, e)
+		for ch := range es.subscribers {
+			select {
+			case ch <- e:
+			default:
+				log.Warn("introspection client too slow, dropping it...")
+				delete(es.subscribers, ch)
+				close(ch)
+			}
+		}
+	}
+	if len(es.backlog) > EventBacklog {
+		es.backlog = append([]*Event{}, es.backlog[len(es.backlog)-EventBacklog:]...)
+	}
+}
+
+// subscribe subscribes a client, returning the events since the given epoch and sequence
+// number. If the events since then are not available any more, for instance because they
+// are from an earlier process, a snapshot event is returned instead.
+func (es *eventStream) subscribe(epoch string, since uint64, resume bool, state *State) (chan *Event, []*Event) {
+	ch := make(chan *Event, subscriberQueue)
+	es.subscribers[ch] = struct{}{}
+
+	if resume && epoch == es.epoch && since <= es.seq {
+		oldest := es.seq + 1
+		if len(es.backlog) > 0 {
+			oldest = es.backlog[0].Seq
+		}
+		if since+1 >= oldest {
+			pending := []*Event{}
+			for _, e := range es.backlog {
+				if e.Seq > since {
+					pending = append(pending, e)
+				}
+			}
+			return ch, pending
+		}
+	}
+
+	return ch, []*Event{{Seq: es.seq, Type: EventSnapshot, Data: state}}
+}
+
+// unsubscribe unsubscribes a client.
+func (es *eventStream) unsubscribe(ch chan *Event) {
+	if _, ok := es.subscribers[ch]; ok {
+		delete(es.subscribers, ch)
+		close(ch)
+	}
+}
+
+// closeAll unsubscribes all clients.
+func (es *eventStream) closeAll() {
+	for ch := range es.subscribers {
+		es.unsubscribe(ch)
+	}
+}
+
+// serveEvents streams introspection updates as Server-Sent Events.
+//
+// Clients can resume the stream by passing the ID of the last event they have seen,
+// either in the Last-Event-ID header or in the 'since' query parameter. Event IDs are
+// of the form <epoch>-<seq>, where the epoch is unique to the running process. If the
+// events since then are not available any more, the client first gets a snapshot of
+// the full state, just like clients which do not try to resume.
+func (s *Server) serveEvents(w http.ResponseWriter, req *http.Request) {
+	if !s.ready {
+		http.Error(w, "introspection is not ready", http.StatusServiceUnavailable)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	epoch, since, resume := "", uint64(0), false
+	lastID := req.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = req.URL.Query().Get("since")
+	}
+	if lastID != "" {
+		var err error
+		if epoch, since, err = parseEventID(lastID); err != nil {
+			http.Error(w, fmt.Sprintf("invalid event ID %q", lastID), http.StatusBadRequest)
+			return
+		}
+		resume = true
+	}
+
+	s.Lock()
+	ch, pending := s.events.subscribe(epoch, since, resume, s.state)
+	eventID := s.events.eventID
+	s.Unlock()
+
+	defer func() {
+		s.Lock()
+		s.events.unsubscribe(ch)
+		s.Unlock()
+	}()
+
+	log.Debug("streaming introspection events to %s...", req.RemoteAddr)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	for _, e := range pending {
+		if err := writeEvent(w, eventID(e.Seq), e); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, eventID(e.Seq), e); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprintf(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+// writeEvent writes a single event with the given ID in Server-Sent Events format.
+func writeEvent(w http.ResponseWriter, id string, e *Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		log.Error("failed to marshal introspection event: %v", err)
+		return nil
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", id, e.Type, data)
+	return err
+}
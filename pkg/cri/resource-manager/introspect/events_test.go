@@ -0,0 +1,145 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspect
+
+import (
+	"testing"
+)
+
+func eventTypes(events []*Event) []string {
+	types := []string{}
+	for _, e := range events {
+		types = append(types, e.Type+":"+e.ID)
+	}
+	return types
+}
+
+func TestDiffStates(t *testing.T) {
+	old := &State{
+		Pods: map[string]*Pod{
+			"pod1": {ID: "pod1", Name: "one"},
+			"pod2": {ID: "pod2", Name: "two"},
+		},
+		Pools: map[string]*Pool{
+			"root": {Name: "root", CPUs: "0-3"},
+		},
+		Assignments: map[string]*Assignment{
+			"c1": {ContainerID: "c1", Pool: "root", SharedCPUs: "0-3"},
+		},
+	}
+	state := &State{
+		Pods: map[string]*Pod{
+			"pod1": {ID: "pod1", Name: "one"},
+			"pod3": {ID: "pod3", Name: "three"},
+		},
+		Pools: map[string]*Pool{
+			"root": {Name: "root", CPUs: "0-3"},
+		},
+		Assignments: map[string]*Assignment{
+			"c1": {ContainerID: "c1", Pool: "root", SharedCPUs: "1-3", ExclusiveCPUs: "0"},
+		},
+	}
+
+	expected := []string{
+		EventPodRemoved + ":pod2",
+		EventPodAdded + ":pod3",
+		EventAssignmentUpdated + ":c1",
+	}
+	got := eventTypes(diffStates(old, state))
+	if len(got) != len(expected) {
+		t.Fatalf("expected events %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected events %v, got %v", expected, got)
+			break
+		}
+	}
+
+	if events := diffStates(state, state); len(events) != 0 {
+		t.Errorf("expected no events for unchanged state, got %v", eventTypes(events))
+	}
+}
+
+func TestEventStreamResume(t *testing.T) {
+	es := newEventStream()
+	state := &State{}
+
+	for i := 0; i < EventBacklog+10; i++ {
+		es.publish([]*Event{{Type: EventPoolUpdated, ID: "root"}})
+	}
+
+	tcases := []struct {
+		name     string
+		epoch    string
+		since    func() uint64
+		snapshot bool
+		pending  int
+	}{
+		{name: "no resume", snapshot: true},
+		{name: "up to date", since: func() uint64 { return es.seq }},
+		{name: "recent", since: func() uint64 { return es.seq - 5 }, pending: 5},
+		{name: "expired", since: func() uint64 { return 5 }, snapshot: true},
+		{name: "future", since: func() uint64 { return es.seq + 1 }, snapshot: true},
+		{name: "other epoch", epoch: "other", since: func() uint64 { return es.seq - 5 }, snapshot: true},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			epoch, since, resume := es.epoch, uint64(0), false
+			if tc.epoch != "" {
+				epoch = tc.epoch
+			}
+			if tc.since != nil {
+				since, resume = tc.since(), true
+			}
+			last := es.seq
+			ch, pending := es.subscribe(epoch, since, resume, state)
+			defer es.unsubscribe(ch)
+
+			if tc.snapshot {
+				if len(pending) != 1 || pending[0].Type != EventSnapshot || pending[0].Seq != last {
+					t.Errorf("expected snapshot at %d, got %v", last, eventTypes(pending))
+				}
+				return
+			}
+			if len(pending) != tc.pending {
+				t.Errorf("expected %d pending events, got %d", tc.pending, len(pending))
+			}
+			if len(pending) > 0 && pending[0].Seq != since+1 {
+				t.Errorf("expected first pending event %d, got %d", since+1, pending[0].Seq)
+			}
+
+			es.publish([]*Event{{Type: EventPoolUpdated, ID: "root"}})
+			if e := <-ch; e.Seq != last+1 {
+				t.Errorf("expected published event %d, got %d", last+1, e.Seq)
+			}
+		})
+	}
+}
+
+func TestEventID(t *testing.T) {
+	es := newEventStream()
+
+	epoch, seq, err := parseEventID(es.eventID(42))
+	if err != nil || epoch != es.epoch || seq != 42 {
+		t.Errorf("expected epoch %q and sequence number 42, got %q, %d, %v", es.epoch, epoch, seq, err)
+	}
+	if epoch, seq, err = parseEventID("42"); err != nil || epoch != "" || seq != 42 {
+		t.Errorf("expected no epoch and sequence number 42, got %q, %d, %v", epoch, seq, err)
+	}
+	if _, _, err = parseEventID(es.epoch + "-x"); err == nil {
+		t.Errorf("expected an error for an invalid event ID")
+	}
+}
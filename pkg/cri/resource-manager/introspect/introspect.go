@@ -109,16 +109,18 @@ type Server struct {
 	mux          *xhttp.ServeMux // our HTTP request multiplexer
 	state        *State          // introspection data
 	data         string          // state as a JSON string
+	events       *eventStream    // incremental updates for streaming clients
 	ready        bool
 }
 
 // Setup prepares the given HTTP request multiplexer for serving introspection.
 func Setup(mux *xhttp.ServeMux, state *State) (*Server, error) {
-	s := &Server{mux: mux}
+	s := &Server{mux: mux, events: newEventStream()}
 	if err := s.set(state); err != nil {
 		return nil, err
 	}
 	mux.HandleFunc("/introspect", s.serve)
+	mux.HandleFunc(EventsPath, s.serveEvents)
 	return s, nil
 }
 
@@ -139,11 +141,15 @@ func (s *Server) Start() {
 func (s *Server) Stop() {
 	log.Info("stopping introspection server...")
 	s.ready = false
+	s.Lock()
+	s.events.closeAll()
+	s.Unlock()
 }
 
 // set sets the given state and encodes it as a JSON string.
 func (s *Server) set(state *State) error {
 	log.Debug("updating introspection data...")
+	old := s.state
 	s.state = state
 	data, err := json.Marshal(s.state)
 	if err != nil {
@@ -153,6 +159,7 @@ func (s *Server) set(state *State) error {
 	}
 
 	s.data = string(data)
+	s.events.publish(diffStates(old, s.state))
 	return err
 }
 
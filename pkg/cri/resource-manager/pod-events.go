@@ -0,0 +1,133 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	agent_v1 "github.com/intel/cri-resource-manager/pkg/agent/api/v1"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/agent"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/events"
+)
+
+const (
+	// podEventQueueSize is the maximum number of pod events waiting to be posted.
+	podEventQueueSize = 64
+	// podEventBatchSize is the maximum number of pod events posted at once.
+	podEventBatchSize = 16
+	// podEventTimeout is the timeout for posting a batch of pod events.
+	podEventTimeout = 5 * time.Second
+)
+
+// podEventPoster posts pod events asynchronously through the agent.
+//
+// Posting is best-effort: events are dropped if the queue is full or if
+// the agent is not available. The agent aggregates similar events and
+// rate-limits them per pod before they reach the API server.
+type podEventPoster struct {
+	agent   agent.Interface
+	queue   chan *agent_v1.PodEvent
+	dropped int32
+}
+
+// newPodEventPoster creates a pod event poster.
+func newPodEventPoster(agent agent.Interface) *podEventPoster {
+	return &podEventPoster{
+		agent: agent,
+		queue: make(chan *agent_v1.PodEvent, podEventQueueSize),
+	}
+}
+
+// start starts posting pod events until stop is closed.
+func (p *podEventPoster) start(stop chan interface{}) {
+	go func() {
+		var lastErr string
+
+		for {
+			var batch []*agent_v1.PodEvent
+
+			select {
+			case _ = <-stop:
+				return
+			case e := <-p.queue:
+				batch = append(batch, e)
+			}
+
+		collect:
+			for len(batch) < podEventBatchSize {
+				select {
+				case e := <-p.queue:
+					batch = append(batch, e)
+				default:
+					break collect
+				}
+			}
+
+			if err := p.agent.PostPodEvents(batch, podEventTimeout); err != nil {
+				// Only warn about new errors to avoid flooding the logs while
+				// the agent is unavailable.
+				if err.Error() != lastErr {
+					evtlog.Warn("failed to post %d pod events: %v", len(batch), err)
+					lastErr = err.Error()
+				} else {
+					evtlog.Debug("failed to post %d pod events: %v", len(batch), err)
+				}
+			} else {
+				lastErr = ""
+			}
+		}
+	}()
+}
+
+// post queues the given event for posting, dropping it if the queue is full.
+func (p *podEventPoster) post(e *events.Pod) {
+	select {
+	case p.queue <- &agent_v1.PodEvent{
+		Namespace: e.Namespace,
+		Name:      e.Name,
+		Uid:       e.UID,
+		Type:      e.Type,
+		Reason:    e.Reason,
+		Message:   e.Message,
+	}:
+		if dropped := atomic.SwapInt32(&p.dropped, 0); dropped > 0 {
+			evtlog.Warn("%d pod events were dropped, queue was full", dropped)
+		}
+	default:
+		atomic.AddInt32(&p.dropped, 1)
+	}
+}
+
+// postPodEvent posts an event about the given container on its pod.
+func (m *resmgr) postPodEvent(c cache.Container, evtType, reason, format string, args ...interface{}) {
+	if m.podEvents == nil {
+		return
+	}
+	pod, ok := c.GetPod()
+	if !ok {
+		return
+	}
+	m.podEvents.post(&events.Pod{
+		Namespace: pod.GetNamespace(),
+		Name:      pod.GetName(),
+		UID:       pod.GetUID(),
+		Type:      evtType,
+		Reason:    reason,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 
 	pkgcfg "github.com/intel/cri-resource-manager/pkg/config"
 )
@@ -115,7 +116,29 @@ func defaultPodpoolsOptions() interface{} {
 // Our runtime configuration.
 var podpoolsOptions = defaultPodpoolsOptions().(*PodpoolsOptions)
 
+// validatePodpoolsOptions checks candidate options without applying them.
+//
+// Only checks that do not depend on the CPUs available are done here.
+func validatePodpoolsOptions(cfg interface{}) error {
+	for _, poolDef := range cfg.(*PodpoolsOptions).PoolDefs {
+		if poolDef.Name == "" {
+			return podpoolsError("undefined or empty pool name")
+		}
+		if poolDef.MaxPods < -1 {
+			return podpoolsError("pool %q: invalid MaxPods %d", poolDef.Name, poolDef.MaxPods)
+		}
+		builtin := poolDef.Name == reservedPoolDefName || poolDef.Name == defaultPoolDefName
+		if !builtin || poolDef.CPU != "" || poolDef.Instances != "" {
+			if _, _, err := parseInstancesCPUs(poolDef.Instances, poolDef.CPU, math.MaxInt32); err != nil {
+				return podpoolsError("pool %q: %w", poolDef.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // Register us for configuration handling.
 func init() {
-	pkgcfg.Register(PolicyPath, PolicyDescription, podpoolsOptions, defaultPodpoolsOptions)
+	pkgcfg.Register(PolicyPath, PolicyDescription, podpoolsOptions, defaultPodpoolsOptions,
+		pkgcfg.WithValidate(validatePodpoolsOptions))
 }
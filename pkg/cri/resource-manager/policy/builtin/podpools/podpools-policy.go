@@ -161,6 +161,7 @@ func CreatePodpoolsPolicy(policyOptions *policy.BackendOptions) policy.Backend {
 	}
 
 	pkgcfg.GetModule(PolicyPath).AddNotify(p.configNotify)
+	pkgcfg.GetModule(PolicyPath).AddValidate(p.validateConfig)
 
 	return p
 }
@@ -255,6 +256,61 @@ func (p *podpools) HandleEvent(*events.Policy) (bool, error) {
 	return false, nil
 }
 
+// GetContainerPool returns the name of the pool the container is assigned to.
+func (p *podpools) GetContainerPool(c cache.Container) (string, bool) {
+	pod, ok := c.GetPod()
+	if !ok {
+		return "", false
+	}
+	if pool := p.allocatedPool(pod); pool != nil {
+		return pool.PrettyName(), true
+	}
+	return "", false
+}
+
+// GetContainerCPUType returns the type of CPU allocated to the container.
+func (p *podpools) GetContainerCPUType(c cache.Container) (string, bool) {
+	pod, ok := c.GetPod()
+	if !ok {
+		return "", false
+	}
+	pool := p.allocatedPool(pod)
+	if pool == nil {
+		return "", false
+	}
+	if pool.Def == p.reservedPoolDef {
+		return policyapi.CPUType(policyapi.CPUReserved, false, true), true
+	}
+	return policyapi.CPUType(policyapi.CPUNormal, false, true), true
+}
+
+// GetPoolMetrics returns the resource utilization of the pools of the policy.
+func (p *podpools) GetPoolMetrics() []*policyapi.PoolMetrics {
+	pools := make([]*policyapi.PoolMetrics, 0, len(p.pools))
+	for _, pool := range p.pools {
+		granted := 0
+		containers := 0
+		for podID, contIDs := range pool.PodIDs {
+			granted += int(p.getPodMilliCPU(podID))
+			containers += len(contIDs)
+		}
+		pm := &policyapi.PoolMetrics{
+			Name:       pool.PrettyName(),
+			CPUs:       pool.CPUs.Size(),
+			Containers: containers,
+		}
+		if pool.Def == p.reservedPoolDef {
+			pm.ReservedCPUs = pool.CPUs.Size()
+			pm.GrantedReserved = granted
+		} else {
+			pm.SharedCPUs = pool.CPUs.Size()
+			pm.GrantedShared = granted
+		}
+		pools = append(pools, pm)
+	}
+	return pools
+}
+
 // ExportResourceData provides resource data to export for the container.
 func (p *podpools) ExportResourceData(c cache.Container) map[string]string {
 	return nil
@@ -543,6 +599,41 @@ func (p *podpools) applyPoolDef(pools *[]*Pool, poolDef *PoolDef, freeCpus *cpus
 
 // setConfig takes new pool configuration into use.
 func (p *podpools) setConfig(ppoptions *PodpoolsOptions) error {
+	pools, userPoolDefs, err := p.buildPools(ppoptions)
+	if err != nil {
+		return err
+	}
+	// Finish pool instance initialization.
+	log.Info("%s policy pools:", PolicyName)
+	for index, pool := range pools {
+		pool.Mems = p.closestMems(pool.CPUs)
+		pool.PodIDs = make(map[string][]string)
+		log.Info("- pool %d: %s", index, pool)
+	}
+	// No errors in pool creation, take new configuration into use.
+	log.Debug("new %s configuration:\n%s", PolicyName, utils.DumpJSON(ppoptions))
+	p.pools = pools
+	p.ppoptions = *ppoptions
+	// Warning on multiple user-defined pools.
+	if userPoolDefs > 1 {
+		log.Warn("Multiple (%d) user-defined pool definitions on the node. kube-scheduler does not know which of the pools has CPUs left for new workloads, and may overbook pools on the node.", userPoolDefs)
+	}
+	return nil
+}
+
+// validateConfig checks if pools could be created from a new configuration.
+func (p *podpools) validateConfig(cfg interface{}) error {
+	// Work on copies of the built-in pool definitions, building pools updates them.
+	reservedPoolDef, defaultPoolDef := *p.reservedPoolDef, *p.defaultPoolDef
+	scratch := *p
+	scratch.reservedPoolDef = &reservedPoolDef
+	scratch.defaultPoolDef = &defaultPoolDef
+	_, _, err := scratch.buildPools(cfg.(*PodpoolsOptions))
+	return err
+}
+
+// buildPools creates the pools for a configuration, returning also the number of user-defined pools.
+func (p *podpools) buildPools(ppoptions *PodpoolsOptions) ([]*Pool, int, error) {
 	// Instantiate pools for pods.
 	pools := []*Pool{}
 	// Built-in reserved pool.
@@ -567,7 +658,7 @@ func (p *podpools) setConfig(ppoptions *PodpoolsOptions) error {
 	userPoolDefs := 0
 	for _, poolDef := range ppoptions.PoolDefs {
 		if err := p.applyPoolDef(&pools, poolDef, &freeCpus, nonReservedCpuCount); err != nil {
-			return err
+			return nil, 0, err
 		}
 		if poolDef.Name != reservedPoolDefName && poolDef.Name != defaultPoolDefName {
 			userPoolDefs += 1
@@ -584,22 +675,7 @@ func (p *podpools) setConfig(ppoptions *PodpoolsOptions) error {
 			defaultPool.CPUs = freeCpus
 		}
 	}
-	// Finish pool instance initialization.
-	log.Info("%s policy pools:", PolicyName)
-	for index, pool := range pools {
-		pool.Mems = p.closestMems(pool.CPUs)
-		pool.PodIDs = make(map[string][]string)
-		log.Info("- pool %d: %s", index, pool)
-	}
-	// No errors in pool creation, take new configuration into use.
-	log.Debug("new %s configuration:\n%s", PolicyName, utils.DumpJSON(ppoptions))
-	p.pools = pools
-	p.ppoptions = *ppoptions
-	// Warning on multiple user-defined pools.
-	if userPoolDefs > 1 {
-		log.Warn("Multiple (%d) user-defined pool definitions on the node. kube-scheduler does not know which of the pools has CPUs left for new workloads, and may overbook pools on the node.", userPoolDefs)
-	}
-	return nil
+	return pools, userPoolDefs, nil
 }
 
 // closestMems returns memory node IDs good for pinning containers
@@ -578,3 +578,89 @@ func TestParseInstancesCPUs(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePodpoolsOptions(t *testing.T) {
+	tcases := []struct {
+		name          string
+		poolDefs      []*PoolDef
+		expectedError string
+	}{
+		{
+			name: "no pool definitions",
+		},
+		{
+			name: "valid user-defined and built-in pools",
+			poolDefs: []*PoolDef{
+				{Name: "reserved", MaxPods: 2},
+				{Name: "default", CPU: "4"},
+				{Name: "dualcpu", CPU: "2", Instances: "50 %", MaxPods: -1},
+			},
+		},
+		{
+			name:          "empty pool name",
+			poolDefs:      []*PoolDef{{CPU: "1"}},
+			expectedError: "empty pool name",
+		},
+		{
+			name:          "invalid MaxPods",
+			poolDefs:      []*PoolDef{{Name: "pool", CPU: "1", MaxPods: -2}},
+			expectedError: "invalid MaxPods",
+		},
+		{
+			name:          "user-defined pool without CPUs",
+			poolDefs:      []*PoolDef{{Name: "pool"}},
+			expectedError: "missing CPUs",
+		},
+		{
+			name:          "invalid Instances",
+			poolDefs:      []*PoolDef{{Name: "pool", CPU: "1", Instances: "many"}},
+			expectedError: "invalid Instances",
+		},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePodpoolsOptions(&PodpoolsOptions{PoolDefs: tc.poolDefs})
+			validateError(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	p := &podpools{
+		allowed:         cpuset.MustParse("0-7"),
+		reserved:        cpuset.MustParse("0"),
+		reservedPoolDef: &PoolDef{Name: reservedPoolDefName},
+		defaultPoolDef:  &PoolDef{Name: defaultPoolDefName},
+		cpuAllocator:    &mockCpuAllocator{},
+	}
+	tcases := []struct {
+		name          string
+		poolDefs      []*PoolDef
+		expectedError string
+	}{
+		{
+			name: "pools fit in available CPUs",
+			poolDefs: []*PoolDef{
+				{Name: reservedPoolDefName, MaxPods: 5},
+				{Name: "dualcpu", CPU: "2", Instances: "3"},
+			},
+		},
+		{
+			name: "pools exceed available CPUs",
+			poolDefs: []*PoolDef{
+				{Name: reservedPoolDefName, MaxPods: 5},
+				{Name: "dualcpu", CPU: "2", Instances: "4"},
+			},
+			expectedError: "insufficient CPUs",
+		},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := p.validateConfig(&PodpoolsOptions{PoolDefs: tc.poolDefs})
+			validateError(t, tc.expectedError, err)
+			if p.reservedPoolDef.MaxPods != 0 || p.pools != nil {
+				t.Errorf("validation unexpectedly altered policy state")
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"path"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -246,7 +247,30 @@ func parseCPUListName(name string) ([]uint, error) {
 	return cpus, nil
 }
 
+// validateConfig checks candidate configuration without applying it.
+func validateConfig(cfg interface{}) error {
+	c := cfg.(*config)
+	names := make([]string, 0, len(c.Pools))
+	for name := range c.Pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, cl := range c.Pools[name].CPULists {
+			if cl == nil {
+				return stpError("pool %q: missing cpu list", name)
+			}
+			if err := validateCPUList(cl.Cpuset); err != nil {
+				return stpError("pool %q: invalid cpu list: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // Register us for command line option processing and configuration management.
 func init() {
-	pkgcfg.Register(PolicyPath, PolicyDescription, conf, defaultConfig)
+	pkgcfg.Register(PolicyPath, PolicyDescription, conf, defaultConfig,
+		pkgcfg.WithValidate(validateConfig))
 }
@@ -22,6 +22,8 @@ import (
 	"strconv"
 	"strings"
 
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+
 	pkgcfg "github.com/intel/cri-resource-manager/pkg/config"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/events"
@@ -251,6 +253,65 @@ func (stp *stp) Introspect(*introspect.State) {
 	return
 }
 
+// GetContainerPool returns the name of the pool the container is assigned to.
+func (stp *stp) GetContainerPool(c cache.Container) (string, bool) {
+	cs, ok := stp.lookupContainerStatus(c)
+	if !ok {
+		return "", false
+	}
+	return cs.Pool, true
+}
+
+// GetContainerCPUType returns the type of CPU allocated to the container.
+func (stp *stp) GetContainerCPUType(c cache.Container) (string, bool) {
+	cs, ok := stp.lookupContainerStatus(c)
+	if !ok {
+		return "", false
+	}
+	exclusive := cs.NExclusiveCPUs > 0
+	return policy.CPUType(policy.CPUNormal, exclusive, !exclusive), true
+}
+
+// lookupContainerStatus looks up the cached STP status of a container.
+func (stp *stp) lookupContainerStatus(c cache.Container) (stpContainerStatus, bool) {
+	ccr := stpContainerCache{}
+	if !stp.state.GetPolicyEntry(cacheKeyContainerRegistry, &ccr) {
+		return stpContainerStatus{}, false
+	}
+	cs, ok := ccr[c.GetCacheID()]
+	return cs, ok
+}
+
+// GetPoolMetrics returns the resource utilization of the pools of the policy.
+func (stp *stp) GetPoolMetrics() []*policy.PoolMetrics {
+	pools := make([]*policy.PoolMetrics, 0, len(stp.conf.Pools))
+	for name, pool := range stp.conf.Pools {
+		pm := &policy.PoolMetrics{Name: name}
+		containers := map[string]struct{}{}
+		for _, cl := range pool.CPULists {
+			cpus, err := cpuset.Parse(cl.Cpuset)
+			if err != nil {
+				stp.Warn("invalid cpuset %q in pool %s: %v", cl.Cpuset, name, err)
+				continue
+			}
+			pm.CPUs += cpus.Size()
+			if pool.Exclusive {
+				if len(cl.containers) == 0 {
+					pm.FreeExclusiveCPUs += cpus.Size()
+				}
+			} else {
+				pm.SharedCPUs += cpus.Size()
+			}
+			for id := range cl.containers {
+				containers[id] = struct{}{}
+			}
+		}
+		pm.Containers = len(containers)
+		pools = append(pools, pm)
+	}
+	return pools
+}
+
 func (stp *stp) configNotify(event pkgcfg.Event, source pkgcfg.Source) error {
 	stp.Info("configuration %s", event)
 
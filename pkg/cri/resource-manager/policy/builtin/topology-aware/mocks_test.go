@@ -527,6 +527,9 @@ func (m *mockContainer) SetTag(string, string) (string, bool) {
 func (m *mockContainer) DeleteTag(string) (string, bool) {
 	panic("unimplemented")
 }
+func (m *mockContainer) GetTags() map[string]string {
+	panic("unimplemented")
+}
 func (m *mockContainer) String() string {
 	return "mockContainer"
 }
@@ -654,7 +657,7 @@ type mockCache struct {
 	returnValue2ForLookupContainer bool
 }
 
-func (m *mockCache) InsertPod(string, interface{}, *cache.PodStatus) cache.Pod {
+func (m *mockCache) InsertPod(string, interface{}, *cache.PodStatus, *cache.PodResourceRequirements) cache.Pod {
 	panic("unimplemented")
 }
 func (m *mockCache) DeletePod(string) cache.Pod {
@@ -663,6 +666,9 @@ func (m *mockCache) DeletePod(string) cache.Pod {
 func (m *mockCache) LookupPod(string) (cache.Pod, bool) {
 	panic("unimplemented")
 }
+func (m *mockCache) UpdatePodMetadata(string, map[string]string, map[string]string) ([]cache.Container, error) {
+	panic("unimplemented")
+}
 func (m *mockCache) InsertContainer(interface{}) (cache.Container, error) {
 	panic("unimplemented")
 }
@@ -727,12 +733,24 @@ func (m *mockCache) GetConfig() *config.RawConfig {
 func (m *mockCache) ResetConfig() error {
 	panic("unimplemented")
 }
+func (m *mockCache) RecordConfig(*config.RawConfig, string) *config.ConfigVersion {
+	panic("unimplemented")
+}
+func (m *mockCache) GetConfigHistory() []*config.ConfigVersion {
+	panic("unimplemented")
+}
+func (m *mockCache) LookupConfigVersion(uint64) (*config.ConfigVersion, bool) {
+	panic("unimplemented")
+}
 func (m *mockCache) SetAdjustment(*config.Adjustment) (bool, map[string]error) {
 	panic("unimplemented")
 }
 func (m *mockCache) Save() error {
 	return nil
 }
+func (m *mockCache) Snapshot() ([]byte, error) {
+	panic("unimplemented")
+}
 func (m *mockCache) RefreshPods(*cri.ListPodSandboxResponse, map[string]*cache.PodStatus) ([]cache.Pod, []cache.Pod, []cache.Container) {
 	panic("unimplemented")
 }
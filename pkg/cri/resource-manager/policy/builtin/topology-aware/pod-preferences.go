@@ -28,7 +28,6 @@ import (
 
 	"github.com/intel/cri-resource-manager/pkg/config"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
-	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/kubernetes"
 )
 
 const (
@@ -42,13 +41,15 @@ const (
 	keyColdStartPreference = "cold-start"
 
 	// effective annotation key for isolated CPU preference
-	preferIsolatedCPUsKey = keyIsolationPreference + "." + kubernetes.ResmgrKeyNamespace
+	preferIsolatedCPUsKey = cache.PreferIsolatedCPUsKey
 	// effective annotation key for shared CPU preference
-	preferSharedCPUsKey = keySharedCPUPreference + "." + kubernetes.ResmgrKeyNamespace
+	preferSharedCPUsKey = cache.PreferSharedCPUsKey
 	// effective annotation key for memory type preference
-	preferMemoryTypeKey = keyMemoryTypePreference + "." + kubernetes.ResmgrKeyNamespace
+	preferMemoryTypeKey = cache.MemoryTypeKey
 	// effective annotation key for "cold start" preference
-	preferColdStartKey = keyColdStartPreference + "." + kubernetes.ResmgrKeyNamespace
+	preferColdStartKey = cache.ColdStartKey
+	// effective annotation key for page migration preference
+	preferPageMigrationKey = cache.PageMigrationKey
 )
 
 // cpuClass is a type of CPU to allocate
@@ -181,10 +182,44 @@ func coldStartPreference(pod cache.Pod, container cache.Container) (ColdStartPre
 		return podColdStartPreference(pod, container)
 	}
 
-	preference := ColdStartPreference{}
-	if err := yaml.Unmarshal([]byte(value), &preference); err != nil {
+	preference, err := parseColdStartPreference(value)
+	if err != nil {
 		log.Error("failed to parse cold start preference (%q, %q): %v",
 			keyColdStartPreference, value, err)
+		return ColdStartPreference{}, err
+	}
+
+	log.Debug("%s: effective cold start preference %v",
+		container.PrettyName(), preference.Duration.String())
+
+	return preference, nil
+}
+
+// pageMigrationPreference returns whether idle pages of the container may be
+// demoted, and if the container was explicitly annotated with this setting.
+func pageMigrationPreference(pod cache.Pod, container cache.Container) (bool, bool) {
+	key := preferPageMigrationKey
+	value, ok := pod.GetEffectiveAnnotation(key, container.GetName())
+	if !ok {
+		return true, false
+	}
+
+	preference, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Error("invalid page migration preference annotation (%q, %q): %v",
+			key, value, err)
+		return true, false
+	}
+
+	log.Debug("%s: effective page migration preference %v", container.PrettyName(), preference)
+
+	return preference, true
+}
+
+// parseColdStartPreference parses the value of a cold start preference annotation.
+func parseColdStartPreference(value string) (ColdStartPreference, error) {
+	preference := ColdStartPreference{}
+	if err := yaml.Unmarshal([]byte(value), &preference); err != nil {
 		return ColdStartPreference{}, policyError("invalid cold start preference %q: %v",
 			value, err)
 	}
@@ -194,12 +229,39 @@ func coldStartPreference(pod cache.Pod, container cache.Container) (ColdStartPre
 			preference.Duration.String())
 	}
 
-	log.Debug("%s: effective cold start preference %v",
-		container.PrettyName(), preference.Duration.String())
-
 	return preference, nil
 }
 
+// ValidatePodAnnotations checks the effective annotations of a pod interpreted
+// by this policy. The pod is given by its annotations and the names of all its
+// containers.
+func ValidatePodAnnotations(annotations map[string]string, containers []string) error {
+	validators := map[string]cache.AnnotationValidator{
+		preferIsolatedCPUsKey: func(value string) error {
+			_, err := strconv.ParseBool(value)
+			return err
+		},
+		preferSharedCPUsKey: func(value string) error {
+			_, err := strconv.ParseBool(value)
+			return err
+		},
+		preferMemoryTypeKey: func(value string) error {
+			_, err := parseMemoryType(value)
+			return err
+		},
+		preferColdStartKey: func(value string) error {
+			_, err := parseColdStartPreference(value)
+			return err
+		},
+		preferPageMigrationKey: func(value string) error {
+			_, err := strconv.ParseBool(value)
+			return err
+		},
+	}
+
+	return cache.ValidateEffectiveAnnotations(annotations, containers, validators)
+}
+
 // podIsolationPreference checks if containers explicitly prefers to run on multiple isolated CPUs.
 // The first return value indicates whether the container is isolated or not.
 // The second return value indicates whether that decision was explicit (true) or implicit (false).
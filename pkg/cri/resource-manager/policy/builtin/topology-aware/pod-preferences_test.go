@@ -910,3 +910,62 @@ func TestCpuAllocationPreferences(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePodAnnotations(t *testing.T) {
+	containers := []string{"c0", "c1"}
+	tcases := []struct {
+		name        string
+		annotations map[string]string
+		expectError bool
+	}{
+		{
+			name: "valid annotations",
+			annotations: map[string]string{
+				preferIsolatedCPUsKey + "/pod":           "true",
+				preferSharedCPUsKey + "/container.c0":    "false",
+				preferMemoryTypeKey + "/container.c1":    "dram,pmem",
+				preferColdStartKey + "/container.c1":     "duration: 10s",
+				preferPageMigrationKey + "/container.c1": "false",
+			},
+		},
+		{
+			name: "invalid CPU isolation preference",
+			annotations: map[string]string{
+				preferIsolatedCPUsKey + "/pod": "yes please",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid memory type",
+			annotations: map[string]string{
+				preferMemoryTypeKey + "/container.c0": "dram,nvme",
+			},
+			expectError: true,
+		},
+		{
+			name: "cold start duration out of range",
+			annotations: map[string]string{
+				preferColdStartKey: "duration: 2h",
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown container",
+			annotations: map[string]string{
+				preferSharedCPUsKey + "/container.c2": "true",
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePodAnnotations(tc.annotations, containers)
+			if tc.expectError && err == nil {
+				t.Errorf("expected error, got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
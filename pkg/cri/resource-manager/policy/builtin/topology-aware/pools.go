@@ -21,6 +21,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
 
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/events"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/kubernetes"
 	system "github.com/intel/cri-resource-manager/pkg/sysfs"
 )
@@ -569,6 +570,10 @@ func (p *policy) allocatePool(container cache.Container, poolHint string) (Grant
 				if changed {
 					log.Debug("* moved container %s upward to node %s to guarantee memory",
 						oldGrant.GetContainer().PrettyName(), oldGrant.GetMemoryNode().Name())
+					p.sendPodEvent(oldGrant.GetContainer(), events.MemorySpilled,
+						"memory of container %s extended to pool %s (NUMA nodes %s) to guarantee memory",
+						oldGrant.GetContainer().GetName(), oldGrant.GetMemoryNode().Name(),
+						oldGrant.GetMemoryNode().GetMemset(oldGrant.MemoryType()))
 					break
 				}
 			}
@@ -733,6 +738,14 @@ func (p *policy) setDemotionPreferences(c cache.Container, g Grant) {
 		return
 	}
 
+	if pod, ok := c.GetPod(); ok {
+		if demote, _ := pageMigrationPreference(pod, c); !demote {
+			log.Debug("%s: opted out of demotion", c.PrettyName())
+			c.SetPageMigration(nil)
+			return
+		}
+	}
+
 	memType := g.GetMemoryNode().GetMemoryType()
 	if memType&memoryDRAM == 0 || memType&memoryPMEM == 0 {
 		c.SetPageMigration(nil)
@@ -965,3 +965,208 @@ func TestAffinities(t *testing.T) {
 		})
 	}
 }
+
+func TestTopologyZones(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cri-resource-manager-test-sysfs-")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = utils.UncompressTbz2(path.Join("testdata", "sysfs.tar.bz2"), dir)
+	if err != nil {
+		panic(err)
+	}
+
+	sys, err := system.DiscoverSystemAt(path.Join(dir, "sysfs", "server", "sys"))
+	if err != nil {
+		panic(err)
+	}
+
+	reserved, _ := resapi.ParseQuantity("750m")
+	policyOptions := &policyapi.BackendOptions{
+		Cache:  &mockCache{},
+		System: sys,
+		Reserved: policyapi.ConstraintSet{
+			policyapi.DomainCPU: reserved,
+		},
+	}
+	policy := CreateTopologyAwarePolicy(policyOptions).(*policy)
+
+	zones := policy.GetTopologyZones()
+	if len(zones) != len(policy.pools) {
+		t.Fatalf("expected %d zones, got %d", len(policy.pools), len(zones))
+	}
+
+	names := map[string]struct{}{}
+	for _, z := range zones {
+		names[z.Name] = struct{}{}
+	}
+
+	for _, z := range zones {
+		if z.Name == policy.root.Name() {
+			if z.Parent != "" {
+				t.Errorf("root zone %s has parent %s", z.Name, z.Parent)
+			}
+		} else if _, ok := names[z.Parent]; !ok {
+			t.Errorf("zone %s has unknown parent %q", z.Name, z.Parent)
+		}
+
+		if len(z.Resources) < 3 {
+			t.Fatalf("zone %s: expected at least 3 resources, got %d", z.Name, len(z.Resources))
+		}
+		if z.Resources[0].Name != "cpu" || z.Resources[1].Name != "memory" {
+			t.Errorf("zone %s: expected cpu and memory first, got %s and %s",
+				z.Name, z.Resources[0].Name, z.Resources[1].Name)
+		}
+		perType := resapi.NewQuantity(0, resapi.BinarySI)
+		for _, r := range z.Resources {
+			if r.Allocatable.Cmp(r.Capacity) > 0 || r.Available.Cmp(r.Allocatable) > 0 {
+				t.Errorf("zone %s: inconsistent %s capacity %s, allocatable %s, available %s",
+					z.Name, r.Name, r.Capacity.String(), r.Allocatable.String(), r.Available.String())
+			}
+			switch r.Name {
+			case "memory-dram", "memory-pmem", "memory-hbm":
+				perType.Add(r.Capacity)
+			}
+		}
+		if total := z.Resources[1].Capacity; total.Cmp(*perType) != 0 {
+			t.Errorf("zone %s: per-type memory capacity %s does not add up to total %s",
+				z.Name, perType.String(), total.String())
+		}
+
+		if z.Name == policy.root.Name() {
+			cpu := z.Resources[0]
+			if cpu.Capacity.MilliValue() != 112000 || cpu.Allocatable.MilliValue() != 111000 {
+				t.Errorf("root zone: expected 112 CPUs, 111 allocatable, got %s, %s",
+					cpu.Capacity.String(), cpu.Allocatable.String())
+			}
+		}
+	}
+}
+
+func TestPoolMetrics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cri-resource-manager-test-sysfs-")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = utils.UncompressTbz2(path.Join("testdata", "sysfs.tar.bz2"), dir)
+	if err != nil {
+		panic(err)
+	}
+
+	sys, err := system.DiscoverSystemAt(path.Join(dir, "sysfs", "server", "sys"))
+	if err != nil {
+		panic(err)
+	}
+
+	reserved, _ := resapi.ParseQuantity("750m")
+	policyOptions := &policyapi.BackendOptions{
+		Cache:  &mockCache{},
+		System: sys,
+		Reserved: policyapi.ConstraintSet{
+			policyapi.DomainCPU: reserved,
+		},
+	}
+	policy := CreateTopologyAwarePolicy(policyOptions).(*policy)
+
+	pools := policy.GetPoolMetrics()
+	if len(pools) != len(policy.pools) {
+		t.Fatalf("expected %d pools, got %d", len(policy.pools), len(pools))
+	}
+
+	for _, p := range pools {
+		if p.CPUs != p.IsolatedCPUs+p.ReservedCPUs+p.SharedCPUs {
+			t.Errorf("pool %s: %d CPUs, but %d isolated, %d reserved and %d shared",
+				p.Name, p.CPUs, p.IsolatedCPUs, p.ReservedCPUs, p.SharedCPUs)
+		}
+		if p.FreeExclusiveCPUs > p.IsolatedCPUs+p.SharedCPUs {
+			t.Errorf("pool %s: %d free exclusive CPUs, more than %d isolated and shared",
+				p.Name, p.FreeExclusiveCPUs, p.IsolatedCPUs+p.SharedCPUs)
+		}
+		if p.GrantedReserved != 0 || p.GrantedShared != 0 || p.Containers != 0 {
+			t.Errorf("pool %s: unexpected grants without containers", p.Name)
+		}
+		for kind, mem := range p.Memory {
+			if mem.Granted > mem.Limit {
+				t.Errorf("pool %s: granted %s memory %d exceeds limit %d",
+					p.Name, kind, mem.Granted, mem.Limit)
+			}
+		}
+
+		if p.Name == policy.root.Name() {
+			if p.CPUs != 112 || p.ReservedCPUs != 1 {
+				t.Errorf("root pool: expected 112 CPUs, 1 reserved, got %d, %d",
+					p.CPUs, p.ReservedCPUs)
+			}
+			if _, ok := p.Memory["dram"]; !ok {
+				t.Errorf("root pool: no DRAM reported")
+			}
+		}
+	}
+}
+
+func TestUpdateResourcesFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cri-resource-manager-test-sysfs-")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = utils.UncompressTbz2(path.Join("testdata", "sysfs.tar.bz2"), dir)
+	if err != nil {
+		panic(err)
+	}
+
+	sys, err := system.DiscoverSystemAt(path.Join(dir, "sysfs", "server", "sys"))
+	if err != nil {
+		panic(err)
+	}
+
+	reserved, _ := resapi.ParseQuantity("750m")
+	policyOptions := &policyapi.BackendOptions{
+		Cache:  &mockCache{},
+		System: sys,
+		Reserved: policyapi.ConstraintSet{
+			policyapi.DomainCPU: reserved,
+		},
+	}
+	policy := CreateTopologyAwarePolicy(policyOptions).(*policy)
+
+	container := &mockContainer{
+		name: "c1",
+		returnValueForGetResourceRequirements: v1.ResourceRequirements{
+			Limits: v1.ResourceList{
+				v1.ResourceCPU:    resapi.MustParse("2"),
+				v1.ResourceMemory: resapi.MustParse("1000"),
+			},
+		},
+		returnValueForGetCacheID: "first",
+	}
+	if err := policy.AllocateResources(container); err != nil {
+		t.Fatalf("failed to allocate resources: %v", err)
+	}
+	old := policy.allocations.grants[container.GetCacheID()]
+	supply := old.GetCPUNode().FreeSupply()
+	sharable, granted := supply.SharableCPUs(), supply.GrantedShared()
+
+	// ask for more memory than there is, to make reallocation fail
+	container.returnValueForGetResourceRequirements.Limits[v1.ResourceMemory] = resapi.MustParse("100Ti")
+	if err := policy.UpdateResources(container); err == nil {
+		t.Fatalf("expected update to fail")
+	}
+
+	grant, ok := policy.allocations.grants[container.GetCacheID()]
+	if !ok {
+		t.Fatalf("grant of %s lost after failed update", container.PrettyName())
+	}
+	if grant != old {
+		t.Errorf("expected old grant %s to be restored, got %s", old, grant)
+	}
+	if !supply.SharableCPUs().Equals(sharable) || supply.GrantedShared() != granted {
+		t.Errorf("expected pool %s to have sharable CPUs %s and %d granted shared, got %s and %d",
+			old.GetCPUNode().Name(), sharable, granted, supply.SharableCPUs(), supply.GrantedShared())
+	}
+}
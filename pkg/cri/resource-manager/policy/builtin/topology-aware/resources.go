@@ -24,6 +24,7 @@ import (
 
 	"github.com/intel/cri-resource-manager/pkg/cpuallocator"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/events"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/kubernetes"
 	system "github.com/intel/cri-resource-manager/pkg/sysfs"
 )
@@ -569,6 +570,9 @@ func (cs *supply) AllocateCPU(r Request) (Grant, error) {
 		log.Warn("  %s: needs %d reserved, only %d available",
 			cr.GetContainer().PrettyName(), fraction, cs.AllocatableReservedCPU())
 		log.Warn("  falling back to using normal unreserved CPUs instead...")
+		cs.node.Policy().sendPodEvent(cr.GetContainer(), events.ReservedCPUFallback,
+			"not enough reserved CPU for container %s (%dm requested, %dm available), using shared CPUs",
+			cr.GetContainer().GetName(), fraction, cs.AllocatableReservedCPU())
 		cpuType = cpuNormal
 	}
 
@@ -15,6 +15,9 @@
 package topologyaware
 
 import (
+	"fmt"
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	resapi "k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
@@ -73,8 +76,10 @@ type policy struct {
 	isAlias      bool                      // whether started by referencing AliasName
 }
 
-// Make sure policy implements the policy.Backend interface.
+// Make sure policy implements the policy.Backend, PoolReporter and ZoneReporter interfaces.
 var _ policyapi.Backend = &policy{}
+var _ policyapi.PoolReporter = &policy{}
+var _ policyapi.ZoneReporter = &policy{}
 
 // Whether we have coldstart forced off due to PMEM in movable memory zones.
 var coldStartOff bool
@@ -185,8 +190,26 @@ func (p *policy) ReleaseResources(container cache.Container) error {
 }
 
 // UpdateResources is a resource allocation update request for this policy.
+// Containers are reallocated, so that changed preferences take effect.
 func (p *policy) UpdateResources(c cache.Container) error {
-	log.Debug("(not) updating container %s...", c.PrettyName())
+	log.Debug("updating container %s...", c.PrettyName())
+
+	old, ok := p.allocations.grants[c.GetCacheID()]
+	if !ok {
+		log.Debug("  => no grant found, nothing to do...")
+		return nil
+	}
+
+	p.ReleaseResources(c)
+	if err := p.AllocateResources(c); err != nil {
+		log.Error("failed to update %s, restoring old allocation: %v", c.PrettyName(), err)
+		if rerr := p.reinstateGrants(map[string]Grant{c.GetCacheID(): old}); rerr != nil {
+			return policyError("%v, failed to restore old allocation: %v", err, rerr)
+		}
+		p.saveAllocations()
+		return err
+	}
+
 	return nil
 }
 
@@ -286,6 +309,172 @@ func (p *policy) Introspect(state *introspect.State) {
 	state.Assignments = assignments
 }
 
+// GetContainerPool returns the name of the pool the container is assigned to.
+func (p *policy) GetContainerPool(c cache.Container) (string, bool) {
+	grant, ok := p.allocations.grants[c.GetCacheID()]
+	if !ok {
+		return "", false
+	}
+	return grant.GetCPUNode().Name(), true
+}
+
+// GetContainerCPUType returns the type of CPU allocated to the container.
+func (p *policy) GetContainerCPUType(c cache.Container) (string, bool) {
+	grant, ok := p.allocations.grants[c.GetCacheID()]
+	if !ok {
+		return "", false
+	}
+	exclusive := !grant.ExclusiveCPUs().IsEmpty()
+	shared := grant.CPUPortion() > 0 || !exclusive
+	return policyapi.CPUType(grant.CPUType().String(), exclusive, shared), true
+}
+
+// sendPodEvent sends a warning about a degraded placement to the pod of the container.
+func (p *policy) sendPodEvent(c cache.Container, reason, format string, args ...interface{}) {
+	if p.options.SendEvent == nil {
+		return
+	}
+	pod, ok := c.GetPod()
+	if !ok {
+		return
+	}
+	e := &events.Pod{
+		Namespace: pod.GetNamespace(),
+		Name:      pod.GetName(),
+		UID:       pod.GetUID(),
+		Type:      events.PodEventWarning,
+		Reason:    reason,
+		Message:   fmt.Sprintf(format, args...),
+	}
+	if err := p.options.SendEvent(e); err != nil {
+		log.Warn("failed to send %s event for %s: %v", reason, c.PrettyName(), err)
+	}
+}
+
+// GetTopologyZones returns the pools of the policy as topology zones.
+func (p *policy) GetTopologyZones() []*policyapi.TopologyZone {
+	zones := make([]*policyapi.TopologyZone, 0, len(p.pools))
+
+	for _, n := range p.pools {
+		supply := n.GetSupply()
+		free := n.FreeSupply()
+
+		zone := &policyapi.TopologyZone{
+			Name: n.Name(),
+			Type: zoneType(n),
+		}
+		if parent := n.Parent(); !parent.IsNil() {
+			zone.Parent = parent.Name()
+		}
+
+		allCPU := supply.IsolatedCPUs().Union(supply.ReservedCPUs()).Union(supply.SharableCPUs())
+		allocatableCPU := allCPU.Difference(supply.ReservedCPUs())
+		availableCPU := 1000 * free.IsolatedCPUs().Size()
+		if shared := free.AllocatableSharedCPU(true); shared > 0 {
+			availableCPU += shared
+		}
+		zone.Resources = append(zone.Resources, &policyapi.ZoneResource{
+			Name:        string(v1.ResourceCPU),
+			Capacity:    *resapi.NewMilliQuantity(int64(1000*allCPU.Size()), resapi.DecimalSI),
+			Allocatable: *resapi.NewMilliQuantity(int64(1000*allocatableCPU.Size()), resapi.DecimalSI),
+			Available:   *resapi.NewMilliQuantity(int64(availableCPU), resapi.DecimalSI),
+		})
+
+		zone.Resources = append(zone.Resources,
+			zoneMemory(string(v1.ResourceMemory), memoryAll, supply, free))
+		for _, memType := range []memoryType{memoryDRAM, memoryPMEM, memoryHBM} {
+			if supply.MemoryLimit()[memType] == 0 {
+				continue
+			}
+			name := string(v1.ResourceMemory) + "-" + strings.ToLower(memoryTypeNames[memType])
+			zone.Resources = append(zone.Resources, zoneMemory(name, memType, supply, free))
+		}
+
+		zones = append(zones, zone)
+	}
+
+	return zones
+}
+
+// zoneMemory returns the amounts of memory of the given type in a pool as a zone resource.
+func zoneMemory(name string, memType memoryType, supply, free Supply) *policyapi.ZoneResource {
+	capacity := supply.MemoryLimit()[memType]
+	available := free.MemoryLimit()[memType]
+	if extra := free.ExtraMemoryReservation(memType); extra < available {
+		available -= extra
+	} else {
+		available = 0
+	}
+	return &policyapi.ZoneResource{
+		Name:        name,
+		Capacity:    *resapi.NewQuantity(int64(capacity), resapi.BinarySI),
+		Allocatable: *resapi.NewQuantity(int64(capacity), resapi.BinarySI),
+		Available:   *resapi.NewQuantity(int64(available), resapi.BinarySI),
+	}
+}
+
+// GetPoolMetrics returns the resource utilization of the pools of the policy.
+func (p *policy) GetPoolMetrics() []*policyapi.PoolMetrics {
+	containers := map[string]int{}
+	for _, g := range p.allocations.grants {
+		containers[g.GetCPUNode().Name()]++
+	}
+
+	pools := make([]*policyapi.PoolMetrics, 0, len(p.pools))
+	for _, n := range p.pools {
+		supply := n.GetSupply()
+		free := n.FreeSupply()
+
+		allCPU := supply.IsolatedCPUs().Union(supply.ReservedCPUs()).Union(supply.SharableCPUs())
+		freeExclusive := free.IsolatedCPUs().Size()
+		if shared := free.AllocatableSharedCPU(true); shared > 0 {
+			freeExclusive += shared / 1000
+		}
+
+		pool := &policyapi.PoolMetrics{
+			Name:              n.Name(),
+			CPUs:              allCPU.Size(),
+			IsolatedCPUs:      supply.IsolatedCPUs().Size(),
+			ReservedCPUs:      supply.ReservedCPUs().Size(),
+			SharedCPUs:        supply.SharableCPUs().Size(),
+			GrantedReserved:   free.GrantedReserved(),
+			GrantedShared:     free.GrantedShared(),
+			FreeExclusiveCPUs: freeExclusive,
+			Memory:            map[string]*policyapi.MemoryMetrics{},
+			Containers:        containers[n.Name()],
+		}
+		limit := supply.MemoryLimit()
+		for _, memType := range []memoryType{memoryDRAM, memoryPMEM, memoryHBM} {
+			if limit[memType] == 0 {
+				continue
+			}
+			pool.Memory[strings.ToLower(memoryTypeNames[memType])] = &policyapi.MemoryMetrics{
+				Limit:   limit[memType],
+				Granted: free.GrantedMemory(memType),
+			}
+		}
+
+		pools = append(pools, pool)
+	}
+
+	return pools
+}
+
+// zoneType returns the topology zone type for a pool node.
+func zoneType(n Node) string {
+	switch n.Kind() {
+	case NumaNode:
+		return "Node"
+	case DieNode:
+		return "Die"
+	case SocketNode:
+		return "Socket"
+	case VirtualNode:
+		return "Root"
+	}
+	return "Unknown"
+}
+
 // ExportResourceData provides resource data to export for the container.
 func (p *policy) ExportResourceData(c cache.Container) map[string]string {
 	grant, ok := p.allocations.grants[c.GetCacheID()]
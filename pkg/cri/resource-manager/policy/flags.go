@@ -223,8 +223,20 @@ func defaultOptions() interface{} {
 	}
 }
 
+// validateOptions checks candidate options without applying them.
+func validateOptions(cfg interface{}) error {
+	o := cfg.(*options)
+	if o.Policy == NullPolicy {
+		return nil
+	}
+	if _, ok := backends[o.Policy]; !ok {
+		return policyError("unknown policy %q", o.Policy)
+	}
+	return nil
+}
+
 // Register us for configuration handling.
 func init() {
 	config.Register(ConfigPath, "Generic policy layer.", opt, defaultOptions,
-		config.WithNotify(configNotify))
+		config.WithNotify(configNotify), config.WithValidate(validateOptions))
 }
@@ -0,0 +1,171 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/intel/cri-resource-manager/pkg/metrics"
+)
+
+// Prometheus metric descriptor indices and descriptor table.
+const (
+	poolCPUsDesc = iota
+	poolIsolatedCPUsDesc
+	poolReservedCPUsDesc
+	poolSharedCPUsDesc
+	poolGrantedReservedDesc
+	poolGrantedSharedDesc
+	poolFreeExclusiveCPUsDesc
+	poolMemoryLimitDesc
+	poolMemoryGrantedDesc
+	poolContainersDesc
+	numPoolDescriptors
+)
+
+var poolDescriptors = [numPoolDescriptors]*prometheus.Desc{
+	poolCPUsDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_cpus",
+		"Total number of CPUs in a pool of the active policy.",
+		[]string{"policy", "pool"}, nil,
+	),
+	poolIsolatedCPUsDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_isolated_cpus",
+		"Number of isolated CPUs in a pool of the active policy.",
+		[]string{"policy", "pool"}, nil,
+	),
+	poolReservedCPUsDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_reserved_cpus",
+		"Number of reserved CPUs in a pool of the active policy.",
+		[]string{"policy", "pool"}, nil,
+	),
+	poolSharedCPUsDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_shared_cpus",
+		"Number of shared CPUs in a pool of the active policy.",
+		[]string{"policy", "pool"}, nil,
+	),
+	poolGrantedReservedDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_granted_reserved_millicpus",
+		"Reserved CPU granted to containers in a pool of the active policy, in milli-CPU.",
+		[]string{"policy", "pool"}, nil,
+	),
+	poolGrantedSharedDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_granted_shared_millicpus",
+		"Shared CPU granted to containers in a pool of the active policy, in milli-CPU.",
+		[]string{"policy", "pool"}, nil,
+	),
+	poolFreeExclusiveCPUsDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_free_exclusive_cpus",
+		"Number of CPUs still available for exclusive allocation in a pool of the active policy.",
+		[]string{"policy", "pool"}, nil,
+	),
+	poolMemoryLimitDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_memory_limit_bytes",
+		"Amount of memory, per memory type, in a pool of the active policy.",
+		[]string{"policy", "pool", "type"}, nil,
+	),
+	poolMemoryGrantedDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_memory_granted_bytes",
+		"Amount of memory, per memory type, granted to containers in a pool of the active policy.",
+		[]string{"policy", "pool", "type"}, nil,
+	),
+	poolContainersDesc: prometheus.NewDesc(
+		"cri_resmgr_pool_containers",
+		"Number of containers assigned to a pool of the active policy.",
+		[]string{"policy", "pool"}, nil,
+	),
+}
+
+// poolCollector exports the latest pool utilization of the active policy.
+type poolCollector struct {
+	sync.RWMutex
+	policy string         // name of the active policy
+	pools  []*PoolMetrics // latest pool utilization
+}
+
+// Our pool utilization collector.
+var poolStats = &poolCollector{}
+
+// update updates the pool utilization to export.
+func (c *poolCollector) update(policy string, pools []*PoolMetrics) {
+	c.Lock()
+	defer c.Unlock()
+
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+	c.policy = policy
+	c.pools = pools
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range poolDescriptors {
+		ch <- d
+	}
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	c.RLock()
+	defer c.RUnlock()
+
+	gauge := func(desc int, value float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(poolDescriptors[desc], prometheus.GaugeValue,
+			value, append([]string{c.policy}, labels...)...)
+	}
+
+	for _, p := range c.pools {
+		gauge(poolCPUsDesc, float64(p.CPUs), p.Name)
+		gauge(poolIsolatedCPUsDesc, float64(p.IsolatedCPUs), p.Name)
+		gauge(poolReservedCPUsDesc, float64(p.ReservedCPUs), p.Name)
+		gauge(poolSharedCPUsDesc, float64(p.SharedCPUs), p.Name)
+		gauge(poolGrantedReservedDesc, float64(p.GrantedReserved), p.Name)
+		gauge(poolGrantedSharedDesc, float64(p.GrantedShared), p.Name)
+		gauge(poolFreeExclusiveCPUsDesc, float64(p.FreeExclusiveCPUs), p.Name)
+		for kind, mem := range p.Memory {
+			gauge(poolMemoryLimitDesc, float64(mem.Limit), p.Name, kind)
+			gauge(poolMemoryGrantedDesc, float64(mem.Granted), p.Name, kind)
+		}
+		gauge(poolContainersDesc, float64(p.Containers), p.Name)
+	}
+}
+
+// updateMetrics updates the exported pool utilization of the active policy.
+// Without an active policy reporting pools, any stale pools are cleared.
+func (p *policy) updateMetrics() {
+	if p.Bypassed() {
+		poolStats.update("", nil)
+		return
+	}
+	r, ok := p.active.(MetricsReporter)
+	if !ok {
+		poolStats.update("", nil)
+		return
+	}
+	poolStats.update(p.active.Name(), r.GetPoolMetrics())
+}
+
+// Register our pool utilization collector.
+func init() {
+	err := metrics.RegisterCollector("policy",
+		func() (prometheus.Collector, error) {
+			return poolStats, nil
+		})
+	if err != nil {
+		log.Error("failed to register policy metrics collector: %v", err)
+	}
+}
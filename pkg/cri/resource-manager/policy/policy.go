@@ -129,6 +129,113 @@ type Backend interface {
 	Introspect(*introspect.State)
 }
 
+// PoolReporter is an optional interface for backends which assign containers to pools.
+type PoolReporter interface {
+	// GetContainerPool returns the name of the pool the container is assigned to.
+	GetContainerPool(cache.Container) (string, bool)
+}
+
+// CPUTypeReporter is an optional interface for backends which can tell the type of CPU of containers.
+type CPUTypeReporter interface {
+	// GetContainerCPUType returns the type of CPU allocated to the container.
+	GetContainerCPUType(cache.Container) (string, bool)
+}
+
+// ZoneReporter is an optional interface for backends which can describe their topology zones.
+type ZoneReporter interface {
+	// GetTopologyZones returns the topology zones of the policy and their resources.
+	GetTopologyZones() []*TopologyZone
+}
+
+// TopologyZone describes a topology zone of a policy, for instance a NUMA node.
+type TopologyZone struct {
+	// Name is the name of the zone, unique within the node.
+	Name string
+	// Type is the type of the zone, for instance Node or Socket.
+	Type string
+	// Parent is the name of the parent zone, if any.
+	Parent string
+	// Resources are the amounts of resources in the zone.
+	Resources []*ZoneResource
+}
+
+// ZoneResource is the amount of a resource in a topology zone.
+type ZoneResource struct {
+	// Name is the name of the resource.
+	Name string
+	// Capacity is the total amount of the resource in the zone.
+	Capacity resource.Quantity
+	// Allocatable is the amount of the resource available for containers.
+	Allocatable resource.Quantity
+	// Available is the amount of the resource not yet allocated.
+	Available resource.Quantity
+}
+
+// MetricsReporter is an optional interface for backends which can report pool utilization.
+type MetricsReporter interface {
+	// GetPoolMetrics returns the resource utilization of the pools of the policy.
+	GetPoolMetrics() []*PoolMetrics
+}
+
+// PoolMetrics describes the resource utilization of a pool, or topology node, of a policy.
+type PoolMetrics struct {
+	// Name is the name of the pool.
+	Name string
+	// CPUs is the total number of CPUs in the pool.
+	CPUs int
+	// IsolatedCPUs is the number of isolated CPUs in the pool.
+	IsolatedCPUs int
+	// ReservedCPUs is the number of reserved CPUs in the pool.
+	ReservedCPUs int
+	// SharedCPUs is the number of shared CPUs in the pool.
+	SharedCPUs int
+	// GrantedReserved is the reserved CPU granted to containers, in milli-CPU.
+	GrantedReserved int
+	// GrantedShared is the shared CPU granted to containers, in milli-CPU.
+	GrantedShared int
+	// FreeExclusiveCPUs is the number of CPUs still available for exclusive allocation.
+	FreeExclusiveCPUs int
+	// Memory is the memory of the pool per memory type.
+	Memory map[string]*MemoryMetrics
+	// Containers is the number of containers assigned to the pool.
+	Containers int
+}
+
+// MemoryMetrics describes the utilization of one type of memory in a pool.
+type MemoryMetrics struct {
+	// Limit is the amount of memory in the pool.
+	Limit uint64
+	// Granted is the amount of memory granted to containers.
+	Granted uint64
+}
+
+// CPU classes and allocation modes used to describe the type of CPU of containers.
+const (
+	// CPUNormal is the class of normal, non-reserved CPUs.
+	CPUNormal = "normal"
+	// CPUReserved is the class of CPUs reserved for system and kube tasks.
+	CPUReserved = "reserved"
+	// CPUExclusive is the mode of CPUs allocated exclusively.
+	CPUExclusive = "exclusive"
+	// CPUShared is the mode of CPUs shared with other containers.
+	CPUShared = "shared"
+	// CPUMixed is the mode of both exclusive and shared CPUs.
+	CPUMixed = "mixed"
+)
+
+// CPUType returns the type of CPU for the given class and allocation mode, for instance
+// normal-exclusive or reserved-shared.
+func CPUType(class string, exclusive, shared bool) string {
+	mode := CPUShared
+	switch {
+	case exclusive && shared:
+		mode = CPUMixed
+	case exclusive:
+		mode = CPUExclusive
+	}
+	return class + "-" + mode
+}
+
 // Policy is the exposed interface for container resource allocations decision making.
 type Policy interface {
 	// Start starts up policy, prepare for serving resource management requests.
@@ -151,6 +258,10 @@ type Policy interface {
 	ExportResourceData(cache.Container)
 	// Introspect provides data for external introspection.
 	Introspect() *introspect.State
+	// GetContainerPool returns the pool of a container, if the active policy has pools.
+	GetContainerPool(cache.Container) (string, bool)
+	// GetContainerCPUType returns the type of CPU of a container, if the active policy knows it.
+	GetContainerCPUType(cache.Container) (string, bool)
 	// Bypassed checks if local policy processing is effectively disabled/bypassed.
 	Bypassed() bool
 }
@@ -163,6 +274,7 @@ type policy struct {
 	system    system.System      // system/HW/topology info
 	inspsys   *introspect.System // ditto for introspection
 	sendEvent SendEventFn        // function to send event up to the resource manager
+	zones     *zoneUpdater       // exporter for topology zones, if any
 }
 
 // backend is a registered Backend.
@@ -236,6 +348,9 @@ func NewPolicy(cache cache.Cache, o *Options) (Policy, error) {
 		p.active = active.create(backendOpts)
 	}
 
+	// registered after the backend, so we get notified once it has reconfigured itself
+	config.GetModule(ConfigPath).AddNotify(p.reconfigNotify)
+
 	return p, nil
 }
 
@@ -243,11 +358,22 @@ func NewPolicy(cache cache.Cache, o *Options) (Policy, error) {
 func (p *policy) Start(add []cache.Container, del []cache.Container) error {
 	if p.Bypassed() {
 		log.Info("policy '%s' active, nothing to start...", opt.Policy)
+		p.updateMetrics()
 		return nil
 	}
 
 	log.Info("starting policy '%s'...", p.active.Name())
-	return p.active.Start(add, del)
+	if err := p.active.Start(add, del); err != nil {
+		return err
+	}
+
+	if _, ok := p.active.(ZoneReporter); ok && p.options.AgentCli != nil {
+		p.zones = newZoneUpdater(p.options.AgentCli)
+		p.zones.start()
+	}
+	p.updateState()
+
+	return nil
 }
 
 func (p *policy) Bypassed() bool {
@@ -256,32 +382,60 @@ func (p *policy) Bypassed() bool {
 
 // Sync synchronizes the active policy state.
 func (p *policy) Sync(add []cache.Container, del []cache.Container) error {
+	defer p.updateState()
 	return p.active.Sync(add, del)
 }
 
 // AllocateResources allocates resources for a container.
 func (p *policy) AllocateResources(c cache.Container) error {
+	defer p.updateState()
 	return p.active.AllocateResources(c)
 }
 
 // ReleaseResources release resources of a container.
 func (p *policy) ReleaseResources(c cache.Container) error {
+	defer p.updateState()
 	return p.active.ReleaseResources(c)
 }
 
+// GetContainerCPUType returns the type of CPU of a container, if the active policy knows it.
+func (p *policy) GetContainerCPUType(c cache.Container) (string, bool) {
+	if r, ok := p.active.(CPUTypeReporter); ok {
+		return r.GetContainerCPUType(c)
+	}
+	return "", false
+}
+
+// updateState exports the current state of the active policy after a change.
+func (p *policy) updateState() {
+	p.updateZones()
+	p.updateMetrics()
+}
+
+// GetContainerPool returns the pool of a container, if the active policy has pools.
+func (p *policy) GetContainerPool(c cache.Container) (string, bool) {
+	if r, ok := p.active.(PoolReporter); ok {
+		return r.GetContainerPool(c)
+	}
+	return "", false
+}
+
 // UpdateResources updates resource allocations of a container.
 func (p *policy) UpdateResources(c cache.Container) error {
+	defer p.updateState()
 	return p.active.UpdateResources(c)
 }
 
 // Rebalance tries to find a more optimal allocation of resources for the current containers.
 func (p *policy) Rebalance() (bool, error) {
+	defer p.updateState()
 	return p.active.Rebalance()
 }
 
 // HandleEvent passes on the given event to the active policy.
 func (p *policy) HandleEvent(e *events.Policy) (bool, error) {
 	if !p.Bypassed() {
+		defer p.updateState()
 		return p.active.HandleEvent(e)
 	}
 	return false, nil
@@ -434,6 +588,12 @@ func ConstraintToString(value Constraint) string {
 	}
 }
 
+// reconfigNotify exports the state of the active policy after a configuration change.
+func (p *policy) reconfigNotify(event config.Event, src config.Source) error {
+	p.updateState()
+	return nil
+}
+
 // configNotify is the configuration change notification callback for the genric policy layer.
 func configNotify(event config.Event, src config.Source) error {
 	// let the active policy know of changes
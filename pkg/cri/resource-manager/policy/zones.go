@@ -0,0 +1,153 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	agent_v1 "github.com/intel/cri-resource-manager/pkg/agent/api/v1"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/agent"
+)
+
+const (
+	// zoneUpdateTimeout is the timeout for a single topology update request.
+	zoneUpdateTimeout = 10 * time.Second
+	// zoneRetryInterval is the delay before retrying a failed topology update.
+	zoneRetryInterval = 5 * time.Second
+)
+
+// zoneUpdate is a pending topology zone update.
+type zoneUpdate struct {
+	policies []string
+	zones    []*agent_v1.TopologyZone
+}
+
+// zoneUpdater exports topology zones asynchronously through the agent.
+type zoneUpdater struct {
+	agent   agent.Interface
+	updates chan *zoneUpdate
+	last    *zoneUpdate
+}
+
+// newZoneUpdater creates a topology zone updater.
+func newZoneUpdater(agent agent.Interface) *zoneUpdater {
+	return &zoneUpdater{
+		agent:   agent,
+		updates: make(chan *zoneUpdate, 1),
+	}
+}
+
+// start starts the updater goroutine.
+func (u *zoneUpdater) start() {
+	log.Info("starting topology zone updater")
+
+	go func() {
+		var pending *zoneUpdate
+		var retry <-chan time.Time
+		var lastErr string
+
+		for {
+			select {
+			case z := <-u.updates:
+				pending = z
+				retry = time.After(0)
+			case _ = <-retry:
+				err := u.agent.UpdateNodeResourceTopology(pending.policies, pending.zones,
+					zoneUpdateTimeout)
+				if err != nil {
+					// Only warn about new errors to avoid flooding the logs while
+					// the agent or the CRD is unavailable.
+					if err.Error() != lastErr {
+						log.Warn("failed to update node resource topology: %v", err)
+						lastErr = err.Error()
+					} else {
+						log.Debug("failed to update node resource topology: %v", err)
+					}
+					retry = time.After(zoneRetryInterval)
+				} else {
+					log.Debug("node resource topology updated")
+					pending = nil
+					retry = nil
+					lastErr = ""
+				}
+			}
+		}
+	}()
+}
+
+// update queues an update, replacing any update still pending.
+func (u *zoneUpdater) update(policies []string, zones []*TopologyZone) {
+	z := &zoneUpdate{policies: policies}
+	for _, zone := range zones {
+		tz := &agent_v1.TopologyZone{
+			Name:   zone.Name,
+			Type:   zone.Type,
+			Parent: zone.Parent,
+		}
+		for _, r := range zone.Resources {
+			tz.Resources = append(tz.Resources, &agent_v1.ZoneResource{
+				Name:        r.Name,
+				Capacity:    r.Capacity.String(),
+				Allocatable: r.Allocatable.String(),
+				Available:   r.Available.String(),
+			})
+		}
+		z.zones = append(z.zones, tz)
+	}
+
+	if u.last != nil && zoneUpdatesEqual(u.last, z) {
+		return
+	}
+	u.last = z
+
+	// Pop possibly pending value from the channel
+	select {
+	case <-u.updates:
+	default:
+	}
+	u.updates <- z
+}
+
+// updateZones exports the current topology zones of the active policy.
+func (p *policy) updateZones() {
+	if p.zones == nil {
+		return
+	}
+	r, ok := p.active.(ZoneReporter)
+	if !ok {
+		return
+	}
+	p.zones.update([]string{p.active.Name()}, r.GetTopologyZones())
+}
+
+// zoneUpdatesEqual checks if two topology zone updates are identical.
+func zoneUpdatesEqual(a, b *zoneUpdate) bool {
+	if len(a.policies) != len(b.policies) || len(a.zones) != len(b.zones) {
+		return false
+	}
+	for i := range a.policies {
+		if a.policies[i] != b.policies[i] {
+			return false
+		}
+	}
+	for i := range a.zones {
+		if !proto.Equal(a.zones[i], b.zones[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -17,7 +17,9 @@ package resmgr
 import (
 	"context"
 	"fmt"
+	"reflect"
 
+	"go.opencensus.io/trace"
 	criapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 
 	pkgcfg "github.com/intel/cri-resource-manager/pkg/config"
@@ -25,7 +27,9 @@ import (
 	config "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/events"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/policy"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/timeline"
 	"github.com/intel/cri-resource-manager/pkg/cri/server"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
 )
 
 // setupRequestProcessing prepares the resource manager for CRI request processing.
@@ -152,8 +156,9 @@ func (m *resmgr) startRequestProcessing() error {
 	if err := m.runPostReleaseHooks(ctx, "startup", del...); err != nil {
 		m.Error("startup: failed to run post-release hooks: %v", err)
 	}
+	m.updateAllAssignments()
 
-	return m.cache.Save()
+	return m.saveCache(ctx)
 }
 
 // syncWithCRI synchronizes cache pods and containers with the CRI runtime.
@@ -230,11 +235,12 @@ func (m *resmgr) RunPod(ctx context.Context, method string, request interface{},
 	}
 
 	podID := reply.(*criapi.RunPodSandboxResponse).PodSandboxId
+	resources := m.prefetchPodResources(request)
 
 	m.Lock()
 	defer m.Unlock()
 
-	pod := m.cache.InsertPod(podID, request, nil)
+	pod := m.cache.InsertPod(podID, request, nil, resources)
 	m.updateIntrospection()
 
 	// search for any lingering old version and clean up if found
@@ -396,19 +402,23 @@ func (m *resmgr) CreateContainer(ctx context.Context, method string, request int
 		}
 	}
 
-	container, err := m.cache.InsertContainer(request)
+	container, err := m.insertContainer(ctx, request)
 	if err != nil {
 		m.Error("%s: failed to insert new container to cache: %v", method, err)
 		return nil, resmgrError("%s: failed to insert new container to cache: %v", method, err)
 	}
 
 	container.SetCRIRequest(request)
+	log := m.containerLogger(container)
 
-	m.Info("%s: creating container %s...", method, container.PrettyName())
+	log.Info("%s: creating container %s...", method, container.PrettyName())
 
-	if err := m.policy.AllocateResources(container); err != nil {
-		m.Error("%s: failed to allocate resources for container %s: %v",
+	if err := m.allocateResources(ctx, container); err != nil {
+		log.Error("%s: failed to allocate resources for container %s: %v",
 			method, container.PrettyName(), err)
+		m.postPodEvent(container, events.PodEventWarning, events.AllocationFailed,
+			"failed to allocate resources for container %s: %v",
+			container.GetName(), err)
 		m.cache.DeleteContainer(container.GetCacheID())
 		return nil, resmgrError("failed to allocate container resources: %v", err)
 	}
@@ -421,7 +431,7 @@ func (m *resmgr) CreateContainer(ctx context.Context, method string, request int
 	})
 
 	if err := m.runPostAllocateHooks(ctx, method); err != nil {
-		m.Error("%s: failed to run post-allocate hooks for %s: %v",
+		log.Error("%s: failed to run post-allocate hooks for %s: %v",
 			method, container.PrettyName(), err)
 		m.policy.ReleaseResources(container)
 		m.runPostReleaseHooks(ctx, method, container)
@@ -433,14 +443,14 @@ func (m *resmgr) CreateContainer(ctx context.Context, method string, request int
 	reply, rqerr := handler(ctx, request)
 
 	if rqerr != nil {
-		m.Error("%s: failed to create container %s: %v", method, container.PrettyName(), rqerr)
+		log.Error("%s: failed to create container %s: %v", method, container.PrettyName(), rqerr)
 		m.policy.ReleaseResources(container)
 		m.runPostReleaseHooks(ctx, method, container)
 		m.cache.DeleteContainer(container.GetCacheID())
 		return nil, resmgrError("failed to create container: %v", rqerr)
 	}
 
-	m.cache.UpdateContainerID(container.GetCacheID(), reply)
+	m.updateContainerID(ctx, container, reply)
 	container.UpdateState(cache.ContainerStateCreated)
 	m.updateIntrospection()
 
@@ -463,10 +473,11 @@ func (m *resmgr) StartContainer(ctx context.Context, method string, request inte
 		return handler(ctx, request)
 	}
 
-	m.Info("%s: starting container %s...", method, container.PrettyName())
+	log := m.containerLogger(container)
+	log.Info("%s: starting container %s...", method, container.PrettyName())
 
 	if container.GetState() != cache.ContainerStateCreated {
-		m.Error("%s: refusing to start container %s in unexpected state %v",
+		log.Error("%s: refusing to start container %s in unexpected state %v",
 			method, container.PrettyName(), container.GetState())
 		return nil, resmgrError("refusing to start container %s in unexpexted state %v",
 			container.PrettyName(), container.GetState())
@@ -475,7 +486,7 @@ func (m *resmgr) StartContainer(ctx context.Context, method string, request inte
 	reply, rqerr := handler(ctx, request)
 
 	if rqerr != nil {
-		m.Error("%s: failed to start container %s: %v", method, container.PrettyName(), rqerr)
+		log.Error("%s: failed to start container %s: %v", method, container.PrettyName(), rqerr)
 		return nil, rqerr
 	}
 
@@ -487,11 +498,11 @@ func (m *resmgr) StartContainer(ctx context.Context, method string, request inte
 		Data:   container,
 	}
 	if _, err := m.policy.HandleEvent(e); err != nil {
-		m.Error("%s: policy failed to handle event %s: %v", method, e.Type, err)
+		log.Error("%s: policy failed to handle event %s: %v", method, e.Type, err)
 	}
 
 	if err := m.runPostStartHooks(ctx, method, container); err != nil {
-		m.Error("%s: failed to run post-start hooks for %s: %v",
+		log.Error("%s: failed to run post-start hooks for %s: %v",
 			method, container.PrettyName(), err)
 	}
 
@@ -518,26 +529,27 @@ func (m *resmgr) StopContainer(ctx context.Context, method string, request inter
 		return reply, rqerr
 	}
 
+	log := m.containerLogger(container)
 	if rqerr != nil {
-		m.Error("%s: failed to stop container %s: %v", method, container.PrettyName(), rqerr)
+		log.Error("%s: failed to stop container %s: %v", method, container.PrettyName(), rqerr)
 		return reply, rqerr
 	}
 
-	m.Info("%s: stopped container %s...", method, container.PrettyName())
+	log.Info("%s: stopped container %s...", method, container.PrettyName())
 
 	// Notes:
 	//   For now, we assume any error replies from CRI are about the container not
 	//   being found, in which case we still go ahead and finish locally stopping it...
 
 	if err := m.policy.ReleaseResources(container); err != nil {
-		m.Error("%s: failed to release resources for container %s: %v",
+		log.Error("%s: failed to release resources for container %s: %v",
 			method, container.PrettyName(), err)
 	}
 
 	container.UpdateState(cache.ContainerStateExited)
 
 	if err := m.runPostReleaseHooks(ctx, method, container); err != nil {
-		m.Error("%s: failed to run post-release hooks for %s: %v",
+		log.Error("%s: failed to run post-release hooks for %s: %v",
 			method, container.PrettyName(), err)
 	}
 
@@ -564,21 +576,22 @@ func (m *resmgr) RemoveContainer(ctx context.Context, method string, request int
 		return reply, rqerr
 	}
 
+	log := m.containerLogger(container)
 	if rqerr != nil {
-		m.Error("%s: failed to remove container %s: %v", method, container.PrettyName(), rqerr)
+		log.Error("%s: failed to remove container %s: %v", method, container.PrettyName(), rqerr)
 	} else {
-		m.Info("%s: removed container %s...", method, container.PrettyName())
+		log.Info("%s: removed container %s...", method, container.PrettyName())
 	}
 
 	if err := m.policy.ReleaseResources(container); err != nil {
-		m.Error("%s: failed to release resources for container %s: %v",
+		log.Error("%s: failed to release resources for container %s: %v",
 			method, container.PrettyName(), err)
 	}
 
 	container.UpdateState(cache.ContainerStateStale)
 
 	if err := m.runPostReleaseHooks(ctx, method, container); err != nil {
-		m.Error("%s: failed to run post-release hooks for %s: %v",
+		log.Error("%s: failed to run post-release hooks for %s: %v",
 			method, container.PrettyName(), err)
 	}
 
@@ -693,6 +706,7 @@ func (m *resmgr) rebalance(method string) error {
 		return nil
 	}
 
+	ctx := context.Background()
 	changes, err := m.policy.Rebalance()
 
 	if err != nil {
@@ -700,13 +714,111 @@ func (m *resmgr) rebalance(method string) error {
 	}
 
 	if changes {
-		if err := m.runPostUpdateHooks(context.Background(), method); err != nil {
+		if err := m.runPostUpdateHooks(ctx, method); err != nil {
 			m.Error("%s: failed to run post-update hooks: %v", method, err)
 			return resmgrError("%s: failed to run post-update hooks: %v", method, err)
 		}
 	}
 
-	return m.cache.Save()
+	return m.saveCache(ctx)
+}
+
+// UpdatePod updates the labels and annotations of a pod and re-evaluates its containers.
+func (m *resmgr) UpdatePod(meta *config.PodMetadata) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.Info("updating metadata of pod %s/%s...", meta.Namespace, meta.Name)
+
+	grants := map[string]*containerGrant{}
+	for _, p := range m.cache.GetPods() {
+		if p.GetUID() == meta.UID {
+			for _, c := range p.GetContainers() {
+				grants[c.GetCacheID()] = getContainerGrant(c)
+			}
+		}
+	}
+
+	containers, err := m.cache.UpdatePodMetadata(meta.UID, meta.Labels, meta.Annotations)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	method := "UpdatePod"
+
+	if m.policy != nil {
+		for _, c := range containers {
+			switch c.GetState() {
+			case cache.ContainerStateRunning, cache.ContainerStateCreated:
+				if err := m.policy.UpdateResources(c); err != nil {
+					m.Error("%s: failed to update resources of %s: %v",
+						method, c.PrettyName(), err)
+				}
+			}
+		}
+	}
+
+	// don't run update hooks for containers whose resources did not change
+	for _, c := range containers {
+		if old, ok := grants[c.GetCacheID()]; ok {
+			m.skipUnchangedGrant(method, c, old)
+		}
+	}
+
+	if err := m.runPostUpdateHooks(ctx, method); err != nil {
+		m.Error("%s: failed to run post-update hooks: %v", method, err)
+		return resmgrError("%s: failed to run post-update hooks: %v", method, err)
+	}
+
+	m.updateIntrospection()
+
+	return m.saveCache(ctx)
+}
+
+// containerGrant is a snapshot of the resources assigned to a container.
+type containerGrant struct {
+	resources     criapi.LinuxContainerResources
+	rdtClass      string
+	blockIOClass  string
+	toptierLimit  int64
+	pageMigration *cache.PageMigrate
+}
+
+// getContainerGrant takes a snapshot of the resources assigned to a container.
+func getContainerGrant(c cache.Container) *containerGrant {
+	g := &containerGrant{
+		rdtClass:      c.GetRDTClass(),
+		blockIOClass:  c.GetBlockIOClass(),
+		toptierLimit:  c.GetToptierLimit(),
+		pageMigration: c.GetPageMigration().Clone(),
+	}
+	if res := c.GetLinuxResources(); res != nil {
+		g.resources = *res
+		g.resources.HugepageLimits = nil
+		for _, l := range res.HugepageLimits {
+			g.resources.HugepageLimits = append(g.resources.HugepageLimits, &criapi.HugepageLimit{
+				PageSize: l.PageSize,
+				Limit:    l.Limit,
+			})
+		}
+	}
+	return g
+}
+
+// skipUnchangedGrant clears pending changes of a container if its resources are
+// the same as in the given snapshot, so that no update hooks are run for it.
+func (m *resmgr) skipUnchangedGrant(method string, c cache.Container, old *containerGrant) {
+	if !reflect.DeepEqual(old, getContainerGrant(c)) {
+		return
+	}
+	m.Debug("%s: resources of %s unchanged", method, c.PrettyName())
+	for _, ctrl := range c.GetPending() {
+		c.ClearPending(ctrl)
+	}
 }
 
 // DeliverPolicyEvent delivers a policy-specific event to the active policy.
@@ -720,6 +832,7 @@ func (m *resmgr) DeliverPolicyEvent(e *events.Policy) error {
 
 	m.Info("delivering policy event %s.%s...", e.Source, e.Type)
 
+	ctx := context.Background()
 	method := "DeliverPolicyEvent"
 	changes, err := m.policy.HandleEvent(e)
 
@@ -729,55 +842,80 @@ func (m *resmgr) DeliverPolicyEvent(e *events.Policy) error {
 	}
 
 	if changes {
-		if err = m.runPostUpdateHooks(context.Background(), method); err != nil {
+		if err = m.runPostUpdateHooks(ctx, method); err != nil {
 			m.Error("%s: failed to run post-update hooks: %v", method, err)
 			return resmgrError("%s: failed to run post-update hooks: %v", method, err)
 		}
 	}
 
-	m.cache.Save()
+	m.saveCache(ctx)
 	return nil
 }
 
 // setConfig activates a new configuration, either from the agent or from a file.
-func (m *resmgr) setConfig(v interface{}) error {
-	var err error
-
+func (m *resmgr) setConfig(v interface{}, source string) error {
 	m.Lock()
 	defer m.Unlock()
 
+	_, err := m.applyConfig(v, source, configOrigin(source))
+	return err
+}
+
+// applyConfig activates a new configuration with the resource manager locked.
+// Configuration originating from the agent is also stored in the cache. The
+// configuration version recorded in the history is returned.
+func (m *resmgr) applyConfig(v interface{}, source, origin string) (*config.ConfigVersion, error) {
+	var err error
+
 	switch cfg := v.(type) {
 	case *config.RawConfig:
 		err = pkgcfg.SetConfig(cfg.Data)
-	case string:
-		err = pkgcfg.SetConfigFromFile(cfg)
+	case []string:
+		err = pkgcfg.SetConfigFromFiles(cfg...)
 	default:
 		err = fmt.Errorf("invalid configuration source/type %T", v)
 	}
 	if err != nil {
 		m.Error("configuration rejected: %v", err)
-		return resmgrError("configuration rejected: %v", err)
+		return nil, resmgrError("configuration rejected: %v", err)
 	}
 
 	// synchronize state of controllers with new configuration
 	if err = m.control.StartStopControllers(m.cache, m.relay.Client()); err != nil {
 		m.Error("failed to synchronize controllers with new configuration: %v", err)
-		return resmgrError("failed to synchronize controllers with new configuration: %v", err)
+		return nil, resmgrError("failed to synchronize controllers with new configuration: %v", err)
 	}
 
 	if err = m.runPostUpdateHooks(context.Background(), "setConfig"); err != nil {
 		m.Error("failed to run post-update hooks after reconfiguration: %v", err)
-		return resmgrError("failed to run post-update hooks after reconfiguration: %v", err)
+		return nil, resmgrError("failed to run post-update hooks after reconfiguration: %v", err)
 	}
 
 	// if we managed to activate a configuration from the agent, store it in the cache
-	if cfg, ok := v.(*config.RawConfig); ok {
+	if cfg, ok := v.(*config.RawConfig); ok && (origin == configSourceAgent || origin == configSourceCache) {
 		m.cache.SetConfig(cfg)
+		m.stopFallbackWatch()
 	}
+	cv := m.recordConfig(v, source, false)
 
 	m.Info("successfully switched to new configuration")
 
-	return nil
+	return cv, nil
+}
+
+// containerLogger returns a logger which tags messages with the identifiers of a container.
+func (m *resmgr) containerLogger(c cache.Container) logger.Logger {
+	pod := ""
+	if p, ok := c.GetPod(); ok {
+		pod = p.GetName()
+	}
+	return m.With(
+		"namespace", c.GetNamespace(),
+		"pod", pod,
+		"podID", c.GetPodID(),
+		"container", c.GetName(),
+		"containerID", c.GetID(),
+	)
 }
 
 // runPostAllocateHooks runs the necessary hooks after allocating resources for some containers.
@@ -785,7 +923,7 @@ func (m *resmgr) runPostAllocateHooks(ctx context.Context, method string) error
 	for _, c := range m.cache.GetPendingContainers() {
 		switch c.GetState() {
 		case cache.ContainerStateRunning, cache.ContainerStateCreated:
-			if err := m.control.RunPostUpdateHooks(c); err != nil {
+			if err := m.control.RunPostUpdateHooks(ctx, c); err != nil {
 				m.Warn("%s post-update hook failed for %s: %v",
 					method, c.PrettyName(), err)
 			}
@@ -796,12 +934,16 @@ func (m *resmgr) runPostAllocateHooks(ctx context.Context, method string) error
 				}
 			}
 			m.policy.ExportResourceData(c)
+			m.updateAssignment(c)
+			m.recordAllocation(c, timeline.ActionUpdate, allocationReason("reallocated", method))
 		case cache.ContainerStateCreating:
-			if err := m.control.RunPreCreateHooks(c); err != nil {
+			if err := m.control.RunPreCreateHooks(ctx, c); err != nil {
 				m.Warn("%s pre-create hook failed for %s: %v",
 					method, c.PrettyName(), err)
 			}
 			m.policy.ExportResourceData(c)
+			m.updateAssignment(c)
+			m.recordAllocation(c, timeline.ActionAllocate, allocationReason("allocated", method))
 		default:
 			m.Warn("%s: skipping container %s (in state %v)", method,
 				c.PrettyName(), c.GetState())
@@ -812,18 +954,21 @@ func (m *resmgr) runPostAllocateHooks(ctx context.Context, method string) error
 
 // runPostStartHooks runs the necessary hooks after having started a container.
 func (m *resmgr) runPostStartHooks(ctx context.Context, method string, c cache.Container) error {
-	if err := m.control.RunPostStartHooks(c); err != nil {
+	if err := m.control.RunPostStartHooks(ctx, c); err != nil {
 		m.Error("%s: post-start hook failed for %s: %v", method, c.PrettyName(), err)
 	}
+	m.updateAssignment(c)
 	return nil
 }
 
 // runPostReleaseHooks runs the necessary hooks after releaseing resources of some containers
 func (m *resmgr) runPostReleaseHooks(ctx context.Context, method string, released ...cache.Container) error {
 	for _, c := range released {
-		if err := m.control.RunPostStopHooks(c); err != nil {
+		if err := m.control.RunPostStopHooks(ctx, c); err != nil {
 			m.Warn("post-stop hook failed for %s: %v", c.PrettyName(), err)
 		}
+		m.removeAssignment(c)
+		m.recordAllocation(c, timeline.ActionRelease, allocationReason("released", method))
 		if c.GetState() == cache.ContainerStateStale {
 			m.cache.DeleteContainer(c.GetCacheID())
 		}
@@ -831,14 +976,16 @@ func (m *resmgr) runPostReleaseHooks(ctx context.Context, method string, release
 	for _, c := range m.cache.GetPendingContainers() {
 		switch state := c.GetState(); state {
 		case cache.ContainerStateStale, cache.ContainerStateExited:
-			if err := m.control.RunPostStopHooks(c); err != nil {
+			if err := m.control.RunPostStopHooks(ctx, c); err != nil {
 				m.Warn("post-stop hook failed for %s: %v", c.PrettyName(), err)
 			}
+			m.removeAssignment(c)
+			m.recordAllocation(c, timeline.ActionRelease, allocationReason("released", method))
 			if state == cache.ContainerStateStale {
 				m.cache.DeleteContainer(c.GetCacheID())
 			}
 		case cache.ContainerStateRunning, cache.ContainerStateCreated:
-			if err := m.control.RunPostUpdateHooks(c); err != nil {
+			if err := m.control.RunPostUpdateHooks(ctx, c); err != nil {
 				m.Warn("post-update hook failed for %s: %v", c.PrettyName(), err)
 			}
 			if req, ok := c.ClearCRIRequest(); ok {
@@ -847,6 +994,8 @@ func (m *resmgr) runPostReleaseHooks(ctx context.Context, method string, release
 				}
 			}
 			m.policy.ExportResourceData(c)
+			m.updateAssignment(c)
+			m.recordAllocation(c, timeline.ActionUpdate, allocationReason("reallocated", method))
 		default:
 			m.Warn("%s: skipping pending container %s (in state %v)",
 				method, c.PrettyName(), c.GetState())
@@ -860,7 +1009,7 @@ func (m *resmgr) runPostUpdateHooks(ctx context.Context, method string) error {
 	for _, c := range m.cache.GetPendingContainers() {
 		switch c.GetState() {
 		case cache.ContainerStateRunning, cache.ContainerStateCreated:
-			if err := m.control.RunPostUpdateHooks(c); err != nil {
+			if err := m.control.RunPostUpdateHooks(ctx, c); err != nil {
 				return err
 			}
 			if req, ok := c.GetCRIRequest(); ok {
@@ -872,6 +1021,8 @@ func (m *resmgr) runPostUpdateHooks(ctx context.Context, method string) error {
 				}
 			}
 			m.policy.ExportResourceData(c)
+			m.updateAssignment(c)
+			m.recordAllocation(c, timeline.ActionUpdate, allocationReason("reallocated", method))
 		default:
 			m.Warn("%s: skipping container %s (in state %v)", method,
 				c.PrettyName(), c.GetState())
@@ -887,7 +1038,14 @@ func (m *resmgr) sendCRIRequest(ctx context.Context, request interface{}) (inter
 	case *criapi.UpdateContainerResourcesRequest:
 		req := request.(*criapi.UpdateContainerResourcesRequest)
 		m.Debug("sending update request for container %s...", req.ContainerId)
-		return client.UpdateContainerResources(ctx, req)
+		ctx, span := trace.StartSpan(ctx, "cri.UpdateContainerResources")
+		defer span.End()
+		span.AddAttributes(trace.StringAttribute("container", req.ContainerId))
+		reply, err := client.UpdateContainerResources(ctx, req)
+		if err != nil {
+			spanError(span, err)
+		}
+		return reply, err
 	default:
 		return nil, resmgrError("sendCRIRequest: unhandled request type %T", request)
 	}
@@ -15,6 +15,7 @@
 package resmgr
 
 import (
+	"fmt"
 	"golang.org/x/sys/unix"
 	"os"
 	"os/signal"
@@ -29,13 +30,17 @@ import (
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
 	config "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/config"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/control"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/ctl"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/introspect"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/metrics"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/policy"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/timeline"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/visualizer"
 	"github.com/intel/cri-resource-manager/pkg/instrumentation"
 	logger "github.com/intel/cri-resource-manager/pkg/log"
 	"github.com/intel/cri-resource-manager/pkg/utils"
+
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 )
 
 // ResourceManager is the interface we expose for controlling the CRI resource manager.
@@ -46,6 +51,8 @@ type ResourceManager interface {
 	Stop()
 	// SetConfig dynamically updates the resource manager configuration.
 	SetConfig(*config.RawConfig) error
+	// DryRunConfig checks what a configuration update would change without applying it.
+	DryRunConfig(*config.RawConfig) (pkgcfg.Diff, []error)
 	// SetAdjustment dynamically updates external adjustments.
 	SetAdjustment(*config.Adjustment) map[string]error
 	// SendEvent sends an event to be processed by the resource manager.
@@ -54,6 +61,14 @@ type ResourceManager interface {
 	ResourceManagerTestAPI
 }
 
+// Configuration sources recorded in the configuration history.
+const (
+	configSourceAgent    = "agent"
+	configSourceCache    = "cache"
+	configSourceFile     = "file"
+	configSourceRollback = "rollback to version"
+)
+
 // resmgr is the implementation of ResourceManager.
 type resmgr struct {
 	logger.Logger
@@ -63,6 +78,7 @@ type resmgr struct {
 	policy       policy.Policy      // resource manager policy
 	policySwitch bool               // active policy is being switched
 	configServer config.Server      // configuration management server
+	ctlServer    ctl.Server         // server for cri-resmgr-ctl requests
 	control      control.Control    // policy controllers/enforcement
 	agent        agent.Interface    // connection to cri-resmgr agent
 	conf         *config.RawConfig  // pending for saving in cache
@@ -70,7 +86,11 @@ type resmgr struct {
 	events       chan interface{}   // channel for delivering events
 	stop         chan interface{}   // channel for signalling shutdown to goroutines
 	signals      chan os.Signal     // signal channel
+	watch        *configWatch       // configuration file watcher
+	fallback     bool               // running with fallback configuration
 	introspect   *introspect.Server // server for external introspection
+	podEvents    *podEventPoster    // poster for pod events
+	timeline     *timeline.Timeline // allocation timeline
 }
 
 // NewResourceManager creates a new ResourceManager instance.
@@ -106,6 +126,10 @@ func NewResourceManager() (ResourceManager, error) {
 		return nil, err
 	}
 
+	if err := m.setupControlServer(); err != nil {
+		return nil, err
+	}
+
 	if err := m.setupPolicy(); err != nil {
 		return nil, err
 	}
@@ -158,11 +182,17 @@ func (m *resmgr) Start() error {
 		return resmgrError("failed to start CRI relay: %v", err)
 	}
 
-	if opt.ForceConfig == "" {
-		if err := m.configServer.Start(opt.ConfigSocket); err != nil {
-			return resmgrError("failed to start configuration server: %v", err)
-		}
+	if err := m.setupConfigWatch(); err != nil {
+		return err
+	}
+
+	// With a forced configuration the server still serves pod updates, queries,
+	// and diagnostics, but rejects configuration and adjustment updates.
+	if err := m.configServer.Start(opt.ConfigSocket); err != nil {
+		return resmgrError("failed to start configuration server: %v", err)
+	}
 
+	if !forcedConfig() {
 		// We never store a forced configuration in the cache. However, if we're not
 		// running with a forced configuration, and the configuration is pending to
 		// get stored in the cache (IOW, it is a new one acquired from an agent), then
@@ -173,6 +203,10 @@ func (m *resmgr) Start() error {
 		}
 	}
 
+	if err := m.ctlServer.Start(opt.ControlSocket); err != nil {
+		return resmgrError("failed to start control server: %v", err)
+	}
+
 	m.Info("up and running")
 
 	return nil
@@ -189,21 +223,48 @@ func (m *resmgr) Stop() {
 		close(m.signals)
 		m.signals = nil
 	}
+	m.stopConfigWatch()
 
 	m.configServer.Stop()
+	m.ctlServer.Stop()
 	m.relay.Stop()
 	m.stopIntrospection()
 	m.stopEventProcessing()
+
+	if m.timeline != nil {
+		m.timeline.Close()
+	}
 }
 
 // SetConfig pushes new configuration to the resource manager.
 func (m *resmgr) SetConfig(conf *config.RawConfig) error {
+	if forcedConfig() {
+		m.Warn("rejecting configuration from agent, using forced configuration")
+		return resmgrError("configuration is forced, updates from the agent are disabled")
+	}
 	m.Info("applying new configuration from agent...")
-	return m.setConfig(conf)
+	return m.setConfig(conf, configSourceAgent)
+}
+
+// DryRunConfig checks what new configuration would change without applying it.
+func (m *resmgr) DryRunConfig(conf *config.RawConfig) (pkgcfg.Diff, []error) {
+	m.Info("checking new configuration from agent...")
+
+	m.RLock()
+	defer m.RUnlock()
+	return pkgcfg.DryRunFromStringMap(conf.Data)
 }
 
 // SetAdjustment pushes new external adjustments to the resource manager.
 func (m *resmgr) SetAdjustment(adjustment *config.Adjustment) map[string]error {
+	if forcedConfig() {
+		m.Warn("rejecting adjustments from agent, using forced configuration")
+		errors := map[string]error{}
+		for name := range adjustment.Adjustments {
+			errors[name] = resmgrError("configuration is forced, adjustments from the agent are disabled")
+		}
+		return errors
+	}
 	m.Info("applying new adjustments from agent...")
 
 	m.Lock()
@@ -211,10 +272,100 @@ func (m *resmgr) SetAdjustment(adjustment *config.Adjustment) map[string]error {
 	return m.setAdjustment(adjustment)
 }
 
-// setConfigFromFile pushes new configuration to the resource manager from a file.
-func (m *resmgr) setConfigFromFile(path string) error {
-	m.Info("applying new configuration from file %s...", path)
-	return m.setConfig(path)
+// setConfigFromFiles pushes new configuration to the resource manager from files.
+func (m *resmgr) setConfigFromFiles(file, dir string) error {
+	files, err := configFiles(file, dir)
+	if err != nil {
+		return err
+	}
+	m.Info("applying new configuration from %s...", strings.Join(files, ", "))
+	return m.setConfig(files, configSourceFile+" "+strings.Join(files, ","))
+}
+
+// GetConfigHistory returns the configuration history, oldest version first.
+func (m *resmgr) GetConfigHistory() []*config.ConfigVersion {
+	m.RLock()
+	defer m.RUnlock()
+	return m.cache.GetConfigHistory()
+}
+
+// GetEffectiveConfig returns the effective configuration of all modules.
+func (m *resmgr) GetEffectiveConfig() (map[string]string, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	data, err := pkgcfg.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return data.StringMap()
+}
+
+// RollbackConfig re-applies the given version from the configuration history.
+func (m *resmgr) RollbackConfig(version uint64) (*config.ConfigVersion, error) {
+	m.Info("rolling back to configuration version %d...", version)
+
+	m.Lock()
+	defer m.Unlock()
+
+	cv, ok := m.cache.LookupConfigVersion(version)
+	if !ok {
+		return nil, resmgrError("configuration version %d not found in history", version)
+	}
+
+	origin := configOrigin(cv.Source)
+	source := fmt.Sprintf("%s %d (%s)", configSourceRollback, version, origin)
+
+	return m.applyConfig(cv.Config, source, origin)
+}
+
+// configOrigin returns the original kind of source (agent, cache, or file) for
+// a recorded configuration source. For rolled back configuration this is the
+// origin of the configuration that was rolled back to.
+func configOrigin(source string) string {
+	if strings.HasPrefix(source, configSourceRollback) {
+		if i := strings.LastIndex(source, "("); i >= 0 {
+			return strings.TrimSuffix(source[i+1:], ")")
+		}
+		return ""
+	}
+	return strings.SplitN(source, " ", 2)[0]
+}
+
+// recordConfig records an applied configuration in the configuration history.
+// It returns the recorded version, or nil if nothing was recorded.
+func (m *resmgr) recordConfig(v interface{}, source string, startup bool) *config.ConfigVersion {
+	var cfg *config.RawConfig
+
+	switch v := v.(type) {
+	case *config.RawConfig:
+		cfg = v
+	case []string:
+		data, err := pkgcfg.DataFromFiles(v...)
+		if err == nil {
+			cfg = &config.RawConfig{}
+			cfg.Data, err = data.StringMap()
+		}
+		if err != nil {
+			m.Error("failed to record configuration in history: %v", err)
+			return nil
+		}
+	}
+
+	// don't flood the history with the same configuration on every restart
+	if startup {
+		if history := m.cache.GetConfigHistory(); len(history) > 0 {
+			if history[len(history)-1].Checksum == cfg.Checksum() {
+				return nil
+			}
+		}
+	}
+
+	cv := m.cache.RecordConfig(cfg, source)
+	m.Info("recorded configuration version %d (source: %s, checksum: %s)",
+		cv.Version, source, cv.Checksum)
+
+	return cv
 }
 
 // setAdjustments pushes new external policies to the resource manager.
@@ -269,7 +420,10 @@ func (m *resmgr) resetCachedConfig() int {
 func (m *resmgr) setupCache() error {
 	var err error
 
-	options := cache.Options{CacheDir: opt.RelayDir}
+	options := cache.Options{
+		CacheDir:     opt.RelayDir,
+		PodResources: m.queryPodResources,
+	}
 	if m.cache, err = cache.NewCache(options); err != nil {
 		return resmgrError("failed to create cache: %v", err)
 	}
@@ -278,6 +432,49 @@ func (m *resmgr) setupCache() error {
 
 }
 
+// podResourcesTimeout is the timeout for looking up pod resource requirements from the agent.
+const podResourcesTimeout = 1 * time.Second
+
+// prefetchPodResources looks up the resource requirements of a pod being created.
+// This is done before taking the lock, to not hold up other requests if the
+// node agent is slow to reply. It returns nil if the pod is annotated with its
+// resource requirements, and empty requirements if the lookup fails.
+func (m *resmgr) prefetchPodResources(request interface{}) *cache.PodResourceRequirements {
+	cfg := request.(*criapi.RunPodSandboxRequest).GetConfig()
+	meta := cfg.GetMetadata()
+	if meta == nil {
+		return nil
+	}
+	if _, ok := cfg.GetAnnotations()[cache.KeyResourceAnnotation]; ok {
+		return nil
+	}
+
+	resources, err := m.queryPodResources(meta.Namespace, meta.Name, meta.Uid)
+	if err != nil {
+		m.Warn("failed to look up resource requirements of pod %s/%s: %v",
+			meta.Namespace, meta.Name, err)
+		return &cache.PodResourceRequirements{}
+	}
+	return resources
+}
+
+// queryPodResources looks up the resource requirements of a pod from the node agent.
+func (m *resmgr) queryPodResources(namespace, name, uid string) (*cache.PodResourceRequirements, error) {
+	if m.agent == nil || !m.agent.IsConnected() {
+		return nil, resmgrError("not connected to node agent")
+	}
+
+	initContainers, containers, err := m.agent.GetPodResources(namespace, name, uid, podResourcesTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cache.PodResourceRequirements{
+		InitContainers: initContainers,
+		Containers:     containers,
+	}, nil
+}
+
 // setupAgentInterface sets up the connection to the node agent.
 func (m *resmgr) setupAgentInterface() error {
 	var err error
@@ -293,7 +490,8 @@ func (m *resmgr) setupAgentInterface() error {
 func (m *resmgr) setupConfigServer() error {
 	var err error
 
-	if m.configServer, err = config.NewConfigServer(m.SetConfig, m.SetAdjustment); err != nil {
+	if m.configServer, err = config.NewConfigServer(m.SetConfig, m.SetAdjustment, m.DryRunConfig, m.UpdatePod,
+		m.CollectDiagnostics, m); err != nil {
 		return resmgrError("failed to create configuration notification server: %v", err)
 	}
 
@@ -302,9 +500,9 @@ func (m *resmgr) setupConfigServer() error {
 
 // checkOpts checks the command line options for obvious errors.
 func (m *resmgr) checkOpts() error {
-	if opt.ForceConfig != "" && opt.FallbackConfig != "" {
-		return resmgrError("both fallback (%s) and forced (%s) configurations given",
-			opt.FallbackConfig, opt.ForceConfig)
+	if forcedConfig() && opt.FallbackConfig != "" {
+		return resmgrError("both fallback (%s) and forced (file %q, directory %q) configurations given",
+			opt.FallbackConfig, opt.ForceConfig, opt.ForceConfigDir)
 	}
 
 	return nil
@@ -330,12 +528,17 @@ func (m *resmgr) loadConfig() error {
 	//   become a problem that we'll need to solve.
 	//
 
-	if opt.ForceConfig != "" {
-		m.Info("using forced configuration %s...", opt.ForceConfig)
-		if err := pkgcfg.SetConfigFromFile(opt.ForceConfig); err != nil {
+	if forcedConfig() {
+		files, err := configFiles(opt.ForceConfig, opt.ForceConfigDir)
+		if err != nil {
+			return err
+		}
+		m.Info("using forced configuration %s...", strings.Join(files, ", "))
+		if err := pkgcfg.SetConfigFromFiles(files...); err != nil {
 			return resmgrError("failed to load forced configuration %s: %v",
-				opt.ForceConfig, err)
+				strings.Join(files, ", "), err)
 		}
+		m.recordConfig(files, configSourceFile+" "+strings.Join(files, ","), true)
 		return m.setupConfigSignal(opt.ForceConfigSignal)
 	}
 
@@ -343,6 +546,7 @@ func (m *resmgr) loadConfig() error {
 	if conf, err := m.agent.GetConfig(1 * time.Second); err == nil {
 		if err = pkgcfg.SetConfig(conf.Data); err == nil {
 			m.conf = conf // schedule storing in cache if we ever manage to start up
+			m.recordConfig(conf, configSourceAgent, true)
 			return nil
 		}
 		m.Error("configuration from agent failed to apply: %v", err)
@@ -352,6 +556,7 @@ func (m *resmgr) loadConfig() error {
 	if conf := m.cache.GetConfig(); conf != nil {
 		err := pkgcfg.SetConfig(conf.Data)
 		if err == nil {
+			m.recordConfig(conf, configSourceCache, true)
 			return nil
 		}
 		m.Error("failed to activate cached configuration: %v", err)
@@ -363,6 +568,8 @@ func (m *resmgr) loadConfig() error {
 			return resmgrError("failed to load fallback configuration %s: %v",
 				opt.FallbackConfig, err)
 		}
+		m.recordConfig([]string{opt.FallbackConfig}, configSourceFile+" "+opt.FallbackConfig, true)
+		m.fallback = true
 		return nil
 	}
 
@@ -395,11 +602,10 @@ func (m *resmgr) setupConfigSignal(signame string) error {
 				}
 			}
 
-			m.Info("reloading forced configuration %s...", opt.ForceConfig)
+			m.Info("reloading forced configuration...")
 
-			if err := m.setConfigFromFile(opt.ForceConfig); err != nil {
-				m.Error("failed to reload forced configuration %s: %v",
-					opt.ForceConfig, err)
+			if err := m.setConfigFromFiles(opt.ForceConfig, opt.ForceConfigDir); err != nil {
+				m.Error("failed to reload forced configuration: %v", err)
 			}
 		}
 	}(m.signals)
@@ -407,6 +613,74 @@ func (m *resmgr) setupConfigSignal(signame string) error {
 	return nil
 }
 
+// setupConfigWatch sets up watching the forced or fallback configuration for changes.
+func (m *resmgr) setupConfigWatch() error {
+	if !opt.ConfigWatch {
+		return nil
+	}
+
+	var file, dir, kind string
+	switch {
+	case forcedConfig():
+		file, dir, kind = opt.ForceConfig, opt.ForceConfigDir, "forced"
+	case m.fallback:
+		file, kind = opt.FallbackConfig, "fallback"
+	default:
+		return nil
+	}
+
+	// A reload can fire after the watch has been stopped or replaced, in which
+	// case we must not reload. w is set with the resmgr lock held, so we check
+	// it with the lock held, too.
+	var w *configWatch
+	reload := func() {
+		m.RLock()
+		active := w != nil && m.watch == w
+		m.RUnlock()
+		if !active {
+			return
+		}
+		m.Info("reloading %s configuration...", kind)
+		if err := m.setConfigFromFiles(file, dir); err != nil {
+			m.Error("failed to reload %s configuration: %v", kind, err)
+		}
+	}
+
+	w, err := newConfigWatch(file, dir, opt.ConfigWatchDelay, reload)
+	if err != nil {
+		return err
+	}
+	m.watch = w
+
+	return nil
+}
+
+// stopConfigWatch stops watching configuration, the caller must hold the resmgr lock.
+func (m *resmgr) stopConfigWatch() {
+	if m.watch != nil {
+		m.watch.Stop()
+		m.watch = nil
+	}
+}
+
+// stopFallbackWatch stops watching the fallback configuration once it is superseded.
+func (m *resmgr) stopFallbackWatch() {
+	if !m.fallback {
+		return
+	}
+
+	m.fallback = false
+	if m.watch != nil {
+		m.Info("fallback configuration superseded, stopping to watch it")
+		m.stopConfigWatch()
+	}
+}
+
+// forcedConfig checks if we were started with a forced configuration.
+func forcedConfig() bool {
+	return opt.ForceConfig != "" || opt.ForceConfigDir != ""
+}
+
 // setupPolicy sets up policy with the configured/active backend
 func (m *resmgr) setupPolicy() error {
 	var err error
@@ -489,6 +763,12 @@ func (m *resmgr) setupIntrospection() error {
 	}
 	m.introspect = i
 
+	mux.HandleFunc(logger.LevelsPath, logger.ServeLevels)
+
+	if err := m.setupTimeline(mux); err != nil {
+		return err
+	}
+
 	if !opt.DisableUI {
 		if err := visualizer.Setup(mux); err != nil {
 			m.Error("failed to set up UI for visualization: %v", err)
@@ -0,0 +1,115 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/agent"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+)
+
+// fakeAgent is an agent.Interface which only serves pod resource lookups.
+type fakeAgent struct {
+	agent.Interface
+	connected bool
+	queries   []string
+}
+
+func (a *fakeAgent) IsConnected() bool {
+	return a.connected
+}
+
+func (a *fakeAgent) GetPodResources(namespace, name, uid string, _ time.Duration) (map[string]core_v1.ResourceRequirements, map[string]core_v1.ResourceRequirements, error) {
+	a.queries = append(a.queries, uid)
+	return nil, map[string]core_v1.ResourceRequirements{"ctr0": {}}, nil
+}
+
+func TestGetPodResources(t *testing.T) {
+	runRequest := func(uid string, annotations map[string]string) *criapi.RunPodSandboxRequest {
+		return &criapi.RunPodSandboxRequest{
+			Config: &criapi.PodSandboxConfig{
+				Metadata:    &criapi.PodSandboxMetadata{Namespace: "default", Name: "pod", Uid: uid},
+				Annotations: annotations,
+			},
+		}
+	}
+
+	a := &fakeAgent{}
+	m := &resmgr{Logger: logger.NewLogger("resource-manager-test"), agent: a}
+
+	if _, err := m.queryPodResources("default", "pod", "uid-0"); err == nil {
+		t.Errorf("expected an error without a connection to the agent")
+	}
+	if pr := m.prefetchPodResources(runRequest("uid-0", nil)); pr == nil || len(pr.Containers) != 0 {
+		t.Errorf("expected empty resources without a connection to the agent, got %+v", pr)
+	}
+	if len(a.queries) != 0 {
+		t.Errorf("expected no queries without a connection to the agent, got %v", a.queries)
+	}
+
+	a.connected = true
+	annotated := map[string]string{cache.KeyResourceAnnotation: "{}"}
+	if pr := m.prefetchPodResources(runRequest("uid-1", annotated)); pr != nil {
+		t.Errorf("expected no prefetch for annotated pod, got %+v", pr)
+	}
+
+	dir, err := ioutil.TempDir("", "resource-manager-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	m.cache, err = cache.NewCache(cache.Options{CacheDir: dir, PodResources: m.queryPodResources})
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+
+	resources := m.prefetchPodResources(runRequest("uid-2", nil))
+	if resources == nil {
+		t.Fatalf("expected prefetched resources")
+	}
+	pod := m.cache.InsertPod("pod-id-2", runRequest("uid-2", nil), nil, resources)
+	if _, ok := pod.GetPodResourceRequirements().Containers["ctr0"]; !ok {
+		t.Errorf("expected prefetched resources for pod, got %+v", pod.GetPodResourceRequirements())
+	}
+	m.cache.InsertPod("pod-id-3", runRequest("uid-3", nil), nil, nil)
+	if len(a.queries) != 2 || a.queries[0] != "uid-2" || a.queries[1] != "uid-3" {
+		t.Errorf("expected queries for uid-2 and uid-3, got %v", a.queries)
+	}
+}
+
+func TestConfigOrigin(t *testing.T) {
+	tcases := map[string]string{
+		configSourceAgent:                             configSourceAgent,
+		configSourceCache:                             configSourceCache,
+		configSourceFile + " /etc/cri-resmgr/a,b":     configSourceFile,
+		configSourceRollback + " 3 (agent)":           configSourceAgent,
+		configSourceRollback + " 5 (file)":            configSourceFile,
+		configSourceRollback + " 7":                   "",
+		configSourceFile + " /etc/cri-resmgr/(weird)": configSourceFile,
+	}
+	for source, expected := range tcases {
+		if origin := configOrigin(source); origin != expected {
+			t.Errorf("expected origin %q for source %q, got %q", expected, source, origin)
+		}
+	}
+}
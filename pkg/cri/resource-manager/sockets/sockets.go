@@ -23,6 +23,8 @@ const (
 	ResourceManagerAgent = "/var/run/cri-resmgr/cri-resmgr-agent.sock"
 	// ResourceManagerConfig for resource manager configuration notifications.
 	ResourceManagerConfig = "/var/run/cri-resmgr/cri-resmgr-config.sock"
+	// ResourceManagerControl is the socket for querying and operating the resource manager.
+	ResourceManagerControl = "/var/run/cri-resmgr/cri-resmgr-control.sock"
 	// DirPermissions is the permissions to create the directory for sockets with.
 	DirPermissions = 0711
 )
@@ -0,0 +1,108 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/policy"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/timeline"
+	xhttp "github.com/intel/cri-resource-manager/pkg/instrumentation/http"
+)
+
+// setupTimeline sets up recording and serving the allocation timeline.
+func (m *resmgr) setupTimeline(mux *xhttp.ServeMux) error {
+	if opt.TimelineSize <= 0 {
+		m.Info("allocation timeline is disabled")
+		return nil
+	}
+
+	t, err := timeline.New(opt.TimelineSize, opt.TimelineFile)
+	if err != nil && opt.TimelineFile != "" {
+		m.Error("failed to set up allocation timeline with file %s: %v",
+			opt.TimelineFile, err)
+		m.Warn("allocation timeline will not be persisted")
+		t, err = timeline.New(opt.TimelineSize, "")
+	}
+	if err != nil {
+		return resmgrError("failed to set up allocation timeline: %v", err)
+	}
+	m.timeline = t
+
+	mux.Handle(timeline.Path, m.timeline)
+
+	return nil
+}
+
+// allocationTriggers describe the requests and events triggering allocations.
+var allocationTriggers = map[string]string{
+	"startup":                  "resynchronization with the runtime",
+	"RunPodSandbox":            "pod creation",
+	"StopPodSandbox":           "pod stop",
+	"RemovePodSandbox":         "pod removal",
+	"CreateContainer":          "container creation",
+	"StopContainer":            "container stop",
+	"RemoveContainer":          "container removal",
+	"ListContainers":           "container resynchronization",
+	"UpdateContainerResources": "container resource update",
+	"Rebalance":                "rebalancing request",
+	"setAdjustment":            "adjustment change",
+	"setConfig":                "configuration change",
+	"UpdatePod":                "pod metadata change",
+	"DeliverPolicyEvent":       "policy event",
+	"SetContainerTags":         "container tag change",
+}
+
+// allocationReason describes the decision of the active policy and the request
+// or event which triggered it, for example "reallocated by topology-aware policy
+// on container creation".
+func allocationReason(decision, method string) string {
+	trigger, ok := allocationTriggers[method]
+	if !ok {
+		trigger = method
+	}
+	return decision + " by " + policy.ActivePolicy() + " policy on " + trigger
+}
+
+// recordAllocation records the current allocation of a container in the timeline.
+func (m *resmgr) recordAllocation(c cache.Container, action, reason string) {
+	if m.timeline == nil {
+		return
+	}
+
+	e := &timeline.Entry{
+		Namespace:    c.GetNamespace(),
+		Container:    c.GetName(),
+		ContainerID:  c.GetID(),
+		Action:       action,
+		CPUs:         c.GetCpusetCpus(),
+		Memory:       c.GetCpusetMems(),
+		RDTClass:     c.GetRDTClass(),
+		BlockIOClass: c.GetBlockIOClass(),
+		Reason:       reason,
+	}
+	if e.ContainerID == "" {
+		e.ContainerID = c.GetCacheID()
+	}
+	if pod, ok := c.GetPod(); ok {
+		e.Pod = pod.GetName()
+	}
+	if action != timeline.ActionRelease {
+		if pool, ok := m.policy.GetContainerPool(c); ok {
+			e.Pool = pool
+		}
+	}
+
+	m.timeline.Record(e)
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeline
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// Path is the HTTP path for querying the allocation timeline.
+	Path = "/timeline"
+)
+
+// ServeHTTP serves a timeline query.
+//
+// The query parameters are 'container' for the container ID (prefix), name, pod/name, or
+// namespace/pod/name, 'since' and 'until' for the time window, either as RFC3339 timestamps
+// or as durations relative to the current time, and 'limit' for the maximum number of the
+// most recent entries to return.
+func (t *Timeline) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q, err := parseQuery(req.URL.Query(), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(t.Query(q))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// parseQuery parses HTTP query parameters into a timeline query.
+func parseQuery(values url.Values, now time.Time) (*Query, error) {
+	var err error
+
+	q := &Query{Container: values.Get("container")}
+
+	if q.Since, err = parseTime(values.Get("since"), now); err != nil {
+		return nil, timelineError("invalid 'since': %v", err)
+	}
+	if q.Until, err = parseTime(values.Get("until"), now); err != nil {
+		return nil, timelineError("invalid 'until': %v", err)
+	}
+	if limit := values.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil || q.Limit < 0 {
+			return nil, timelineError("invalid 'limit' %q", limit)
+		}
+	}
+
+	return q, nil
+}
+
+// parseTime parses an RFC3339 timestamp, or a duration relative to now.
+func parseTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, timelineError("%q is neither an RFC3339 time nor a duration", value)
+	}
+	if d < 0 {
+		d = -d
+	}
+	return now.Add(-d), nil
+}
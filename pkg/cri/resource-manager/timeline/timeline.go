@@ -0,0 +1,310 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeline
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+)
+
+// Allocation actions recorded in the timeline.
+const (
+	// ActionAllocate records the initial allocation of resources for a container.
+	ActionAllocate = "allocate"
+	// ActionUpdate records a change in the resources allocated to a container.
+	ActionUpdate = "update"
+	// ActionRelease records the release of the resources of a container.
+	ActionRelease = "release"
+)
+
+// Entry is a single allocation event in the timeline.
+type Entry struct {
+	Time         time.Time // time of the event
+	Namespace    string    // namespace of the pod of the container
+	Pod          string    // name of the pod of the container
+	Container    string    // name of the container
+	ContainerID  string    // container runtime ID
+	Action       string    // allocation action
+	CPUs         string    // CPUs the container is allowed to run on
+	Memory       string    // memory nodes the container is allowed to use
+	Pool         string    // pool the container is assigned to
+	RDTClass     string    // RDT class of the container
+	BlockIOClass string    // block I/O class of the container
+	Reason       string    // policy decision and the request or event triggering it
+}
+
+// Query selects entries from the timeline.
+type Query struct {
+	Container string    // container ID (prefix), name, pod/name, or namespace/pod/name
+	Since     time.Time // earliest time of entries, if set
+	Until     time.Time // latest time of entries, if set
+	Limit     int       // maximum number of most recent entries, if non-zero
+}
+
+// Timeline keeps a bounded history of allocation events, optionally persisted.
+type Timeline struct {
+	sync.RWMutex
+	size    int               // maximum number of entries kept
+	entries []*Entry          // entries, a ring buffer once full
+	next    int               // slot for the next entry once full
+	last    map[string]*Entry // last entry per container
+	path    string            // file to persist entries in, if any
+	file    *os.File          // file opened for appending entries
+	written int               // entries appended since last compaction
+}
+
+// our logger instance
+var log = logger.NewLogger("timeline")
+
+// New creates a timeline of the given size, persisting it in the given file if it is set.
+func New(size int, path string) (*Timeline, error) {
+	if size <= 0 {
+		return nil, timelineError("invalid timeline size %d", size)
+	}
+
+	t := &Timeline{
+		size:    size,
+		entries: make([]*Entry, 0, size),
+		last:    make(map[string]*Entry),
+		path:    path,
+	}
+
+	if path != "" {
+		if err := t.load(); err != nil {
+			return nil, err
+		}
+		if err := t.compact(); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// Record records an entry, unless it is an update which does not change anything.
+func (t *Timeline) Record(e *Entry) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	if prev, ok := t.last[e.ContainerID]; ok && e.Action == ActionUpdate && sameAllocation(prev, e) {
+		return false
+	}
+
+	t.add(e)
+
+	if t.file != nil {
+		if err := t.persist(e); err != nil {
+			log.Error("failed to persist allocation timeline: %v", err)
+		}
+	}
+
+	return true
+}
+
+// Query returns the entries matching the query, oldest first.
+func (t *Timeline) Query(q *Query) []*Entry {
+	t.RLock()
+	defer t.RUnlock()
+
+	entries := []*Entry{}
+	for _, e := range t.ordered() {
+		if !q.Since.IsZero() && e.Time.Before(q.Since) {
+			continue
+		}
+		if !q.Until.IsZero() && e.Time.After(q.Until) {
+			continue
+		}
+		if q.Container != "" && !e.matches(q.Container) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	if q.Limit > 0 && len(entries) > q.Limit {
+		entries = entries[len(entries)-q.Limit:]
+	}
+
+	return entries
+}
+
+// Close closes the file the timeline is persisted in.
+func (t *Timeline) Close() error {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// add adds an entry, replacing the oldest one if the timeline is full.
+func (t *Timeline) add(e *Entry) {
+	if len(t.entries) < t.size {
+		t.entries = append(t.entries, e)
+	} else {
+		old := t.entries[t.next]
+		if t.last[old.ContainerID] == old {
+			delete(t.last, old.ContainerID)
+		}
+		t.entries[t.next] = e
+		t.next = (t.next + 1) % t.size
+	}
+
+	if e.Action == ActionRelease {
+		delete(t.last, e.ContainerID)
+	} else {
+		t.last[e.ContainerID] = e
+	}
+}
+
+// ordered returns all entries, oldest first.
+func (t *Timeline) ordered() []*Entry {
+	if len(t.entries) < t.size {
+		return t.entries
+	}
+	entries := make([]*Entry, 0, len(t.entries))
+	entries = append(entries, t.entries[t.next:]...)
+	entries = append(entries, t.entries[:t.next]...)
+	return entries
+}
+
+// load loads persisted entries.
+func (t *Timeline) load() error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return timelineError("failed to open %q: %v", t.path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		e := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			log.Warn("skipping corrupt entry in %q: %v", t.path, err)
+			continue
+		}
+		t.add(e)
+	}
+	if err := scanner.Err(); err != nil {
+		log.Warn("failed to fully load %q: %v", t.path, err)
+	}
+
+	log.Info("loaded %d allocation timeline entries from %s", len(t.entries), t.path)
+
+	return nil
+}
+
+// persist appends an entry to the file, compacting the file once it has grown too large.
+func (t *Timeline) persist(e *Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := t.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	t.written++
+	if t.written < t.size {
+		return nil
+	}
+
+	return t.compact()
+}
+
+// compact rewrites the file with the current entries and reopens it for appending.
+func (t *Timeline) compact() error {
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0700); err != nil {
+		return timelineError("failed to create directory for %q: %v", t.path, err)
+	}
+
+	tmp := t.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return timelineError("failed to create %q: %v", tmp, err)
+	}
+	w := bufio.NewWriter(f)
+	for _, e := range t.ordered() {
+		data, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		w.Write(append(data, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return timelineError("failed to write %q: %v", tmp, err)
+	}
+	f.Close()
+
+	if err := os.Rename(tmp, t.path); err != nil {
+		return timelineError("failed to replace %q: %v", t.path, err)
+	}
+
+	t.file, err = os.OpenFile(t.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return timelineError("failed to open %q: %v", t.path, err)
+	}
+	t.written = 0
+
+	return nil
+}
+
+// matches checks if the entry is for the given container.
+func (e *Entry) matches(container string) bool {
+	switch parts := strings.Split(container, "/"); len(parts) {
+	case 1:
+		return e.Container == container || strings.HasPrefix(e.ContainerID, container)
+	case 2:
+		return e.Pod == parts[0] && e.Container == parts[1]
+	case 3:
+		return e.Namespace == parts[0] && e.Pod == parts[1] && e.Container == parts[2]
+	}
+	return false
+}
+
+// sameAllocation checks if two entries record the same allocation.
+func sameAllocation(a, b *Entry) bool {
+	return a.CPUs == b.CPUs && a.Memory == b.Memory && a.Pool == b.Pool &&
+		a.RDTClass == b.RDTClass && a.BlockIOClass == b.BlockIOClass
+}
+
+// timelineError returns a formatted timeline-specific error.
+func timelineError(format string, args ...interface{}) error {
+	return fmt.Errorf("timeline: "+format, args...)
+}
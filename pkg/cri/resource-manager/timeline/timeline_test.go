@@ -0,0 +1,146 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeline
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func entry(ns, pod, name, id, action, cpus string, at time.Time) *Entry {
+	return &Entry{
+		Time:        at,
+		Namespace:   ns,
+		Pod:         pod,
+		Container:   name,
+		ContainerID: id,
+		Action:      action,
+		CPUs:        cpus,
+	}
+}
+
+func TestRecordAndQuery(t *testing.T) {
+	tl, err := New(4, "")
+	if err != nil {
+		t.Fatalf("failed to create timeline: %v", err)
+	}
+
+	base := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+
+	tl.Record(entry("default", "pod1", "c1", "id1", ActionAllocate, "0-1", at(0)))
+	tl.Record(entry("default", "pod2", "c2", "id2", ActionAllocate, "2-3", at(1)))
+	if tl.Record(entry("default", "pod1", "c1", "id1", ActionUpdate, "0-1", at(2))) {
+		t.Errorf("update without changes should not be recorded")
+	}
+	tl.Record(entry("default", "pod1", "c1", "id1", ActionUpdate, "0", at(3)))
+	tl.Record(entry("default", "pod2", "c2", "id2", ActionRelease, "2-3", at(4)))
+	tl.Record(entry("kube-system", "pod1", "c1", "id3", ActionAllocate, "3", at(5)))
+
+	all := tl.Query(&Query{})
+	if len(all) != 4 {
+		t.Fatalf("expected 4 entries after wrapping, got %d", len(all))
+	}
+	if all[0].Time != at(1) || all[3].Time != at(5) {
+		t.Errorf("unexpected order of entries: %v ... %v", all[0].Time, all[3].Time)
+	}
+
+	tcases := []struct {
+		name     string
+		query    *Query
+		expected int
+	}{
+		{name: "by ID prefix", query: &Query{Container: "id"}, expected: 4},
+		{name: "by ID", query: &Query{Container: "id1"}, expected: 1},
+		{name: "by name", query: &Query{Container: "c1"}, expected: 2},
+		{name: "by pod/name", query: &Query{Container: "pod1/c1"}, expected: 2},
+		{name: "by namespace/pod/name", query: &Query{Container: "default/pod1/c1"}, expected: 1},
+		{name: "since", query: &Query{Since: at(4)}, expected: 2},
+		{name: "until", query: &Query{Until: at(3)}, expected: 2},
+		{name: "window", query: &Query{Since: at(3), Until: at(4)}, expected: 2},
+		{name: "limit", query: &Query{Limit: 3}, expected: 3},
+	}
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := len(tl.Query(tc.query)); got != tc.expected {
+				t.Errorf("expected %d entries, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "timeline-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "timeline.json")
+
+	tl, err := New(3, path)
+	if err != nil {
+		t.Fatalf("failed to create timeline: %v", err)
+	}
+	for i, cpus := range []string{"0", "1", "2", "3", "4"} {
+		tl.Record(entry("default", "pod", "c", "id", ActionUpdate, cpus,
+			time.Unix(int64(i), 0)))
+	}
+	tl.Close()
+
+	tl, err = New(3, path)
+	if err != nil {
+		t.Fatalf("failed to reload timeline: %v", err)
+	}
+	defer tl.Close()
+
+	entries := tl.Query(&Query{})
+	if len(entries) != 3 || entries[0].CPUs != "2" || entries[2].CPUs != "4" {
+		t.Errorf("unexpected entries after reload: %+v", entries)
+	}
+	if tl.Record(entry("default", "pod", "c", "id", ActionUpdate, "4", time.Unix(5, 0))) {
+		t.Errorf("update without changes should not be recorded after reload")
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	q, err := parseQuery(url.Values{
+		"container": {"pod/c"},
+		"since":     {"1h"},
+		"until":     {"2020-06-01T11:30:00Z"},
+		"limit":     {"10"},
+	}, now)
+	if err != nil {
+		t.Fatalf("failed to parse query: %v", err)
+	}
+	if q.Container != "pod/c" || q.Limit != 10 ||
+		!q.Since.Equal(now.Add(-time.Hour)) || !q.Until.Equal(now.Add(-30*time.Minute)) {
+		t.Errorf("unexpected query %+v", q)
+	}
+
+	for _, values := range []url.Values{
+		{"since": {"yesterday"}},
+		{"limit": {"-1"}},
+	} {
+		if _, err := parseQuery(values, now); err == nil {
+			t.Errorf("parsing %v should have failed", values)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/timeline"
+	xhttp "github.com/intel/cri-resource-manager/pkg/instrumentation/http"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+)
+
+func TestAllocationReason(t *testing.T) {
+	tcases := map[string]string{
+		allocationReason("allocated", "CreateContainer"): " policy on container creation",
+		allocationReason("released", "StopContainer"):    " policy on container stop",
+		allocationReason("reallocated", "setConfig"):     " policy on configuration change",
+		allocationReason("reallocated", "unknown"):       " policy on unknown",
+	}
+	for reason, suffix := range tcases {
+		if !strings.HasSuffix(reason, suffix) {
+			t.Errorf("expected reason %q to end with %q", reason, suffix)
+		}
+	}
+	if reason := allocationReason("allocated", "CreateContainer"); !strings.HasPrefix(reason, "allocated by ") {
+		t.Errorf("expected reason %q to start with the decision", reason)
+	}
+}
+
+func TestSetupTimelineFallback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resource-manager-timeline-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	saved := opt
+	defer func() { opt = saved }()
+	opt.TimelineSize = 16
+	opt.TimelineFile = dir // a directory cannot be used as a timeline file
+	if _, err := timeline.New(opt.TimelineSize, opt.TimelineFile); err == nil {
+		t.Fatalf("expected an error for timeline file %s", opt.TimelineFile)
+	}
+
+	m := &resmgr{Logger: logger.NewLogger("resource-manager-test")}
+	if err := m.setupTimeline(xhttp.NewServeMux()); err != nil {
+		t.Fatalf("expected timeline without persistence, got error %v", err)
+	}
+	if m.timeline == nil {
+		t.Fatalf("expected an in-memory timeline")
+	}
+	m.timeline.Close()
+}
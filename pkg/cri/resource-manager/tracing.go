@@ -0,0 +1,90 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"context"
+
+	"go.opencensus.io/trace"
+
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/cache"
+)
+
+// allocateResources allocates resources for a container in a child span.
+func (m *resmgr) allocateResources(ctx context.Context, c cache.Container) error {
+	_, span := trace.StartSpan(ctx, "policy.AllocateResources")
+	defer span.End()
+
+	span.AddAttributes(trace.StringAttribute("container", c.PrettyName()))
+
+	err := m.policy.AllocateResources(c)
+	if err != nil {
+		spanError(span, err)
+		return err
+	}
+
+	if pool, ok := m.policy.GetContainerPool(c); ok {
+		span.AddAttributes(trace.StringAttribute("pool", pool))
+	}
+
+	return nil
+}
+
+// insertContainer inserts a container into the cache in a child span.
+func (m *resmgr) insertContainer(ctx context.Context, request interface{}) (cache.Container, error) {
+	_, span := trace.StartSpan(ctx, "cache.InsertContainer")
+	defer span.End()
+
+	c, err := m.cache.InsertContainer(request)
+	if err != nil {
+		spanError(span, err)
+		return nil, err
+	}
+
+	span.AddAttributes(trace.StringAttribute("container", c.PrettyName()))
+
+	return c, nil
+}
+
+// updateContainerID updates the ID of a newly created container in a child span.
+func (m *resmgr) updateContainerID(ctx context.Context, c cache.Container, reply interface{}) {
+	_, span := trace.StartSpan(ctx, "cache.UpdateContainerID")
+	defer span.End()
+
+	span.AddAttributes(trace.StringAttribute("container", c.PrettyName()))
+
+	if _, err := m.cache.UpdateContainerID(c.GetCacheID(), reply); err != nil {
+		spanError(span, err)
+	}
+}
+
+// saveCache saves the cache in a child span.
+func (m *resmgr) saveCache(ctx context.Context) error {
+	_, span := trace.StartSpan(ctx, "cache.Save")
+	defer span.End()
+
+	err := m.cache.Save()
+	if err != nil {
+		spanError(span, err)
+	}
+
+	return err
+}
+
+// spanError marks a span failed with the given error.
+func spanError(span *trace.Span, err error) {
+	span.AddAttributes(trace.StringAttribute("error", err.Error()))
+	span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+}
@@ -19,8 +19,10 @@ package visualizer
 import (
 	// Pull in builtin visualizer implementations.
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/visualizer/bubbles"
+	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/visualizer/timeline"
 )
 
 func init() {
 	visualizers.register("bubbles", bubbles.Assets)
+	visualizers.register("timeline", timeline.Assets)
 }
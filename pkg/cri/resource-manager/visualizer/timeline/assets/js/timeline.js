@@ -0,0 +1,174 @@
+// CRI-RM allocation timeline visualization.
+//
+// Fetches allocation events from /timeline and draws one lane per container,
+// with a segment for each allocation lasting until the next event of the
+// container, colored by pool and labeled with the allocated CPUs.
+
+var svgNS = "http://www.w3.org/2000/svg"
+var laneHeight = 24
+var labelWidth = 260
+var plotWidth = 900
+var axisHeight = 24
+var palette = ["#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd",
+               "#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf"]
+
+document.getElementById("query").addEventListener("submit", function(event) {
+    event.preventDefault()
+    loadTimeline()
+})
+
+loadTimeline()
+
+function loadTimeline() {
+    var form = document.getElementById("query")
+    var params = []
+    for (var i = 0; i < form.elements.length; i++) {
+        var e = form.elements[i]
+        if (e.name && e.value) {
+            params.push(encodeURIComponent(e.name) + "=" + encodeURIComponent(e.value))
+        }
+    }
+
+    var req = new XMLHttpRequest()
+    req.open("GET", "/timeline?" + params.join("&"))
+    req.onload = function() {
+        if (req.status != 200) {
+            showMessage("failed to query timeline: " + req.responseText)
+            return
+        }
+        drawTimeline(JSON.parse(req.responseText) || [])
+    }
+    req.onerror = function() {
+        showMessage("failed to query timeline")
+    }
+    req.send()
+}
+
+function showMessage(msg) {
+    document.getElementById("timeline").textContent = msg
+    document.getElementById("details").textContent = ""
+}
+
+function containerKey(e) {
+    return e.Namespace + "/" + e.Pod + "/" + e.Container
+}
+
+// buildLanes groups events by container into segments of constant allocation.
+function buildLanes(events, now) {
+    var lanes = {}
+    var order = []
+    events.forEach(function(e) {
+        var key = containerKey(e)
+        if (!(key in lanes)) {
+            lanes[key] = []
+            order.push(key)
+        }
+        var lane = lanes[key]
+        var t = Date.parse(e.Time)
+        if (lane.length > 0 && lane[lane.length - 1].end === null) {
+            lane[lane.length - 1].end = t
+        }
+        if (e.Action != "release") {
+            lane.push({start: t, end: null, event: e})
+        }
+    })
+    order.forEach(function(key) {
+        lanes[key].forEach(function(s) {
+            if (s.end === null) {
+                s.end = now
+            }
+        })
+    })
+    return {lanes: lanes, order: order}
+}
+
+function svgElement(name, attrs) {
+    var e = document.createElementNS(svgNS, name)
+    for (var a in attrs) {
+        e.setAttribute(a, attrs[a])
+    }
+    return e
+}
+
+function drawTimeline(events) {
+    var div = document.getElementById("timeline")
+    div.textContent = ""
+    document.getElementById("details").textContent = ""
+
+    if (events.length == 0) {
+        showMessage("No allocation events found.")
+        return
+    }
+
+    var now = Date.now()
+    var data = buildLanes(events, now)
+    var t0 = Date.parse(events[0].Time)
+    var t1 = now
+    var span = Math.max(t1 - t0, 1000)
+    var x = function(t) { return labelWidth + (t - t0) * plotWidth / span }
+
+    var height = axisHeight + data.order.length * laneHeight
+    var svg = svgElement("svg", {width: labelWidth + plotWidth + 20, height: height})
+
+    var pools = {}
+    var poolColor = function(pool) {
+        if (!(pool in pools)) {
+            pools[pool] = palette[Object.keys(pools).length % palette.length]
+        }
+        return pools[pool]
+    }
+
+    for (var i = 0; i <= 4; i++) {
+        var t = t0 + i * span / 4
+        var line = svgElement("line", {class: "axis-line", x1: x(t), x2: x(t), y1: axisHeight - 4, y2: height})
+        var label = svgElement("text", {class: "axis", x: x(t), y: axisHeight - 8, "text-anchor": "middle"})
+        label.textContent = new Date(t).toLocaleTimeString()
+        svg.appendChild(line)
+        svg.appendChild(label)
+    }
+
+    data.order.forEach(function(key, idx) {
+        var y = axisHeight + idx * laneHeight
+        var label = svgElement("text", {class: "lane-label", x: 0, y: y + laneHeight * 0.7})
+        label.textContent = key
+        svg.appendChild(label)
+
+        data.lanes[key].forEach(function(s) {
+            var e = s.event
+            var w = Math.max(x(s.end) - x(s.start), 2)
+            var rect = svgElement("rect", {
+                class: "segment",
+                x: x(s.start), y: y + 2, width: w, height: laneHeight - 4,
+                fill: poolColor(e.Pool || "-"),
+            })
+            var title = svgElement("title", {})
+            title.textContent = describe(e)
+            rect.appendChild(title)
+            rect.addEventListener("click", function() {
+                document.getElementById("details").textContent = describe(e)
+            })
+            svg.appendChild(rect)
+
+            if (w > 40) {
+                var text = svgElement("text", {class: "segment-label", x: x(s.start) + 4, y: y + laneHeight * 0.7})
+                text.textContent = e.CPUs || "-"
+                svg.appendChild(text)
+            }
+        })
+    })
+
+    div.appendChild(svg)
+}
+
+function describe(e) {
+    return [
+        "container: " + containerKey(e) + " (" + e.ContainerID + ")",
+        "time:      " + e.Time,
+        "action:    " + e.Action + " (" + e.Reason + ")",
+        "pool:      " + (e.Pool || "-"),
+        "CPUs:      " + (e.CPUs || "-"),
+        "memory:    " + (e.Memory || "-"),
+        "RDT class: " + (e.RDTClass || "-"),
+        "block I/O: " + (e.BlockIOClass || "-"),
+    ].join("\n")
+}
@@ -0,0 +1,40 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build ignore
+
+package main
+
+import (
+	"fmt"
+	visualizer "github.com/intel/cri-resource-manager/pkg/cri/resource-manager/visualizer/timeline"
+	"github.com/shurcooL/vfsgen"
+	"log"
+)
+
+const (
+	name = "timeline"
+)
+
+func main() {
+	opts := vfsgen.Options{
+		PackageName:  name,
+		BuildTags:    "!test",
+		VariableName: "Assets",
+		Filename:     "assets_gendata.go",
+	}
+	if err := vfsgen.Generate(visualizer.Assets, opts); err != nil {
+		log.Fatalln(fmt.Sprintf("failed to generate assets for %s UI:", name, err))
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+)
+
+// configWatch watches configuration files and fragment directories for changes.
+type configWatch struct {
+	logger.Logger
+	sync.Mutex
+	watcher *fsnotify.Watcher // inotify watcher
+	file    string            // configuration file, if any
+	dir     string            // configuration fragment directory, if any
+	delay   time.Duration     // delay for debouncing bursts of changes
+	timer   *time.Timer       // pending reload, if any
+	reload  func()            // function to reload configuration with
+	stop    chan struct{}     // channel to stop watching
+}
+
+// configFiles returns the configuration file and fragments from dir, in lexical order.
+func configFiles(file, dir string) ([]string, error) {
+	files := []string{}
+	if file != "" {
+		files = append(files, file)
+	}
+	if dir == "" {
+		return files, nil
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, resmgrError("failed to read configuration directory %q: %v", dir, err)
+	}
+
+	fragments := []string{}
+	for _, e := range entries {
+		if !e.IsDir() && isConfigFragment(e.Name()) {
+			fragments = append(fragments, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(fragments)
+
+	return append(files, fragments...), nil
+}
+
+// isConfigFragment checks if the given file name looks like a configuration fragment.
+func isConfigFragment(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// newConfigWatch creates a watch for the given configuration file and fragment directory.
+func newConfigWatch(file, dir string, delay time.Duration, reload func()) (*configWatch, error) {
+	w := &configWatch{
+		Logger: logger.NewLogger("config-watch"),
+		delay:  delay,
+		reload: reload,
+		stop:   make(chan struct{}),
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, resmgrError("failed to create configuration watcher: %v", err)
+	}
+	w.watcher = watcher
+
+	// Files are watched through their parent directories. This way we also catch
+	// files being replaced by editors or atomically updated ConfigMap volumes.
+	dirs := map[string]struct{}{}
+	if file != "" {
+		w.file = filepath.Clean(file)
+		dirs[filepath.Dir(w.file)] = struct{}{}
+	}
+	if dir != "" {
+		w.dir = filepath.Clean(dir)
+		dirs[w.dir] = struct{}{}
+	}
+	for d := range dirs {
+		if err := watcher.Add(d); err != nil {
+			watcher.Close()
+			return nil, resmgrError("failed to watch configuration directory %q: %v", d, err)
+		}
+		w.Info("watching %s for configuration changes", d)
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+// Stop stops watching for changes.
+func (w *configWatch) Stop() {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.stop == nil {
+		return
+	}
+
+	close(w.stop)
+	w.stop = nil
+	w.watcher.Close()
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+}
+
+// run processes inotify events until the watch is stopped.
+func (w *configWatch) run() {
+	w.Lock()
+	stop := w.stop
+	w.Unlock()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case e, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if w.isRelevant(e) {
+				w.Debug("configuration change detected: %s", e)
+				w.schedule()
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.Error("configuration watcher error: %v", err)
+		}
+	}
+}
+
+// isRelevant checks if an inotify event can affect the configuration.
+func (w *configWatch) isRelevant(e fsnotify.Event) bool {
+	if e.Op == fsnotify.Chmod {
+		return false
+	}
+
+	name := filepath.Clean(e.Name)
+	dir, base := filepath.Split(name)
+	dir = filepath.Clean(dir)
+
+	// Kubernetes updates ConfigMap volumes by swapping a '..data' symlink.
+	if strings.HasPrefix(base, "..") {
+		return true
+	}
+	if name == w.file {
+		return true
+	}
+	if dir == w.dir && isConfigFragment(base) {
+		return true
+	}
+
+	return false
+}
+
+// schedule schedules a reload, postponing any pending one.
+func (w *configWatch) schedule() {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.stop == nil {
+		return
+	}
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.delay, w.fire)
+}
+
+// fire reloads configuration once a burst of changes has settled.
+func (w *configWatch) fire() {
+	w.Lock()
+	stopped := w.stop == nil
+	w.timer = nil
+	w.Unlock()
+
+	if !stopped {
+		w.reload()
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resmgr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const (
+	// testWatchDelay is the debounce delay used for tests.
+	testWatchDelay = 100 * time.Millisecond
+	// testWatchTimeout is how long we wait for a reload to happen.
+	testWatchTimeout = 2 * time.Second
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestConfigFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config-watch-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"20-policy.yaml", "10-logger.yml", ".hidden.yaml", "README"} {
+		writeTestFile(t, filepath.Join(dir, name), "")
+	}
+	if err := os.Mkdir(filepath.Join(dir, "30-subdir.yaml"), 0755); err != nil {
+		t.Fatalf("failed to create subdirectory: %v", err)
+	}
+
+	files, err := configFiles("/etc/cri-resmgr/forced.cfg", dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"/etc/cri-resmgr/forced.cfg",
+		filepath.Join(dir, "10-logger.yml"),
+		filepath.Join(dir, "20-policy.yaml"),
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected files %v, got %v", expected, files)
+	}
+
+	if _, err := configFiles("", filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("expected an error for a missing directory")
+	}
+}
+
+func TestConfigWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config-watch-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "forced.cfg")
+	fragments := filepath.Join(dir, "conf.d")
+	if err := os.Mkdir(fragments, 0755); err != nil {
+		t.Fatalf("failed to create fragment directory: %v", err)
+	}
+	writeTestFile(t, file, "")
+
+	reloads := make(chan struct{}, 16)
+	w, err := newConfigWatch(file, fragments, testWatchDelay, func() { reloads <- struct{}{} })
+	if err != nil {
+		t.Fatalf("failed to create configuration watch: %v", err)
+	}
+	defer w.Stop()
+
+	expectReload := func(what string) {
+		select {
+		case <-reloads:
+		case <-time.After(testWatchTimeout):
+			t.Fatalf("%s: no configuration reload", what)
+		}
+	}
+	expectNoReload := func(what string) {
+		select {
+		case <-reloads:
+			t.Fatalf("%s: unexpected configuration reload", what)
+		case <-time.After(4 * testWatchDelay):
+		}
+	}
+
+	writeTestFile(t, file, "logger:\n  Debug: all\n")
+	expectReload("changing forced configuration file")
+
+	writeTestFile(t, filepath.Join(fragments, "10-policy.yaml"), "policy:\n  Active: none\n")
+	writeTestFile(t, filepath.Join(fragments, "20-logger.yaml"), "logger:\n  Debug: cache\n")
+	expectReload("adding configuration fragments")
+	expectNoReload("burst of changes after reload")
+
+	writeTestFile(t, filepath.Join(dir, "unrelated.cfg"), "")
+	writeTestFile(t, filepath.Join(fragments, "notes.txt"), "")
+	writeTestFile(t, filepath.Join(fragments, ".hidden.yaml"), "")
+	expectNoReload("changing unrelated files")
+
+	if err := os.Remove(filepath.Join(fragments, "10-policy.yaml")); err != nil {
+		t.Fatalf("failed to remove configuration fragment: %v", err)
+	}
+	expectReload("removing a configuration fragment")
+
+	w.Stop()
+	writeTestFile(t, file, "logger:\n  Debug: policy\n")
+	expectNoReload("changing configuration after stopping")
+}
@@ -0,0 +1,132 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	grpcstatus "google.golang.org/grpc/status"
+
+	logger "github.com/intel/cri-resource-manager/pkg/log"
+	"github.com/intel/cri-resource-manager/pkg/metrics"
+)
+
+// latencyBuckets are the histogram buckets we use for CRI request latencies.
+var latencyBuckets = []float64{
+	.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30,
+}
+
+// requestMetrics collects CRI request processing metrics.
+type requestMetrics struct {
+	overhead *prometheus.HistogramVec // relay overhead per method
+	runtime  *prometheus.HistogramVec // CRI runtime latency per method
+	total    *prometheus.HistogramVec // total request latency per method
+	errors   *prometheus.CounterVec   // failed requests per method and gRPC code
+	bypassed *prometheus.CounterVec   // bypassed requests per method
+}
+
+// Our request metrics collector.
+var stats = newRequestMetrics()
+
+// newRequestMetrics creates the CRI request metrics collector.
+func newRequestMetrics() *requestMetrics {
+	return &requestMetrics{
+		overhead: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "cri_request_overhead_seconds",
+				Help:    "Latency added by the relay to CRI requests, per method.",
+				Buckets: latencyBuckets,
+			},
+			[]string{"method", "kind"},
+		),
+		runtime: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "cri_request_runtime_seconds",
+				Help:    "Latency of CRI requests in the CRI runtime, per method.",
+				Buckets: latencyBuckets,
+			},
+			[]string{"method", "kind"},
+		),
+		total: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "cri_request_duration_seconds",
+				Help:    "Total latency of CRI requests, per method.",
+				Buckets: latencyBuckets,
+			},
+			[]string{"method", "kind"},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cri_request_errors_total",
+				Help: "Number of failed CRI requests, per method and gRPC status code.",
+			},
+			[]string{"method", "code"},
+		),
+		bypassed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cri_requests_bypassed_total",
+				Help: "Number of CRI requests passed through due to bypassed processing.",
+			},
+			[]string{"method"},
+		),
+	}
+}
+
+// latency records the latencies of a processed request.
+func (m *requestMetrics) latency(kind, name string, pre, server, post time.Duration) {
+	m.overhead.WithLabelValues(name, kind).Observe((pre + post).Seconds())
+	m.runtime.WithLabelValues(name, kind).Observe(server.Seconds())
+	m.total.WithLabelValues(name, kind).Observe((pre + server + post).Seconds())
+}
+
+// error records a failed request.
+func (m *requestMetrics) error(name string, err error) {
+	m.errors.WithLabelValues(name, grpcstatus.Code(err).String()).Inc()
+}
+
+// bypass records a bypassed request.
+func (m *requestMetrics) bypass(name string) {
+	m.bypassed.WithLabelValues(name).Inc()
+}
+
+// Describe implements the prometheus.Collector interface.
+func (m *requestMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.overhead.Describe(ch)
+	m.runtime.Describe(ch)
+	m.total.Describe(ch)
+	m.errors.Describe(ch)
+	m.bypassed.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (m *requestMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.overhead.Collect(ch)
+	m.runtime.Collect(ch)
+	m.total.Collect(ch)
+	m.errors.Collect(ch)
+	m.bypassed.Collect(ch)
+}
+
+// Register our request metrics collector.
+func init() {
+	err := metrics.RegisterCollector("cri",
+		func() (prometheus.Collector, error) {
+			return stats, nil
+		})
+	if err != nil {
+		logger.Get("cri/server").Error("failed to register CRI request metrics collector: %v", err)
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 	api "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 
+	"github.com/intel/cri-resource-manager/pkg/auth"
 	"github.com/intel/cri-resource-manager/pkg/cri/resource-manager/sockets"
 	"github.com/intel/cri-resource-manager/pkg/dump"
 	logger "github.com/intel/cri-resource-manager/pkg/log"
@@ -227,7 +228,14 @@ func (s *server) createGrpcServer() error {
 		}
 	}
 
-	s.server = grpc.NewServer(instrumentation.InjectGrpcServerTrace()...)
+	opts, err := auth.ServerOptions(auth.RelaySocket)
+	if err != nil {
+		l.Close()
+		s.listener = nil
+		return serverError("failed to set up socket access control: %v", err)
+	}
+
+	s.server = grpc.NewServer(instrumentation.InjectGrpcServerTrace(opts...)...)
 
 	return nil
 }
@@ -272,22 +280,19 @@ func (s *server) Chown(uid, gid int) error {
 }
 
 // getInterceptor finds an interceptor for the given method.
-func (s *server) getInterceptor(method string) (Interceptor, string) {
+func (s *server) getInterceptor(method string) (Interceptor, string, bool) {
 	name := method[strings.LastIndex(method, "/")+1:]
 
-	if s.chkBypassFn != nil && s.chkBypassFn() {
-		return nil, name
+	fn, ok := s.interceptors[name]
+	if !ok {
+		fn = s.interceptors["*"]
 	}
 
-	if fn, ok := s.interceptors[name]; ok {
-		return fn, name
+	if fn != nil && s.chkBypassFn != nil && s.chkBypassFn() {
+		return nil, name, true
 	}
 
-	if fn, ok := s.interceptors["*"]; ok {
-		return fn, name
-	}
-
-	return nil, name
+	return fn, name, false
 }
 
 // intercept processes requests with a registered interceptor or the default handler.
@@ -314,7 +319,10 @@ func (s *server) intercept(ctx context.Context, req interface{},
 		return rpl, err
 	}
 
-	fn, name := s.getInterceptor(info.FullMethod)
+	fn, name, bypassed := s.getInterceptor(info.FullMethod)
+	if bypassed {
+		stats.bypass(name)
+	}
 	if fn != nil {
 		kind = "intercepted"
 		sync = true
@@ -333,6 +341,8 @@ func (s *server) intercept(ctx context.Context, req interface{},
 		span.AddAttributes(trace.StringAttribute("kind", kind))
 	}
 
+	recorded := dump.RecordRequest(req)
+
 	start = time.Now()
 	rpl, err := fn(ctx, name, req, wrapHandler)
 	end = time.Now()
@@ -340,8 +350,11 @@ func (s *server) intercept(ctx context.Context, req interface{},
 
 	if err != nil {
 		dump.ReplyMessage(kind, info.FullMethod, qualif, err, elapsed, false)
+		dump.RecordMessage(kind, info.FullMethod, qualif, recorded, err, elapsed)
+		stats.error(name, err)
 	} else {
 		dump.ReplyMessage(kind, info.FullMethod, qualif, rpl, elapsed, false)
+		dump.RecordMessage(kind, info.FullMethod, qualif, recorded, rpl, elapsed)
 	}
 
 	s.collectStatistics(kind, name, start, send, recv, end)
@@ -350,9 +363,9 @@ func (s *server) intercept(ctx context.Context, req interface{},
 	return rpl, err
 }
 
-// collectStatistics collects (should collect) request processing statistics.
+// collectStatistics collects request processing statistics.
 func (s *server) collectStatistics(kind, name string, start, send, recv, end time.Time) {
-	if kind == "passthrough" {
+	if send.IsZero() || recv.IsZero() {
 		return
 	}
 
@@ -360,6 +373,12 @@ func (s *server) collectStatistics(kind, name string, start, send, recv, end tim
 	server := recv.Sub(send)
 	post := end.Sub(recv)
 
+	stats.latency(kind, name, pre, server, post)
+
+	if kind == "passthrough" {
+		return
+	}
+
 	s.Debug(" * latency for %s: preprocess: %v, CRI server: %v, postprocess: %v, total: %v",
 		name, pre, server, post, pre+server+post)
 }
@@ -62,11 +62,45 @@ explicitly enabled, otherwise messages are suppressed.
 If a dump file is specified messages will be dumped additionally
 to the dump file as well.
 
+Full dumps can contain sensitive data. Redaction rules can be used
+to replace such data with '<redacted>' before messages are dumped
+or recorded:
+
+  RedactEnv: list of regexps, values of matching environment variables
+  RedactAnnotations: list of regexps, values of matching annotations
+  RedactMounts: if true, redact the host paths of all mounts
+
+The patterns must match the full environment variable or annotation
+key.
+
+The dump file can be rotated once it grows too large or too old. A
+bounded number of rotated files, with suffixes .1, .2, etc. are kept:
+
+  MaxFileSize: size limit for rotating the dump file, e.g. 10Mi
+  MaxFileAge: age limit for rotating the dump file, e.g. 24h
+  MaxBackups: number of rotated dump files to keep
+
+If a recording file is specified, all method calls are also recorded
+to the given file, regardless of the dump configuration. Each call
+is recorded as a single line of JSON with the method name, request,
+reply or error, and latency. Recordings can be replayed offline using
+cri-resmgr-replay.
+
 Here is a sample configuration fragment to suppress all .*List.*
 calls, produce short dumps of all .*Stop.* calls, and full dumps
-of everything else, dumps also going to the file '/tmp/cri-dump.log'
+of everything else, dumps also going to the file '/tmp/cri-dump.log',
+and all calls getting recorded to the file '/tmp/cri-record.json'.
+Values of environment variables with names containing PASSWORD or
+TOKEN are redacted and the dump file is rotated once it reaches 10
+MiB, keeping 3 rotated files.
 
   dump:
     config: full:.*,short:.*Stop.*,off:.*List.*
     file: /tmp/cri-dump.log
+    record: /tmp/cri-record.json
+    RedactEnv:
+      - .*PASSWORD.*
+      - .*TOKEN.*
+    MaxFileSize: 10Mi
+    MaxBackups: 3
 `
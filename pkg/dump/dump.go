@@ -55,7 +55,14 @@ type dumper struct {
 	debug        bool             // dump as debug messages
 	path         string           // extra dump file path
 	file         *os.File         // extra dump file
+	size         int64            // current size of dump file
+	opened       time.Time        // time dump file was opened
+	rotation     rotation         // dump file rotation parameters
+	redactor     *redactor        // redaction of sensitive data
 	methods      []string         // training set for config
+	recpath      string           // recording file path
+	recfile      *os.File         // recording file
+	recording    bool             // whether we are recording
 	q            chan *dumpreq
 }
 
@@ -76,6 +83,7 @@ type direction int
 const (
 	request = iota
 	reply
+	record
 	nop
 )
 
@@ -154,20 +162,12 @@ func newDumper() *dumper {
 func (d *dumper) run() {
 	go func() {
 		for req := range d.q {
+			if req.dir == record {
+				d.record(req)
+				continue
+			}
 			if req.dir != nop {
-				method := methodName(req.method)
-				d.RLock()
-				detail, ok := d.details[method]
-				if !ok {
-					detail = d.rules.detailOf(method)
-				}
-				d.RUnlock()
-				switch detail {
-				case Name:
-					d.name(req.dir, req.kind, method, req.qualifier, req.msg, req.latency)
-				case Full:
-					d.full(req.dir, req.kind, method, req.qualifier, req.msg, req.latency)
-				}
+				d.dump(req)
 			}
 			if req.sync != nil {
 				close(req.sync)
@@ -183,6 +183,26 @@ func (d *dumper) sync() {
 	_ = <-ch
 }
 
+// dump dumps a request or a reply according to the dumping rules. The dumper
+// is locked while dumping, since reconfiguration may swap the dump file and the
+// redactor, and the file is rotated in the middle of dumping.
+func (d *dumper) dump(req *dumpreq) {
+	d.Lock()
+	defer d.Unlock()
+
+	method := methodName(req.method)
+	detail, ok := d.details[method]
+	if !ok {
+		detail = d.rules.detailOf(method)
+	}
+	switch detail {
+	case Name:
+		d.name(req.dir, req.kind, method, req.qualifier, req.msg, req.latency)
+	case Full:
+		d.full(req.dir, req.kind, method, req.qualifier, req.msg, req.latency)
+	}
+}
+
 // configure (re)configures dumper
 func (d *dumper) configure(o *options) {
 	d.Lock()
@@ -190,6 +210,13 @@ func (d *dumper) configure(o *options) {
 
 	d.debug = o.Debug
 	d.rules = o.rules.duplicate()
+	d.redactor = o.redactor
+	d.rotation = rotation{
+		maxSize:    o.MaxFileSize.Value(),
+		maxAge:     time.Duration(o.MaxFileAge),
+		maxBackups: o.MaxBackups,
+	}
+	d.openRecording(o.Record)
 
 	if d.path != o.File || d.disabled != o.Disabled {
 		if d.file != nil {
@@ -205,11 +232,9 @@ func (d *dumper) configure(o *options) {
 
 		d.path = o.File
 		if d.path != "" {
-			var err error
 			log.Info("opening new message dump file %q...", d.path)
-			d.file, err = os.Create(d.path)
-			if err != nil {
-				log.Error("failed to open file %q: %v", d.path, err)
+			if err := d.openFile(); err != nil {
+				log.Error("%v", err)
 			}
 		}
 	}
@@ -265,6 +290,8 @@ func (d *dumper) full(dir direction, kind, method, qualifier string, msg interfa
 		hdr = method + " " + dir.arrow() + " "
 	}
 
+	msg = d.redactor.redact(msg)
+
 	switch dir {
 	case request:
 		raw, _ := yaml.Marshal(msg)
@@ -329,9 +356,16 @@ func (d *dumper) warn(dir direction, latency time.Duration, format string, args
 	}
 }
 
-// tofile dumps a single line to a file.
+// tofile dumps a single line to a file. It must be called with the dumper locked.
 func (d *dumper) tofile(dir direction, latency time.Duration, format string, args ...interface{}) {
-	fmt.Fprintf(d.file, "["+stamp(dir, latency)+"] "+format+"\n", args...)
+	if d.needRotate() {
+		d.rotate()
+		if d.file == nil {
+			return
+		}
+	}
+	n, _ := fmt.Fprintf(d.file, "["+stamp(dir, latency)+"] "+format+"\n", args...)
+	d.size += int64(n)
 }
 
 // stamp produces a stamp from a direction and a latency.
@@ -352,6 +386,8 @@ func (d direction) String() string {
 		return "request"
 	case reply:
 		return "reply"
+	case record:
+		return "record"
 	}
 	return "unknown"
 }
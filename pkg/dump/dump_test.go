@@ -15,14 +15,24 @@
 package dump
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/resource"
+	cri "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
 	"github.com/intel/cri-resource-manager/pkg/config"
+	logger "github.com/intel/cri-resource-manager/pkg/log"
 )
 
 // TestConfigParsing test parsing of dump configuration strings.
@@ -222,6 +232,217 @@ func (ft *filterTest) run(t *testing.T) {
 	}
 }
 
+// TestRecording tests recording of method calls.
+func TestRecording(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump-test-")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "record.json")
+	opt.Record = path
+	opt.configNotify(config.UpdateEvent, config.ConfigFile)
+
+	req := mkmsg(&Type1Message1{}).(*Type1Message1)
+	original := &Type1Message1{Body: append([]string{}, req.Body...)}
+	recorded := RecordRequest(req)
+	// changes done while processing the request must not end up in the recording
+	req.Body[3] = "#processed"
+	RecordMessage(marker, "/test/Type1Message1", "q1", recorded, Reply, time.Millisecond)
+	RecordMessage(marker, "/test/Type1Message2", "", recorded,
+		status.Error(codes.NotFound, "not found"), time.Second)
+	dump.sync()
+
+	opt.Record = ""
+	opt.configNotify(config.UpdateEvent, config.ConfigFile)
+
+	records, err := ReadRecordFile(path)
+	if err != nil {
+		t.Fatalf("failed to read recording: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	chk := &Type1Message1{}
+	if err := json.Unmarshal(records[0].Request, chk); err != nil {
+		t.Errorf("failed to unmarshal recorded request: %v", err)
+	}
+	if !reflect.DeepEqual(chk, original) {
+		t.Errorf("expected recorded request %v, got %v", original, chk)
+	}
+	rpl := []string{}
+	if err := json.Unmarshal(records[0].Reply, &rpl); err != nil {
+		t.Errorf("failed to unmarshal recorded reply: %v", err)
+	}
+	if !reflect.DeepEqual(rpl, Reply) {
+		t.Errorf("expected recorded reply %v, got %v", Reply, rpl)
+	}
+	if records[0].Method != "/test/Type1Message1" || records[0].Qualifier != "q1" ||
+		records[0].Latency != time.Millisecond || records[0].Error != nil {
+		t.Errorf("unexpected record %+v", *records[0])
+	}
+
+	if records[1].Error == nil || records[1].Error.Code != uint32(codes.NotFound) ||
+		records[1].Error.Message != "not found" || len(records[1].Reply) != 0 {
+		t.Errorf("unexpected error record %+v", *records[1])
+	}
+}
+
+// TestRedaction tests redaction of sensitive data from messages.
+func TestRedaction(t *testing.T) {
+	msg := &cri.CreateContainerRequest{
+		Config: &cri.ContainerConfig{
+			Envs: []*cri.KeyValue{
+				{Key: "PATH", Value: "/bin"},
+				{Key: "DB_PASSWORD", Value: "secret"},
+			},
+			Mounts: []*cri.Mount{
+				{ContainerPath: "/data", HostPath: "/srv/data"},
+			},
+			Annotations: map[string]string{
+				"a.b.c/token": "secret",
+				"a.b.c/name":  "name",
+			},
+		},
+	}
+
+	r, err := newRedactor([]string{".*PASSWORD.*"}, []string{".*/token"}, true)
+	if err != nil {
+		t.Fatalf("failed to create redactor: %v", err)
+	}
+
+	raw, err := json.Marshal(r.redact(msg))
+	if err != nil {
+		t.Fatalf("failed to marshal redacted message: %v", err)
+	}
+	chk := &cri.CreateContainerRequest{}
+	if err := json.Unmarshal(raw, chk); err != nil {
+		t.Fatalf("failed to unmarshal redacted message: %v", err)
+	}
+
+	if v := chk.Config.Envs[0].Value; v != "/bin" {
+		t.Errorf("env PATH: expected %q, got %q", "/bin", v)
+	}
+	if v := chk.Config.Envs[1].Value; v != Redacted {
+		t.Errorf("env DB_PASSWORD: expected %q, got %q", Redacted, v)
+	}
+	if v := chk.Config.Mounts[0].HostPath; v != Redacted {
+		t.Errorf("mount host path: expected %q, got %q", Redacted, v)
+	}
+	if v := chk.Config.Mounts[0].ContainerPath; v != "/data" {
+		t.Errorf("mount container path: expected %q, got %q", "/data", v)
+	}
+	if v := chk.Config.Annotations["a.b.c/token"]; v != Redacted {
+		t.Errorf("annotation a.b.c/token: expected %q, got %q", Redacted, v)
+	}
+	if v := chk.Config.Annotations["a.b.c/name"]; v != "name" {
+		t.Errorf("annotation a.b.c/name: expected %q, got %q", "name", v)
+	}
+	if v := msg.Config.Envs[1].Value; v != "secret" {
+		t.Errorf("original message modified by redaction")
+	}
+
+	if _, err := newRedactor([]string{"(invalid"}, nil, false); err == nil {
+		t.Errorf("expected invalid redaction rule to fail")
+	}
+}
+
+// TestRotation tests size-based rotation of the dump file.
+func TestRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump-test-")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := &dumper{
+		path: filepath.Join(dir, "dump.log"),
+		rotation: rotation{
+			maxSize:    64,
+			maxBackups: 2,
+		},
+	}
+	if err := d.openFile(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	for i := 0; i < 20; i++ {
+		d.tofile(request, 0, "message #%d", i)
+	}
+	d.file.Close()
+
+	for _, name := range []string{d.path, backupName(d.path, 1), backupName(d.path, 2)} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Errorf("expected file %q: %v", name, err)
+			continue
+		}
+		if info.Size() > 64+int64(stampLen)+16 {
+			t.Errorf("file %q too large (%d bytes)", name, info.Size())
+		}
+	}
+	if _, err := os.Stat(backupName(d.path, 3)); !os.IsNotExist(err) {
+		t.Errorf("unexpected backup %q", backupName(d.path, 3))
+	}
+}
+
+// TestReconfigureWhileDumping tests reconfiguring the dumper while it is dumping to a file.
+func TestReconfigureWhileDumping(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump-test-")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	options := func(file string, redact bool) *options {
+		o := &options{
+			Debug:       true,
+			File:        filepath.Join(dir, file),
+			Config:      "full:.*",
+			MaxFileSize: resource.MustParse("512"),
+			MaxBackups:  1,
+		}
+		if redact {
+			o.RedactEnv = []string{".*PASSWORD.*"}
+		}
+		var err error
+		if o.rules, o.redactor, err = o.parse(); err != nil {
+			t.Fatalf("failed to parse dump options: %v", err)
+		}
+		return o
+	}
+
+	d := newDumper()
+	d.configure(options("dump-0.log", false))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			d.configure(options(fmt.Sprintf("dump-%d.log", i%2), i%3 == 0))
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		d.q <- &dumpreq{dir: request, method: "/test/Type1Message1", msg: mkmsg(&Type1Message1{})}
+	}
+	wg.Wait()
+
+	ch := make(chan struct{})
+	d.q <- &dumpreq{dir: nop, sync: ch}
+	<-ch
+	close(d.q)
+
+	d.Lock()
+	defer d.Unlock()
+	if d.file == nil {
+		t.Errorf("expected an open dump file")
+	} else {
+		d.file.Close()
+	}
+}
+
 //
 // a few message types for testing
 //
@@ -420,3 +641,5 @@ func (*testlog) EnableDebug(bool) bool { return true }
 func (*testlog) DebugEnabled() bool    { return true }
 func (*testlog) Stop()                 {}
 func (*testlog) Source() string        { return "" }
+
+func (t *testlog) With(...interface{}) logger.Logger { return t }
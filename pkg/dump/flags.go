@@ -33,6 +33,8 @@ import (
 	re "regexp"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+
 	"github.com/intel/cri-resource-manager/pkg/config"
 )
 
@@ -43,11 +45,19 @@ const (
 
 // Dumping options configurable via the command line or pkg/config.
 type options struct {
-	Debug    bool    // log messages as debug messages
-	Disabled bool    // whether dumping is globally disabled
-	File     string  // file to also dump to, if set
-	Config   string  // dumping configuration
-	rules    ruleset // corresponding dumping rules
+	Debug             bool              // log messages as debug messages
+	Disabled          bool              // whether dumping is globally disabled
+	File              string            // file to also dump to, if set
+	Record            string            // file to record method calls to as JSON, if set
+	Config            string            // dumping configuration
+	RedactEnv         []string          // environment variable keys to redact
+	RedactAnnotations []string          // annotation keys to redact
+	RedactMounts      bool              // whether to redact mount host paths
+	MaxFileSize       resource.Quantity // rotate dump file once it grows this large
+	MaxFileAge        config.Duration   // rotate dump file once it gets this old
+	MaxBackups        int               // number of rotated dump files to keep
+	rules             ruleset           // corresponding dumping rules
+	redactor          *redactor         // corresponding redaction rules
 }
 
 // ruleset is an ordered set of dumping rules.
@@ -186,20 +196,48 @@ func defaultOptions() interface{} {
 	return o
 }
 
+// parse checks the options, parsing dump rules and redaction patterns.
+func (o *options) parse() (ruleset, *redactor, error) {
+	rules := ruleset{}
+	if err := rules.parse(o.Config); err != nil {
+		return nil, nil, err
+	}
+	redactor, err := newRedactor(o.RedactEnv, o.RedactAnnotations, o.RedactMounts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.MaxFileSize.Sign() < 0 || o.MaxFileAge < 0 || o.MaxBackups < 0 {
+		return nil, nil, dumpError("invalid negative dump file rotation parameters")
+	}
+	return rules, redactor, nil
+}
+
+// validateOptions checks candidate options without applying them.
+func validateOptions(cfg interface{}) error {
+	_, _, err := cfg.(*options).parse()
+	return err
+}
+
 // configNotify updates our runtime configuration.
 func (o *options) configNotify(event config.Event, source config.Source) error {
 	log.Info("message dumper configuration %v", event)
 	log.Info(" * config: %s", o.Config)
 
-	rules := ruleset{}
-	if err := rules.parse(o.Config); err != nil {
+	rules, redactor, err := o.parse()
+	if err != nil {
 		return err
 	}
 
 	o.rules = rules
+	o.redactor = redactor
 
 	log.Info(" * parsed: %s", o.rules.String())
 	log.Info(" * dump file: %v", opt.File)
+	log.Info(" * recording file: %v", opt.Record)
+	log.Info(" * redact env: %v, annotations: %v, mounts: %v",
+		opt.RedactEnv, opt.RedactAnnotations, opt.RedactMounts)
+	log.Info(" * rotate: size %s, age %s, backups %d",
+		opt.MaxFileSize.String(), opt.MaxFileAge.String(), opt.MaxBackups)
 	log.Info(" * log with debug: %v", opt.Debug)
 
 	dump.configure(o)
@@ -211,5 +249,5 @@ func (o *options) configNotify(event config.Event, source config.Source) error {
 func init() {
 	opt.rules.parse(opt.Config)
 	config.Register("dump", configHelp, opt, defaultOptions,
-		config.WithNotify(opt.configNotify))
+		config.WithNotify(opt.configNotify), config.WithValidate(validateOptions))
 }
@@ -0,0 +1,193 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dump
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// Record is a single recorded method call, a line in a recording.
+type Record struct {
+	// Time is the time the method call was received.
+	Time time.Time `json:"time"`
+	// Kind is the kind of the call (intercepted, passthrough, etc.).
+	Kind string `json:"kind"`
+	// Method is the full gRPC method name of the call.
+	Method string `json:"method"`
+	// Qualifier is the extra context used to disambiguate the call.
+	Qualifier string `json:"qualifier,omitempty"`
+	// Request is the request of the call.
+	Request json.RawMessage `json:"request,omitempty"`
+	// Reply is the reply of the call, if the call succeeded.
+	Reply json.RawMessage `json:"reply,omitempty"`
+	// Error is the error returned by the call, if the call failed.
+	Error *RecordedError `json:"error,omitempty"`
+	// Latency is the total latency of the call.
+	Latency time.Duration `json:"latency"`
+}
+
+// RecordedError is an error returned by a recorded method call.
+type RecordedError struct {
+	// Code is the gRPC status code of the error.
+	Code uint32 `json:"code"`
+	// Message is the error message.
+	Message string `json:"message"`
+}
+
+// RecordRequest takes a snapshot of a request for recording. It must be called
+// before the request is processed, since processing might alter the request.
+// It returns nil if method calls are not being recorded.
+func RecordRequest(req interface{}) []byte {
+	if !dump.isRecording() {
+		return nil
+	}
+	return dump.marshal("request", req)
+}
+
+// RecordMessage records a method call with its request snapshot and reply or error.
+func RecordMessage(kind, name, qualifier string, req []byte, rpl interface{}, latency time.Duration) {
+	if req == nil || !dump.isRecording() {
+		return
+	}
+
+	rec := &Record{
+		Time:      time.Now().Add(-latency),
+		Kind:      kind,
+		Method:    name,
+		Qualifier: qualifier,
+		Request:   req,
+		Latency:   latency,
+	}
+	if e, ok := rpl.(error); ok {
+		s := status.Convert(e)
+		rec.Error = &RecordedError{Code: uint32(s.Code()), Message: s.Message()}
+	} else {
+		if rec.Reply = dump.marshal("reply", rpl); rec.Reply == nil {
+			return
+		}
+	}
+
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		log.Error("failed to record %s: %v", name, err)
+		return
+	}
+
+	dump.q <- &dumpreq{
+		dir:    record,
+		method: name,
+		msg:    raw,
+	}
+}
+
+// ReadRecords reads a recording of method calls.
+func ReadRecords(r io.Reader) ([]*Record, error) {
+	records := []*Record{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		rec := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), rec); err != nil {
+			return nil, dumpError("invalid record on line %d: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, dumpError("failed to read records: %v", err)
+	}
+	return records, nil
+}
+
+// ReadRecordFile reads a recording of method calls from the given file.
+func ReadRecordFile(path string) ([]*Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, dumpError("failed to open recording %q: %v", path, err)
+	}
+	defer f.Close()
+	return ReadRecords(f)
+}
+
+// marshal marshals a redacted request or reply for recording.
+func (d *dumper) marshal(kind string, msg interface{}) []byte {
+	d.RLock()
+	redactor := d.redactor
+	d.RUnlock()
+
+	raw, err := json.Marshal(redactor.redact(msg))
+	if err != nil {
+		log.Error("failed to record %s: %v", kind, err)
+		return nil
+	}
+	return raw
+}
+
+// record writes a recorded method call to the recording file.
+func (d *dumper) record(req *dumpreq) {
+	d.RLock()
+	defer d.RUnlock()
+
+	if d.recfile == nil {
+		return
+	}
+
+	if _, err := d.recfile.Write(append(req.msg.([]byte), '\n')); err != nil {
+		log.Error("failed to write recording file %q: %v", d.recpath, err)
+	}
+}
+
+// isRecording checks if method calls are being recorded.
+func (d *dumper) isRecording() bool {
+	d.RLock()
+	defer d.RUnlock()
+	return d.recording
+}
+
+// openRecording (re)opens the recording file if necessary.
+func (d *dumper) openRecording(path string) {
+	if d.recpath == path && (d.recfile != nil || path == "") {
+		return
+	}
+
+	if d.recfile != nil {
+		log.Info("closing old recording file %q...", d.recpath)
+		d.recfile.Close()
+		d.recfile = nil
+	}
+
+	d.recpath = path
+	d.recording = false
+	if d.recpath == "" {
+		return
+	}
+
+	var err error
+	log.Info("opening new recording file %q...", d.recpath)
+	d.recfile, err = os.OpenFile(d.recpath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		log.Error("failed to open recording file %q: %v", d.recpath, err)
+		return
+	}
+	d.recording = true
+}
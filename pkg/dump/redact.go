@@ -0,0 +1,176 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	re "regexp"
+)
+
+const (
+	// Redacted is the value redacted data is replaced with.
+	Redacted = "<redacted>"
+)
+
+// redactor redacts sensitive data from messages before they are dumped.
+type redactor struct {
+	env         []*re.Regexp // environment variable keys to redact
+	annotations []*re.Regexp // annotation keys to redact
+	mounts      bool         // whether to redact host paths of mounts
+}
+
+// newRedactor creates a redactor for the given key patterns.
+func newRedactor(env, annotations []string, mounts bool) (*redactor, error) {
+	var err error
+
+	r := &redactor{mounts: mounts}
+	if r.env, err = compilePatterns(env); err != nil {
+		return nil, dumpError("invalid environment redaction rule: %v", err)
+	}
+	if r.annotations, err = compilePatterns(annotations); err != nil {
+		return nil, dumpError("invalid annotation redaction rule: %v", err)
+	}
+
+	return r, nil
+}
+
+// compilePatterns compiles key patterns into regexps matching full keys.
+func compilePatterns(patterns []string) ([]*re.Regexp, error) {
+	regexps := []*re.Regexp{}
+	for _, pattern := range patterns {
+		regexp, err := re.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, err
+		}
+		regexps = append(regexps, regexp)
+	}
+	return regexps, nil
+}
+
+// empty checks if the redactor has nothing to redact.
+func (r *redactor) empty() bool {
+	return r == nil || (len(r.env) == 0 && len(r.annotations) == 0 && !r.mounts)
+}
+
+// redact returns a redacted copy of the given message.
+func (r *redactor) redact(msg interface{}) interface{} {
+	if r.empty() {
+		return msg
+	}
+	if _, ok := msg.(error); ok {
+		return msg
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return msg
+	}
+
+	var data interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return msg
+	}
+
+	r.walk(data)
+
+	return data
+}
+
+// walk redacts sensitive data in a generic (JSON-decoded) message.
+func (r *redactor) walk(data interface{}) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			switch key {
+			case "envs":
+				r.redactEnvs(value)
+			case "annotations":
+				r.redactAnnotations(value)
+			case "mounts":
+				r.redactMounts(value)
+			}
+			r.walk(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			r.walk(value)
+		}
+	}
+}
+
+// redactEnvs redacts the values of matching environment variables.
+func (r *redactor) redactEnvs(data interface{}) {
+	envs, ok := data.([]interface{})
+	if !ok {
+		return
+	}
+	for _, e := range envs {
+		env, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if key, ok := env["key"].(string); ok && matches(r.env, key) {
+			if _, ok := env["value"]; ok {
+				env["value"] = Redacted
+			}
+		}
+	}
+}
+
+// redactAnnotations redacts the values of matching annotations.
+func (r *redactor) redactAnnotations(data interface{}) {
+	annotations, ok := data.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for key := range annotations {
+		if matches(r.annotations, key) {
+			annotations[key] = Redacted
+		}
+	}
+}
+
+// redactMounts redacts the host paths of mounts.
+func (r *redactor) redactMounts(data interface{}) {
+	if !r.mounts {
+		return
+	}
+	mounts, ok := data.([]interface{})
+	if !ok {
+		return
+	}
+	for _, m := range mounts {
+		mount, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := mount["host_path"]; ok {
+			mount["host_path"] = Redacted
+		}
+	}
+}
+
+// matches checks if key matches any of the given regexps.
+func matches(regexps []*re.Regexp, key string) bool {
+	for _, regexp := range regexps {
+		if regexp.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dump
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// rotation describes when and how to rotate the dump file.
+type rotation struct {
+	maxSize    int64         // rotate once the file grows larger than this
+	maxAge     time.Duration // rotate once the file gets older than this
+	maxBackups int           // number of rotated files to keep
+}
+
+// enabled checks if rotation is enabled.
+func (r rotation) enabled() bool {
+	return r.maxSize > 0 || r.maxAge > 0
+}
+
+// openFile (re)creates the dump file.
+func (d *dumper) openFile() error {
+	f, err := os.Create(d.path)
+	if err != nil {
+		return dumpError("failed to open file %q: %v", d.path, err)
+	}
+	d.file = f
+	d.size = 0
+	d.opened = time.Now()
+	return nil
+}
+
+// needRotate checks if the dump file needs to be rotated.
+func (d *dumper) needRotate() bool {
+	if !d.rotation.enabled() {
+		return false
+	}
+	if d.rotation.maxSize > 0 && d.size >= d.rotation.maxSize {
+		return true
+	}
+	if d.rotation.maxAge > 0 && time.Since(d.opened) >= d.rotation.maxAge {
+		return true
+	}
+	return false
+}
+
+// rotate rotates the dump file, keeping at most the configured number of backups.
+func (d *dumper) rotate() {
+	log.Info("rotating message dump file %q...", d.path)
+
+	d.file.Close()
+	d.file = nil
+
+	if d.rotation.maxBackups < 1 {
+		os.Remove(d.path)
+	} else {
+		os.Remove(backupName(d.path, d.rotation.maxBackups))
+		for idx := d.rotation.maxBackups - 1; idx > 0; idx-- {
+			os.Rename(backupName(d.path, idx), backupName(d.path, idx+1))
+		}
+		if err := os.Rename(d.path, backupName(d.path, 1)); err != nil {
+			log.Error("failed to rotate message dump file %q: %v", d.path, err)
+		}
+	}
+
+	if err := d.openFile(); err != nil {
+		log.Error("%v", err)
+	}
+}
+
+// backupName returns the name of the given rotated backup of a file.
+func backupName(path string, idx int) string {
+	return path + "." + strconv.Itoa(idx)
+}
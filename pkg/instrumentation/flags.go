@@ -16,6 +16,8 @@ package instrumentation
 
 import (
 	"encoding/json"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -230,8 +232,35 @@ func configNotify(event config.Event, source config.Source) error {
 	return nil
 }
 
+// validateOptions checks candidate options without applying them.
+func validateOptions(cfg interface{}) error {
+	o := cfg.(*options)
+	if o.Sampling < 0 || o.Sampling > 1 {
+		return instrumentationError("invalid Sampling %v, expected a value in [0, 1]", o.Sampling)
+	}
+	if o.ReportPeriod < 0 {
+		return instrumentationError("invalid negative ReportPeriod %v", o.ReportPeriod)
+	}
+	if o.HTTPEndpoint != "" {
+		if _, _, err := net.SplitHostPort(o.HTTPEndpoint); err != nil {
+			return instrumentationError("invalid HTTPEndpoint %q: %v", o.HTTPEndpoint, err)
+		}
+	}
+	if o.JaegerCollector != "" {
+		if _, err := url.Parse(o.JaegerCollector); err != nil {
+			return instrumentationError("invalid JaegerCollector %q: %v", o.JaegerCollector, err)
+		}
+	}
+	if o.JaegerAgent != "" {
+		if _, _, err := net.SplitHostPort(o.JaegerAgent); err != nil {
+			return instrumentationError("invalid JaegerAgent %q: %v", o.JaegerAgent, err)
+		}
+	}
+	return nil
+}
+
 // Register us for for configuration handling.
 func init() {
 	config.Register("instrumentation", "Instrumentation for traces and metrics.",
-		opt, defaultOptions, config.WithNotify(configNotify))
+		opt, defaultOptions, config.WithNotify(configNotify), config.WithValidate(validateOptions))
 }
@@ -104,10 +104,12 @@ func (mux *ServeMux) Unregister(pattern string) (http.Handler, bool) {
 
 // ServeHTTP serves a HTTP request.
 func (mux *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Don't hold the lock while serving, some requests (event streams) are long-lived.
 	mux.RLock()
-	defer mux.RUnlock()
+	m := mux.mux
+	mux.RUnlock()
 	log.Debug("serving %s...", r.URL)
-	mux.mux.ServeHTTP(w, r)
+	m.ServeHTTP(w, r)
 }
 
 // Server is our HTTP server, with support for unregistering handlers.
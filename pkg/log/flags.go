@@ -17,6 +17,7 @@ package log
 import (
 	"encoding/json"
 	"os"
+	"sort"
 	"strings"
 
 	pkgcfg "github.com/intel/cri-resource-manager/pkg/config"
@@ -41,11 +42,18 @@ type options struct {
 	Debug srcmap
 	// LogSource determines if messages are prefixed with the logger source
 	LogSource bool
+	// Levels defines per-source severity levels.
+	Levels levelmap
+	// Format is the output format of messages, text or json.
+	Format string
 }
 
 // srcmap tracks debugging settings for sources.
 type srcmap map[string]bool
 
+// levelmap tracks severity level settings for sources.
+type levelmap map[string]Level
+
 var (
 	// Runtime logging configuration.
 	opt *options
@@ -171,11 +179,107 @@ func (m srcmap) clone() srcmap {
 	return o
 }
 
+// parse parses the given string and updates the levelmap accordingly.
+func (m *levelmap) parse(value string) error {
+	if *m == nil {
+		*m = make(levelmap)
+	}
+	if value = strings.TrimSpace(value); value == "" {
+		return nil
+	}
+
+	prev := levelUnset
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		level, src := levelUnset, ""
+		levelsrc := strings.Split(entry, ":")
+		switch len(levelsrc) {
+		case 2:
+			l, err := ParseLevel(levelsrc[0])
+			if err != nil {
+				return loggerError("invalid level '%s' in level map", levelsrc[0])
+			}
+			level, src = l, strings.TrimSpace(levelsrc[1])
+			prev = level
+		case 1:
+			if prev == levelUnset {
+				return loggerError("missing level for source '%s' in level map", entry)
+			}
+			level, src = prev, strings.TrimSpace(levelsrc[0])
+		default:
+			return loggerError("invalid level spec '%s' in level map", entry)
+		}
+
+		if src == "all" {
+			src = "*"
+		}
+		(*m)[src] = level
+	}
+
+	return nil
+}
+
+// String returns a string representation of the levelmap.
+func (m *levelmap) String() string {
+	bylevel := map[Level][]string{}
+	for src, level := range *m {
+		bylevel[level] = append(bylevel[level], src)
+	}
+	entries := []string{}
+	for level := LevelDebug; level <= LevelFatal; level++ {
+		if sources, ok := bylevel[level]; ok {
+			sort.Strings(sources)
+			entries = append(entries, level.String()+":"+strings.Join(sources, ","))
+		}
+	}
+	return strings.Join(entries, ",")
+}
+
+// MarshalJSON is the JSON marshaller for levelmap.
+func (m levelmap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON is the JSON unmarshaller for levelmap.
+func (m *levelmap) UnmarshalJSON(raw []byte) error {
+	cfgstr := ""
+	if err := json.Unmarshal(raw, &cfgstr); err != nil {
+		return loggerError("failed to unmarshal level map '%s': %v", string(raw), err)
+	}
+	if err := m.parse(cfgstr); err != nil {
+		return loggerError("failed to unmarshal level map '%s': %v", string(raw), err)
+	}
+	return nil
+}
+
+// clone returns a copy of the levelmap.
+func (m levelmap) clone() levelmap {
+	if m == nil {
+		return nil
+	}
+	o := make(levelmap)
+	for src, level := range m {
+		o[src] = level
+	}
+	return o
+}
+
 // configNotify is the configuration change notification callback for options.
 func (o *options) configNotify(event pkgcfg.Event, src pkgcfg.Source) error {
 	deflog.Info("logger configuration %v", event)
 	deflog.Info(" * debugging: %s", o.Debug.String())
 	deflog.Info(" * log source: %v", o.LogSource)
+	deflog.Info(" * levels: %s", o.Levels.String())
+	deflog.Info(" * format: %s", o.Format)
+
+	if o.Format == "" {
+		o.Format = FormatText
+	}
+	if err := o.validate(); err != nil {
+		return err
+	}
 	deflog.InfoBlock(" * klog: ", "%s", o.Klog.String())
 
 	// On the first configuration update event, we record the current values
@@ -204,6 +308,22 @@ func (o *options) configNotify(event pkgcfg.Event, src pkgcfg.Source) error {
 	return o.apply()
 }
 
+// validate checks the options for errors.
+func (o *options) validate() error {
+	switch o.Format {
+	case FormatText, FormatJSON, "":
+	default:
+		return loggerError("invalid log format %q, should be %s or %s",
+			o.Format, FormatText, FormatJSON)
+	}
+	return nil
+}
+
+// validateOptions checks candidate options without applying them.
+func validateOptions(cfg interface{}) error {
+	return cfg.(*options).validate()
+}
+
 // apply applies the options to logging.
 func (o *options) apply() error {
 	log.Lock()
@@ -217,14 +337,16 @@ func (o *options) apply() error {
 	}
 
 	log.setDbgMap(o.Debug.clone())
+	log.setLvlMap(o.Levels.clone())
 	log.setPrefix(prefix)
+	log.setJSON(o.Format == FormatJSON)
 
 	return klogctl.Configure(o.Klog)
 }
 
 // defaultOptions returns our current default runtime options.
 func defaultOptions() interface{} {
-	o := &options{}
+	o := &options{Format: FormatText}
 
 	o.Debug.cloneFrom(defaultDebugFlags)
 	if defaultKlogFlags != nil {
@@ -266,5 +388,5 @@ func init() {
 	}
 
 	pkgcfg.Register(configModule, "logging control", opt, defaultOptions,
-		pkgcfg.WithNotify(opt.configNotify))
+		pkgcfg.WithNotify(opt.configNotify), pkgcfg.WithValidate(validateOptions))
 }
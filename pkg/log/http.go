@@ -0,0 +1,47 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	// LevelsPath is the conventional HTTP path for serving per-source log levels.
+	LevelsPath = "/loglevels"
+)
+
+// ServeLevels serves HTTP requests for querying per-source log levels.
+//
+// A GET request returns the current levels as a JSON object of source-level
+// pairs. The endpoint is served unauthenticated alongside the other
+// instrumentation endpoints, so it is deliberately read-only. Levels can be
+// changed through the logger configuration.
+func ServeLevels(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "unsupported method "+req.Method, http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := json.Marshal(SourceLevels())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(data, '\n'))
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServeLevels(t *testing.T) {
+	SetSourceLevel("http-test", LevelWarn)
+	defer SetSourceLevel("http-test", levelUnset)
+
+	for _, method := range []string{http.MethodPut, http.MethodPost, http.MethodDelete} {
+		req := httptest.NewRequest(method, LevelsPath, strings.NewReader(`{"http-test":"debug"}`))
+		rec := httptest.NewRecorder()
+		ServeLevels(rec, req)
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s: expected status %d, got %d", method, http.StatusMethodNotAllowed, rec.Code)
+		}
+	}
+	if level := SourceLevels()["http-test"]; level != LevelWarn {
+		t.Errorf("expected level %s to be left intact, got %s", LevelWarn, level)
+	}
+
+	rec := httptest.NewRecorder()
+	ServeLevels(rec, httptest.NewRequest(http.MethodGet, LevelsPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET: expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"http-test"`) {
+		t.Errorf("GET: expected levels to include http-test, got %s", rec.Body.String())
+	}
+}
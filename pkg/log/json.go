@@ -0,0 +1,139 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// FormatText is the format for plain text (klog) messages.
+	FormatText = "text"
+	// FormatJSON is the format for JSON messages, one object per line.
+	FormatJSON = "json"
+)
+
+var (
+	// jsonOut is where JSON-formatted messages are written to.
+	jsonOut io.Writer = os.Stderr
+	// jsonLock serializes writing JSON-formatted messages.
+	jsonLock sync.Mutex
+)
+
+// Keys reserved for the fixed fields of JSON-formatted messages.
+var reservedKeys = map[string]struct{}{
+	"time":   {},
+	"level":  {},
+	"source": {},
+	"caller": {},
+	"msg":    {},
+}
+
+// emitJSON emits a JSON-formatted message for the caller depth frames above our caller.
+func (log *logging) emitJSON(l logger, level Level, depth int, fields []interface{}, msg string) {
+	if level < log.level && level != LevelDebug {
+		return
+	}
+
+	entry := make(map[string]interface{}, 5+len(fields)/2)
+	for key, value := range fieldMap(fields) {
+		if _, reserved := reservedKeys[key]; reserved {
+			key = "field." + key
+		}
+		entry[key] = value
+	}
+	entry["time"] = time.Now().Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["source"] = log.sources[l]
+	entry["msg"] = msg
+	if _, file, line, ok := runtime.Caller(depth + 1); ok {
+		entry["caller"] = filepath.Base(file) + ":" + strconv.Itoa(line)
+	}
+
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		raw, _ = json.Marshal(map[string]interface{}{
+			"time":   entry["time"],
+			"level":  entry["level"],
+			"source": entry["source"],
+			"msg":    msg,
+			"error":  fmt.Sprintf("failed to marshal message fields: %v", err),
+		})
+	}
+
+	jsonLock.Lock()
+	jsonOut.Write(append(raw, '\n'))
+	jsonLock.Unlock()
+
+	if level == LevelFatal {
+		klog.Flush()
+		os.Exit(1)
+	}
+}
+
+// fieldMap converts a list of key-value pairs to a map.
+func fieldMap(fields []interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		key := fmt.Sprintf("%v", fields[i])
+		if i+1 < len(fields) {
+			m[key] = fieldValue(fields[i+1])
+		} else {
+			m[key] = nil
+		}
+	}
+	return m
+}
+
+// fieldValue converts a field value to something suitable for JSON encoding.
+func fieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, string, bool,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return v
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// formatFields formats a list of key-value pairs for plain text messages.
+func formatFields(fields []interface{}) string {
+	pairs := make([]string, 0, len(fields)/2+1)
+	for i := 0; i < len(fields); i += 2 {
+		key := fmt.Sprintf("%v", fields[i])
+		if i+1 < len(fields) {
+			pairs = append(pairs, key+"="+strconv.Quote(fmt.Sprintf("%v", fieldValue(fields[i+1]))))
+		} else {
+			pairs = append(pairs, key+"=")
+		}
+	}
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLevelMap(t *testing.T) {
+	tcases := []struct {
+		value    string
+		expected levelmap
+		invalid  bool
+	}{
+		{value: "", expected: levelmap{}},
+		{value: "debug:cache", expected: levelmap{"cache": LevelDebug}},
+		{
+			value:    "warning:all,debug:cache,policy,error:config",
+			expected: levelmap{"*": LevelWarn, "cache": LevelDebug, "policy": LevelDebug, "config": LevelError},
+		},
+		{value: "cache", invalid: true},
+		{value: "verbose:cache", invalid: true},
+		{value: "info:cache:policy", invalid: true},
+	}
+
+	for _, tc := range tcases {
+		m := levelmap{}
+		err := m.parse(tc.value)
+		if tc.invalid {
+			if err == nil {
+				t.Errorf("level map %q: expected error, got none", tc.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("level map %q: unexpected error: %v", tc.value, err)
+			continue
+		}
+		if len(m) != len(tc.expected) {
+			t.Errorf("level map %q: expected %v, got %v", tc.value, tc.expected, m)
+			continue
+		}
+		for src, level := range tc.expected {
+			if m[src] != level {
+				t.Errorf("level map %q: expected %s for %s, got %s", tc.value, level, src, m[src])
+			}
+		}
+		o := levelmap{}
+		if err := o.parse(m.String()); err != nil || len(o) != len(m) {
+			t.Errorf("level map %q: failed to reparse %q (%v)", tc.value, m.String(), err)
+		}
+	}
+}
+
+func TestJSONLogging(t *testing.T) {
+	buf := &bytes.Buffer{}
+	jsonOut = buf
+	log.setJSON(true)
+	defer func() {
+		log.setJSON(false)
+		log.setLvlMap(nil)
+	}()
+
+	l := Get("json-test")
+	SetSourceLevel("json-test", LevelWarn)
+
+	l.Info("suppressed")
+	l.With("pod", "pod0", "container", "ctr0").Warn("hello %s", "world")
+	l.Error("failed")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 messages, got %d: %q", len(lines), buf.String())
+	}
+
+	entry := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("failed to unmarshal message %q: %v", lines[0], err)
+	}
+	expected := map[string]interface{}{
+		"level":     "warning",
+		"source":    "json-test",
+		"msg":       "hello world",
+		"pod":       "pod0",
+		"container": "ctr0",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("expected %s %q, got %q", key, value, entry[key])
+		}
+	}
+	if caller, ok := entry["caller"].(string); !ok || !strings.HasPrefix(caller, "json_test.go:") {
+		t.Errorf("unexpected caller %v", entry["caller"])
+	}
+
+	SetSourceLevel("json-test", LevelDebug)
+	if !l.DebugEnabled() {
+		t.Errorf("expected debug level to enable debugging for source")
+	}
+}
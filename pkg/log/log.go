@@ -15,6 +15,7 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -84,11 +85,20 @@ type Logger interface {
 
 	// Source returns the source name of this Logger.
 	Source() string
+
+	// With returns a Logger which attaches the given key-value pairs to all messages.
+	With(keysAndValues ...interface{}) Logger
 }
 
 // logger implements Logger.
 type logger uint
 
+// fieldLogger implements Logger with extra fields attached to every message.
+type fieldLogger struct {
+	logger
+	fields []interface{}
+}
+
 // logging encapsulates the full runtime state of logging.
 type logging struct {
 	sync.RWMutex
@@ -97,6 +107,9 @@ type logging struct {
 	loggers map[string]logger   // source to logger mapping
 	sources map[logger]string   // logger to source mapping
 	debug   map[logger]struct{} // loggers with debugging enabled
+	lvlmap  levelmap            // per-source severity level configuration
+	levels  map[logger]Level    // per-source severity thresholds
+	json    bool                // emit messages as JSON
 	maxlen  int                 // max source length.
 	forced  bool                // forced global debugging
 	prefix  bool                // prefix messages with logger source
@@ -110,6 +123,7 @@ var log = &logging{
 	sources: make(map[logger]string),
 	aligned: make(map[logger]string),
 	debug:   make(map[logger]struct{}),
+	levels:  make(map[logger]Level),
 }
 
 // Get returns the named Logger.
@@ -152,6 +166,33 @@ func SetLevel(level Level) {
 	log.setLevel(level)
 }
 
+// SetSourceLevel sets the severity level for the source, or clears it if level is unset.
+func SetSourceLevel(source string, level Level) {
+	log.Lock()
+	defer log.Unlock()
+	lvlmap := log.lvlmap.clone()
+	if lvlmap == nil {
+		lvlmap = make(levelmap)
+	}
+	if level == levelUnset {
+		delete(lvlmap, source)
+	} else {
+		lvlmap[source] = level
+	}
+	log.setLvlMap(lvlmap)
+}
+
+// SourceLevels returns the current per-source severity level configuration.
+func SourceLevels() map[string]Level {
+	log.RLock()
+	defer log.RUnlock()
+	levels := make(map[string]Level)
+	for source, level := range log.lvlmap {
+		levels[source] = level
+	}
+	return levels
+}
+
 // Flush flushes any pending log messages.
 func Flush() {
 	log.RLock()
@@ -181,6 +222,44 @@ func (l Level) String() string {
 	return "unknown"
 }
 
+// ParseLevel parses the given string as a severity level.
+func ParseLevel(value string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "panic":
+		return LevelPanic, nil
+	case "fatal":
+		return LevelFatal, nil
+	}
+	return levelUnset, loggerError("invalid severity level %q", value)
+}
+
+// MarshalJSON is the JSON marshaller for Level.
+func (l Level) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.String())
+}
+
+// UnmarshalJSON is the JSON unmarshaller for Level.
+func (l *Level) UnmarshalJSON(raw []byte) error {
+	str := ""
+	if err := json.Unmarshal(raw, &str); err != nil {
+		return loggerError("failed to unmarshal level '%s': %v", string(raw), err)
+	}
+	level, err := ParseLevel(str)
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
 // setLevel sets the logging severity level.
 func (log *logging) setLevel(level Level) error {
 	log.level = level
@@ -225,15 +304,50 @@ func (log *logging) getDebug(source string) bool {
 func (log *logging) setDbgMap(dbgmap srcmap) {
 	log.dbgmap = dbgmap
 	log.debug = make(map[logger]struct{})
-	for source := range log.loggers {
-		state, ok := log.dbgmap[source]
-		if !ok {
-			state = log.dbgmap["*"]
-		}
-		log.setDebug(source, state)
+	for _, l := range log.loggers {
+		log.update(l)
+	}
+}
+
+// setLvlMap updates the per-source severity level configuration of logging.
+func (log *logging) setLvlMap(lvlmap levelmap) {
+	log.lvlmap = lvlmap
+	log.levels = make(map[logger]Level)
+	for _, l := range log.loggers {
+		log.update(l)
+	}
+}
+
+// update updates the debug state and severity threshold of the given logger.
+func (log *logging) update(l logger) {
+	source := log.sources[l]
+
+	state, ok := log.dbgmap[source]
+	if !ok {
+		state = log.dbgmap["*"]
+	}
+	level, ok := log.lvlmap[source]
+	if !ok {
+		level = log.lvlmap["*"]
+	}
+
+	if state || level == LevelDebug {
+		log.debug[l] = struct{}{}
+	} else {
+		delete(log.debug, l)
+	}
+	if level != levelUnset {
+		log.levels[l] = level
+	} else {
+		delete(log.levels, l)
 	}
 }
 
+// setJSON sets the JSON output preference.
+func (log *logging) setJSON(enabled bool) {
+	log.json = enabled
+}
+
 // setPrefix sets the prefix (source) logging preference.
 func (log *logging) setPrefix(prefix bool) {
 	log.prefix = prefix
@@ -285,12 +399,7 @@ func (log *logging) get(source string) logger {
 	log.loggers[source] = l
 	log.sources[l] = source
 	log.align(l)
-
-	state, ok := log.dbgmap[source]
-	if !ok {
-		state = log.dbgmap["*"]
-	}
-	log.setDebug(source, state)
+	log.update(l)
 
 	return l
 }
@@ -319,132 +428,192 @@ func (l logger) Source() string {
 	return log.sources[l]
 }
 
+func (l logger) With(keysAndValues ...interface{}) Logger {
+	return &fieldLogger{logger: l, fields: keysAndValues}
+}
+
 func (l logger) Debug(format string, args ...interface{}) {
+	l.message(LevelDebug, nil, format, args...)
+}
+
+func (l logger) Info(format string, args ...interface{}) {
+	l.message(LevelInfo, nil, format, args...)
+}
+
+func (l logger) Warn(format string, args ...interface{}) {
+	l.message(LevelWarn, nil, format, args...)
+}
+
+func (l logger) Error(format string, args ...interface{}) {
+	l.message(LevelError, nil, format, args...)
+}
+
+func (l logger) Fatal(format string, args ...interface{}) {
+	l.message(LevelFatal, nil, format, args...)
+}
+
+func (l logger) Panic(format string, args ...interface{}) {
+	l.message(LevelPanic, nil, format, args...)
+}
+
+func (l logger) DebugBlock(prefix string, format string, args ...interface{}) {
+	l.block(LevelDebug, nil, prefix, format, args...)
+}
+
+func (l logger) InfoBlock(prefix string, format string, args ...interface{}) {
+	l.block(LevelInfo, nil, prefix, format, args...)
+}
+
+func (l logger) WarnBlock(prefix string, format string, args ...interface{}) {
+	l.block(LevelWarn, nil, prefix, format, args...)
+}
+
+func (l logger) ErrorBlock(prefix string, format string, args ...interface{}) {
+	l.block(LevelError, nil, prefix, format, args...)
+}
+
+// message formats and emits a message, panicking for LevelPanic.
+func (l logger) message(level Level, fields []interface{}, format string, args ...interface{}) {
 	log.RLock()
 	defer log.RUnlock()
 
-	if !log.forced {
-		if _, ok := log.debug[l]; !ok {
-			return
-		}
+	if !log.enabled(l, level) {
+		return
 	}
 
 	msg := fmt.Sprintf(format, args...)
+	log.emit(l, level, 2, fields, msg)
 
-	if log.prefix {
-		klog.InfoDepth(1, levelTag[LevelDebug], log.aligned[l], msg)
-	} else {
-		klog.InfoDepth(1, msg)
+	if level == LevelPanic {
+		panic(msg)
 	}
 }
 
-func (l logger) Info(format string, args ...interface{}) {
-	log.RLock()
-	defer log.RUnlock()
+// block formats and emits a multiline message.
+func (l logger) block(level Level, fields []interface{}, prefix, format string, args ...interface{}) {
+	log.Lock()
+	defer log.Unlock()
 
-	msg := fmt.Sprintf(format, args...)
+	switch level {
+	case LevelDebug, LevelInfo, LevelWarn, LevelError:
+	default:
+		return
+	}
 
-	if log.prefix {
-		klog.InfoDepth(1, levelTag[LevelInfo], log.aligned[l], msg)
-	} else {
-		klog.InfoDepth(1, msg)
+	if !log.enabled(l, level) {
+		return
+	}
+
+	for _, msg := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+		log.emit(l, level, 2, fields, prefix+msg)
 	}
 }
 
-func (l logger) Warn(format string, args ...interface{}) {
-	log.RLock()
-	defer log.RUnlock()
+//
+// fieldLogger
+//
 
-	msg := fmt.Sprintf(format, args...)
+func (f *fieldLogger) With(keysAndValues ...interface{}) Logger {
+	fields := make([]interface{}, 0, len(f.fields)+len(keysAndValues))
+	fields = append(fields, f.fields...)
+	fields = append(fields, keysAndValues...)
+	return &fieldLogger{logger: f.logger, fields: fields}
+}
 
-	if log.prefix {
-		klog.WarningDepth(1, levelTag[LevelWarn], log.aligned[l], msg)
-	} else {
-		klog.WarningDepth(1, msg)
-	}
+func (f *fieldLogger) Debug(format string, args ...interface{}) {
+	f.message(LevelDebug, f.fields, format, args...)
 }
 
-func (l logger) Error(format string, args ...interface{}) {
-	log.RLock()
-	defer log.RUnlock()
+func (f *fieldLogger) Info(format string, args ...interface{}) {
+	f.message(LevelInfo, f.fields, format, args...)
+}
 
-	msg := fmt.Sprintf(format, args...)
-	if log.prefix {
-		klog.ErrorDepth(1, levelTag[LevelError], log.aligned[l], msg)
-	} else {
-		klog.ErrorDepth(1, msg)
-	}
+func (f *fieldLogger) Warn(format string, args ...interface{}) {
+	f.message(LevelWarn, f.fields, format, args...)
 }
 
-func (l logger) Fatal(format string, args ...interface{}) {
-	log.RLock()
-	defer log.RUnlock()
+func (f *fieldLogger) Error(format string, args ...interface{}) {
+	f.message(LevelError, f.fields, format, args...)
+}
 
-	msg := fmt.Sprintf(format, args...)
-	if log.prefix {
-		klog.ExitDepth(1, levelTag[LevelFatal], log.aligned[l], msg)
-	} else {
-		klog.ExitDepth(1, msg)
-	}
+func (f *fieldLogger) Fatal(format string, args ...interface{}) {
+	f.message(LevelFatal, f.fields, format, args...)
 }
 
-func (l logger) Panic(format string, args ...interface{}) {
-	log.RLock()
-	defer log.RUnlock()
+func (f *fieldLogger) Panic(format string, args ...interface{}) {
+	f.message(LevelPanic, f.fields, format, args...)
+}
 
-	msg := fmt.Sprintf(format, args...)
-	if log.prefix {
-		klog.ErrorDepth(1, levelTag[LevelPanic], log.aligned[l], msg)
-	} else {
-		klog.ErrorDepth(1, msg)
-	}
-	panic(msg)
+func (f *fieldLogger) DebugBlock(prefix string, format string, args ...interface{}) {
+	f.block(LevelDebug, f.fields, prefix, format, args...)
 }
 
-func (l logger) DebugBlock(prefix string, format string, args ...interface{}) {
-	if l.DebugEnabled() {
-		l.block(LevelDebug, prefix, format, args...)
-	}
+func (f *fieldLogger) InfoBlock(prefix string, format string, args ...interface{}) {
+	f.block(LevelInfo, f.fields, prefix, format, args...)
 }
 
-func (l logger) InfoBlock(prefix string, format string, args ...interface{}) {
-	l.block(LevelInfo, prefix, format, args...)
+func (f *fieldLogger) WarnBlock(prefix string, format string, args ...interface{}) {
+	f.block(LevelWarn, f.fields, prefix, format, args...)
 }
 
-func (l logger) WarnBlock(prefix string, format string, args ...interface{}) {
-	l.block(LevelWarn, prefix, format, args...)
+func (f *fieldLogger) ErrorBlock(prefix string, format string, args ...interface{}) {
+	f.block(LevelError, f.fields, prefix, format, args...)
 }
 
-func (l logger) ErrorBlock(prefix string, format string, args ...interface{}) {
-	l.block(LevelError, prefix, format, args...)
+//
+// message emission
+//
+
+// enabled checks if a message of the given severity should be emitted for a logger.
+func (log *logging) enabled(l logger, level Level) bool {
+	if level == LevelDebug {
+		if log.forced {
+			return true
+		}
+		_, ok := log.debug[l]
+		return ok
+	}
+	if threshold, ok := log.levels[l]; ok && level < threshold && level < LevelPanic {
+		return false
+	}
+	return true
 }
 
-func (l logger) block(level Level, prefix, format string, args ...interface{}) {
-	log.Lock()
-	defer log.Unlock()
+// emit emits a message for the caller depth frames above our caller.
+func (log *logging) emit(l logger, level Level, depth int, fields []interface{}, msg string) {
+	if len(fields) > 0 {
+		recent.add(l, level, msg+" "+formatFields(fields))
+	} else {
+		recent.add(l, level, msg)
+	}
 
-	var logFn func(int, ...interface{})
+	if log.json {
+		log.emitJSON(l, level, depth+1, fields, msg)
+		return
+	}
+
+	if len(fields) > 0 {
+		msg += " " + formatFields(fields)
+	}
 
+	var logFn func(int, ...interface{})
 	switch level {
 	case LevelDebug, LevelInfo:
 		logFn = klog.InfoDepth
 	case LevelWarn:
 		logFn = klog.WarningDepth
-	case LevelError:
+	case LevelError, LevelPanic:
 		logFn = klog.ErrorDepth
+	case LevelFatal:
+		logFn = klog.ExitDepth
 	default:
 		return
 	}
 
 	if log.prefix {
-		src := log.aligned[l]
-		for _, msg := range strings.Split(fmt.Sprintf(format, args...), "\n") {
-			logFn(2, levelTag[level], src, prefix, msg)
-		}
+		logFn(depth+1, levelTag[level], log.aligned[l], msg)
 	} else {
-		for _, msg := range strings.Split(fmt.Sprintf(format, args...), "\n") {
-			logFn(2, prefix, msg)
-		}
+		logFn(depth+1, msg)
 	}
 }
 
@@ -105,6 +105,10 @@ func (rl *ratelimited) Error(format string, args ...interface{}) {
 	}
 }
 
+func (rl *ratelimited) With(keysAndValues ...interface{}) Logger {
+	return RateLimit(rl.Logger.With(keysAndValues...), rl.rate)
+}
+
 // Get existing message limit or create a new one, shifting out the oldest if window is full.
 func (rl *ratelimited) getMessageLimit(msg string) *goxrate.Limiter {
 	rl.Lock()
@@ -0,0 +1,73 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// RecentMessageCount is the number of recent messages kept in memory.
+	RecentMessageCount = 2048
+)
+
+// recentMessages is a ring buffer of the most recently emitted messages.
+type recentMessages struct {
+	sync.Mutex
+	messages []string // buffered messages
+	next     int      // slot for the next message
+}
+
+// recent keeps our most recently emitted messages.
+var recent = &recentMessages{
+	messages: make([]string, 0, RecentMessageCount),
+}
+
+// RecentMessages returns the most recently emitted messages, oldest first.
+func RecentMessages() []string {
+	return recent.get()
+}
+
+// add adds a message, replacing the oldest one if the buffer is full.
+func (r *recentMessages) add(l logger, level Level, msg string) {
+	entry := time.Now().Format(time.RFC3339Nano) + " " + levelTag[level] + log.sources[l] + ": " + msg
+
+	r.Lock()
+	defer r.Unlock()
+
+	if len(r.messages) < cap(r.messages) {
+		r.messages = append(r.messages, entry)
+	} else {
+		r.messages[r.next] = entry
+	}
+	r.next = (r.next + 1) % cap(r.messages)
+}
+
+// get returns the buffered messages, oldest first.
+func (r *recentMessages) get() []string {
+	r.Lock()
+	defer r.Unlock()
+
+	messages := make([]string, 0, len(r.messages))
+	if len(r.messages) == cap(r.messages) {
+		messages = append(messages, r.messages[r.next:]...)
+		messages = append(messages, r.messages[:r.next]...)
+	} else {
+		messages = append(messages, r.messages...)
+	}
+
+	return messages
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Intel Corporation. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRecentMessages(t *testing.T) {
+	r := &recentMessages{messages: make([]string, 0, 4)}
+	l := log.get("recent-test")
+
+	for i := 0; i < 3; i++ {
+		r.add(l, LevelInfo, fmt.Sprintf("message #%d", i))
+	}
+	checkRecent(t, r.get(), 0, 3)
+
+	for i := 3; i < 10; i++ {
+		r.add(l, LevelInfo, fmt.Sprintf("message #%d", i))
+	}
+	checkRecent(t, r.get(), 6, 10)
+}
+
+func checkRecent(t *testing.T, messages []string, first, last int) {
+	if len(messages) != last-first {
+		t.Fatalf("got %d messages, expected %d", len(messages), last-first)
+	}
+	for i, msg := range messages {
+		expected := fmt.Sprintf("recent-test: message #%d", first+i)
+		if !strings.HasSuffix(msg, expected) {
+			t.Errorf("got message %q, expected it to end with %q", msg, expected)
+		}
+	}
+}
@@ -234,9 +234,20 @@ type Cache struct {
 	cpus  IDSet     // CPUs sharing this cache
 }
 
+// sysRoot is the sysfs root path DiscoverSystem() uses.
+var sysRoot = SysfsRootPath
+
+// SetSysRoot sets the sysfs root path used by DiscoverSystem().
+func SetSysRoot(path string) {
+	if path == "" {
+		path = SysfsRootPath
+	}
+	sysRoot = path
+}
+
 // DiscoverSystem performs discovery of the running systems details.
 func DiscoverSystem(args ...DiscoveryFlag) (System, error) {
-	return DiscoverSystemAt(SysfsRootPath, args...)
+	return DiscoverSystemAt(sysRoot, args...)
 }
 
 // DiscoverSystemAt performs discovery of the running systems details from sysfs mounted at path.
@@ -465,6 +465,8 @@ launch() { # script API
     local target="$1"
     local launch_cmd
     local adjustment_schema="$HOST_PROJECT_DIR/pkg/apis/resmgr/v1alpha1/adjustment-schema.yaml"
+    local config_schema="$HOST_PROJECT_DIR/pkg/apis/resmgr/v1alpha1/resourcemanagerconfig-schema.yaml"
+    local topology_schema="$HOST_PROJECT_DIR/pkg/apis/resmgr/v1alpha1/noderesourcetopology-schema.yaml"
     local cri_resmgr_config_option="-${cri_resmgr_config:-force}-config"
     case $target in
         "cri-resmgr")
@@ -490,6 +492,12 @@ launch() { # script API
             host-command "$SCP \"$adjustment_schema\" $VM_SSH_USER@$VM_IP:" ||
                 command-error "copying \"$adjustment_schema\" to VM failed"
             vm-command "kubectl delete -f $(basename "$adjustment_schema"); kubectl create -f $(basename "$adjustment_schema")"
+            host-command "$SCP \"$config_schema\" $VM_SSH_USER@$VM_IP:" ||
+                command-error "copying \"$config_schema\" to VM failed"
+            vm-command "kubectl delete -f $(basename "$config_schema"); kubectl create -f $(basename "$config_schema")"
+            host-command "$SCP \"$topology_schema\" $VM_SSH_USER@$VM_IP:" ||
+                command-error "copying \"$topology_schema\" to VM failed"
+            vm-command "kubectl delete -f $(basename "$topology_schema"); kubectl create -f $(basename "$topology_schema")"
             launch_cmd="NODE_NAME=\$(hostname) cri-resmgr-agent -kubeconfig /root/.kube/config $cri_resmgr_agent_extra_args"
             vm-command-q "echo '$launch_cmd' >cri-resmgr-agent.launch.sh; rm -f cri-resmgr-agent.output.txt"
             vm-command "$launch_cmd >cri-resmgr-agent.output.txt 2>&1 &"